@@ -28,7 +28,10 @@ export default function () {
     password: password,
   };
 
-  const params = getRequestParams('create-user');
+  // タイムアウト時の再試行で重複作成しないよう、イテレーションごとに冪等キーを付ける
+  const params = getRequestParams('create-user', {
+    'Idempotency-Key': `k6-create-user-${__VU}-${__ITER}-${Date.now()}`,
+  });
 
   // リクエスト実行とレスポンス時間測定
   const startTime = new Date();
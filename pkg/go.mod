@@ -2,6 +2,12 @@ module github.com/lot-koichi/sre-skill-up-project/pkg
 
 go 1.24.6
 
-require go.uber.org/zap v1.27.0
+require (
+	go.opentelemetry.io/otel/trace v1.38.0
+	go.uber.org/zap v1.27.0
+)
 
-require go.uber.org/multierr v1.10.0 // indirect
+require (
+	go.opentelemetry.io/otel v1.38.0 // indirect
+	go.uber.org/multierr v1.10.0 // indirect
+)
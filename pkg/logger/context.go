@@ -0,0 +1,31 @@
+package logger
+
+import (
+	"context"
+	"slices"
+
+	"go.uber.org/zap"
+)
+
+type contextFieldsKey struct{}
+
+// WithContextFields returns a copy of ctx carrying the fields in addition to those it already carries.
+// Every entry logged with the returned context includes them, so request-scoped values such as the
+// request ID need to be attached only once, by a middleware.
+func WithContextFields(ctx context.Context, fields ...zap.Field) context.Context {
+	if len(fields) == 0 {
+		return ctx
+	}
+	// 親のコンテキストのフィールドを書き換えないよう複製してから追加する
+	carried := slices.Concat(FieldsFromContext(ctx), fields)
+	return context.WithValue(ctx, contextFieldsKey{}, carried)
+}
+
+// FieldsFromContext returns the fields attached to ctx by WithContextFields
+func FieldsFromContext(ctx context.Context) []zap.Field {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(contextFieldsKey{}).([]zap.Field)
+	return fields
+}
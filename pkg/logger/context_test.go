@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithContextFields(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	l := New(zap.New(core), DefaultRedactionConfig())
+
+	parent := WithContextFields(context.Background(), zap.String("request_id", "req-1"))
+	child := WithContextFields(parent, zap.String("user_id", "user-1"))
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x4b, 0xf9},
+		SpanID:  trace.SpanID{0x00, 0xf0},
+	})
+	child = trace.ContextWithSpanContext(child, spanContext)
+
+	l.Info(child, "child", zap.String("key", "value"))
+	l.Info(parent, "parent")
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	want := map[string]string{
+		"key":        "value",
+		"request_id": "req-1",
+		"user_id":    "user-1",
+		"trace_id":   spanContext.TraceID().String(),
+		"span_id":    spanContext.SpanID().String(),
+	}
+	got := entries[0].ContextMap()
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("child entry: %s = %v, want %q", key, got[key], value)
+		}
+	}
+	// 子のコンテキストで追加したフィールドは親に漏れない
+	if _, ok := entries[1].ContextMap()["user_id"]; ok {
+		t.Errorf("parent entry has user_id: %v", entries[1].ContextMap())
+	}
+	if entries[1].ContextMap()["request_id"] != "req-1" {
+		t.Errorf("parent entry: request_id = %v", entries[1].ContextMap()["request_id"])
+	}
+}
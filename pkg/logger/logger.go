@@ -3,6 +3,7 @@ package logger
 import (
 	"context"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -13,15 +14,24 @@ type Logger interface {
 	Warn(ctx context.Context, msg string, fields ...zap.Field)
 	Error(ctx context.Context, msg string, fields ...zap.Field)
 	Debug(ctx context.Context, msg string, fields ...zap.Field)
+	// Fatal logs the message and exits the process; use it only while starting up
+	Fatal(ctx context.Context, msg string, fields ...zap.Field)
 	WithFields(fields ...zap.Field) Logger
+	// Sync flushes any buffered log entries
+	Sync() error
 }
 
 type logger struct {
 	*zap.Logger
+	redactor *redactor
 }
 
-// NewLogger creates a new logger instance based on environment
-func NewLogger(env string) (Logger, error) {
+// NewLogger creates a new logger instance based on environment; personal data is redacted according to redaction
+func NewLogger(env string, redaction RedactionConfig) (Logger, error) {
+	if err := redaction.Validate(); err != nil {
+		return nil, err
+	}
+
 	var config zap.Config
 	if env == "production" {
 		config = NewProductionConfig()
@@ -37,7 +47,20 @@ func NewLogger(env string) (Logger, error) {
 		return nil, err
 	}
 
-	return &logger{Logger: zapLogger}, nil
+	return &logger{Logger: zapLogger, redactor: newRedactor(redaction)}, nil
+}
+
+// New wraps an already configured zap logger, such as one observing entries in tests; redaction must be valid
+func New(zapLogger *zap.Logger, redaction RedactionConfig) Logger {
+	return &logger{
+		Logger:   zapLogger.WithOptions(zap.AddCallerSkip(1)),
+		redactor: newRedactor(redaction),
+	}
+}
+
+// NewNop returns a logger that discards every entry
+func NewNop() Logger {
+	return New(zap.NewNop(), DefaultRedactionConfig())
 }
 
 // NewProductionConfig returns production logging configuration
@@ -60,27 +83,55 @@ func NewDevelopmentConfig() zap.Config {
 }
 
 func (l *logger) Info(ctx context.Context, msg string, fields ...zap.Field) {
-	l.Logger.Info(msg, append(fields, extractTraceID(ctx)...)...)
+	l.Logger.Info(msg, l.fields(ctx, fields)...)
 }
 
 func (l *logger) Warn(ctx context.Context, msg string, fields ...zap.Field) {
-	l.Logger.Warn(msg, append(fields, extractTraceID(ctx)...)...)
+	l.Logger.Warn(msg, l.fields(ctx, fields)...)
 }
 
 func (l *logger) Error(ctx context.Context, msg string, fields ...zap.Field) {
-	l.Logger.Error(msg, append(fields, extractTraceID(ctx)...)...)
+	l.Logger.Error(msg, l.fields(ctx, fields)...)
 }
 
 func (l *logger) Debug(ctx context.Context, msg string, fields ...zap.Field) {
-	l.Logger.Debug(msg, append(fields, extractTraceID(ctx)...)...)
+	l.Logger.Debug(msg, l.fields(ctx, fields)...)
+}
+
+func (l *logger) Fatal(ctx context.Context, msg string, fields ...zap.Field) {
+	l.Logger.Fatal(msg, l.fields(ctx, fields)...)
 }
 
 func (l *logger) WithFields(fields ...zap.Field) Logger {
-	return &logger{Logger: l.Logger.With(fields...)}
+	return &logger{Logger: l.Logger.With(l.redactor.redact(fields)...), redactor: l.redactor}
+}
+
+// fields returns the fields of an entry logged with ctx, with personal data redacted
+func (l *logger) fields(ctx context.Context, fields []zap.Field) []zap.Field {
+	return l.redactor.redact(withContext(ctx, fields))
 }
 
-// extractTraceID extracts trace ID from context if present
+// withContext appends the request-scoped fields and the trace of ctx to the fields of an entry
+func withContext(ctx context.Context, fields []zap.Field) []zap.Field {
+	contextFields := FieldsFromContext(ctx)
+	traceFields := extractTraceID(ctx)
+	if len(contextFields) == 0 && len(traceFields) == 0 {
+		return fields
+	}
+	all := make([]zap.Field, 0, len(fields)+len(contextFields)+len(traceFields))
+	all = append(all, fields...)
+	all = append(all, contextFields...)
+	return append(all, traceFields...)
+}
+
+// extractTraceID returns the trace_id and span_id of the OpenTelemetry span in ctx, if any
 func extractTraceID(ctx context.Context) []zap.Field {
-	// TODO: Extract trace ID from context when OpenTelemetry is integrated
-	return nil
-}
\ No newline at end of file
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+	return []zap.Field{
+		zap.String("trace_id", spanContext.TraceID().String()),
+		zap.String("span_id", spanContext.SpanID().String()),
+	}
+}
@@ -0,0 +1,190 @@
+package logger
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// RedactionMode tells what the logger writes in place of personal data
+type RedactionMode string
+
+const (
+	// RedactionMask replaces the value with a fixed mask; emails keep their domain
+	RedactionMask RedactionMode = "mask"
+	// RedactionHash replaces the value with a keyed hash, so that entries about the same person can still be correlated
+	RedactionHash RedactionMode = "hash"
+	// RedactionDrop removes the field from the entry
+	RedactionDrop RedactionMode = "drop"
+)
+
+// SensitiveKindEmail is the kind of email addresses, which are masked as "a***@example.com"
+const SensitiveKindEmail = "email"
+
+// redactedMask is written in place of masked values other than emails; it does not tell the length of the value
+const redactedMask = "***"
+
+// hashedLength is the number of hex characters of the hash written by RedactionHash
+const hashedLength = 16
+
+// Sensitive is implemented by types holding personal data, such as email addresses and names.
+// The logger redacts fields holding such values whatever constructor created them, e.g. zap.Any.
+type Sensitive interface {
+	// SensitiveKind returns SensitiveKindEmail for email addresses, which are masked keeping their domain, or any other kind
+	SensitiveKind() string
+}
+
+// RedactionConfig is the redaction policy of the logger
+type RedactionConfig struct {
+	Mode RedactionMode
+	// HashKey is the HMAC key of RedactionHash; a plain hash of a guessable value such as an email could be reversed
+	HashKey []byte
+	// Keys are the keys of plain string fields that hold personal data, e.g. zap.String("email", ...)
+	Keys []string
+}
+
+// DefaultRedactionConfig masks the helpers below, Sensitive values and the fields holding personal data or credentials
+func DefaultRedactionConfig() RedactionConfig {
+	return RedactionConfig{
+		Mode: RedactionMask,
+		Keys: []string{
+			"email", "name",
+			"password", "token", "access_token", "refresh_token", "secret", "authorization",
+		},
+	}
+}
+
+// ParseRedactionMode parses the mode set in configuration, e.g. "hash"
+func ParseRedactionMode(s string) (RedactionMode, error) {
+	switch mode := RedactionMode(s); mode {
+	case RedactionMask, RedactionHash, RedactionDrop:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown redaction mode: %q", s)
+	}
+}
+
+// Validate reports whether the logger can apply the policy
+func (c RedactionConfig) Validate() error {
+	if _, err := ParseRedactionMode(string(c.Mode)); err != nil {
+		return err
+	}
+	if c.Mode == RedactionHash && len(c.HashKey) == 0 {
+		return errors.New("redaction mode hash requires a hash key")
+	}
+	return nil
+}
+
+// Email creates a field holding an email address, which the logger redacts
+func Email(key, value string) zap.Field {
+	return zap.Stringer(key, sensitiveValue{kind: SensitiveKindEmail, value: value})
+}
+
+// Name creates a field holding the name of a person, which the logger redacts
+func Name(key, value string) zap.Field {
+	return zap.Stringer(key, sensitiveValue{kind: "name", value: value})
+}
+
+// Redacted creates a field holding any other personal data, such as an IP address, which the logger redacts
+func Redacted(key, value string) zap.Field {
+	return zap.Stringer(key, sensitiveValue{kind: "sensitive", value: value})
+}
+
+// sensitiveValue is the value of the fields created by the helpers above
+type sensitiveValue struct {
+	kind  string
+	value string
+}
+
+// String masks the value, so that it does not leak even if the field is written by a zap logger without redaction
+func (v sensitiveValue) String() string {
+	return mask(v.kind, v.value)
+}
+
+// redactor applies a RedactionConfig to the fields of an entry
+type redactor struct {
+	mode    RedactionMode
+	hashKey []byte
+	keys    map[string]bool
+}
+
+func newRedactor(cfg RedactionConfig) *redactor {
+	keys := make(map[string]bool, len(cfg.Keys))
+	for _, key := range cfg.Keys {
+		keys[key] = true
+	}
+	return &redactor{
+		mode:    cfg.Mode,
+		hashKey: cfg.HashKey,
+		keys:    keys,
+	}
+}
+
+// redact returns the fields with personal data redacted; fields is never modified, as it may be the caller's slice
+func (r *redactor) redact(fields []zap.Field) []zap.Field {
+	var redacted []zap.Field
+	for i, field := range fields {
+		kind, value, ok := r.sensitive(field)
+		if !ok {
+			if redacted != nil {
+				redacted = append(redacted, field)
+			}
+			continue
+		}
+		if redacted == nil {
+			redacted = make([]zap.Field, i, len(fields))
+			copy(redacted, fields[:i])
+		}
+		switch r.mode {
+		case RedactionDrop:
+		case RedactionHash:
+			redacted = append(redacted, zap.String(field.Key, r.hash(value)))
+		default:
+			redacted = append(redacted, zap.String(field.Key, mask(kind, value)))
+		}
+	}
+	if redacted == nil {
+		return fields
+	}
+	return redacted
+}
+
+// sensitive returns the kind and the raw value of the field if it holds personal data
+func (r *redactor) sensitive(field zap.Field) (string, string, bool) {
+	switch v := field.Interface.(type) {
+	case sensitiveValue:
+		return v.kind, v.value, true
+	case Sensitive:
+		return v.SensitiveKind(), fmt.Sprint(v), true
+	}
+	if field.Type == zapcore.StringType && r.keys[field.Key] {
+		return field.Key, field.String, true
+	}
+	return "", "", false
+}
+
+// hash returns the truncated HMAC-SHA256 of the value
+func (r *redactor) hash(value string) string {
+	mac := hmac.New(sha256.New, r.hashKey)
+	mac.Write([]byte(value))
+	return "hmac:" + hex.EncodeToString(mac.Sum(nil))[:hashedLength]
+}
+
+// mask hides the value; emails keep the first character and the domain, which helps to tell test accounts from real ones
+func mask(kind, value string) string {
+	if kind != SensitiveKindEmail {
+		return redactedMask
+	}
+	local, domain, ok := strings.Cut(value, "@")
+	if !ok || local == "" {
+		return redactedMask
+	}
+	first := []rune(local)[0]
+	return string(first) + redactedMask + "@" + domain
+}
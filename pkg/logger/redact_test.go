@@ -0,0 +1,145 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// testEmail is a type holding personal data, like the domain types of the services
+type testEmail string
+
+func (testEmail) SensitiveKind() string { return SensitiveKindEmail }
+
+// personalData are the raw values that must never reach the encoder
+var personalData = []string{"alice@example.com", "Alice Smith", "192.0.2.1", "s3cret-password", "reset-token"}
+
+// logPersonalData logs every kind of field holding personal data and returns what the JSON encoder wrote
+func logPersonalData(t *testing.T, cfg RedactionConfig) []map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&buf), zapcore.DebugLevel)
+	l := New(zap.New(core), cfg)
+
+	ctx := WithContextFields(context.Background(), Email("caller_email", "alice@example.com"))
+	l.Info(ctx, "helpers",
+		Email("email", "alice@example.com"),
+		Name("name", "Alice Smith"),
+		Redacted("subject", "192.0.2.1"))
+	l.Warn(context.Background(), "sensitive type", zap.Any("user_email", testEmail("alice@example.com")))
+	l.Error(context.Background(), "keys", zap.String("email", "alice@example.com"), zap.String("password", "s3cret-password"), zap.String("token", "reset-token"))
+	l.WithFields(zap.String("name", "Alice Smith")).Debug(context.Background(), "with fields")
+
+	for _, raw := range personalData {
+		if strings.Contains(buf.String(), raw) {
+			t.Fatalf("%q reached the encoder:\n%s", raw, buf.String())
+		}
+	}
+
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid entry %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 entries, got %d", len(entries))
+	}
+	return entries
+}
+
+func TestRedaction_Mask(t *testing.T) {
+	entries := logPersonalData(t, DefaultRedactionConfig())
+
+	want := []map[string]any{
+		{"email": "a***@example.com", "name": "***", "subject": "***", "caller_email": "a***@example.com"},
+		{"user_email": "a***@example.com"},
+		{"email": "a***@example.com", "password": "***", "token": "***"},
+		{"name": "***"},
+	}
+	for i, fields := range want {
+		for key, value := range fields {
+			if entries[i][key] != value {
+				t.Errorf("entry %d: %s = %v, want %q", i, key, entries[i][key], value)
+			}
+		}
+	}
+}
+
+func TestRedaction_Hash(t *testing.T) {
+	cfg := DefaultRedactionConfig()
+	cfg.Mode = RedactionHash
+	cfg.HashKey = []byte("test-key")
+	entries := logPersonalData(t, cfg)
+
+	hashed := entries[0]["email"]
+	if s, ok := hashed.(string); !ok || !strings.HasPrefix(s, "hmac:") || len(s) != len("hmac:")+hashedLength {
+		t.Fatalf("email = %v, want a truncated hmac", hashed)
+	}
+	// 同じ値は同じハッシュになり、ログをまたいで突き合わせられる
+	for _, got := range []any{entries[0]["caller_email"], entries[1]["user_email"], entries[2]["email"]} {
+		if got != hashed {
+			t.Errorf("hash of the same email = %v, want %v", got, hashed)
+		}
+	}
+	if entries[0]["name"] == hashed {
+		t.Errorf("name and email have the same hash %v", hashed)
+	}
+}
+
+func TestRedaction_Drop(t *testing.T) {
+	cfg := DefaultRedactionConfig()
+	cfg.Mode = RedactionDrop
+	entries := logPersonalData(t, cfg)
+
+	for i, entry := range entries {
+		for _, key := range []string{"email", "name", "subject", "caller_email", "user_email", "password", "token"} {
+			if _, ok := entry[key]; ok {
+				t.Errorf("entry %d has %s: %v", i, key, entry)
+			}
+		}
+	}
+	if entries[0]["msg"] != "helpers" {
+		t.Errorf("entry 0: msg = %v", entries[0]["msg"])
+	}
+}
+
+func TestRedaction_HelpersWithoutLogger(t *testing.T) {
+	// pkg/logger を経由せず zap に直接渡しても生の値は出力しない
+	var buf bytes.Buffer
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&buf), zapcore.InfoLevel)
+	zap.New(core).Info("raw zap", Email("email", "alice@example.com"), Name("name", "Alice Smith"))
+
+	for _, raw := range personalData {
+		if strings.Contains(buf.String(), raw) {
+			t.Fatalf("%q reached the encoder:\n%s", raw, buf.String())
+		}
+	}
+}
+
+func TestRedactionConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     RedactionConfig
+		wantErr bool
+	}{
+		{name: "mask", cfg: RedactionConfig{Mode: RedactionMask}},
+		{name: "hash with key", cfg: RedactionConfig{Mode: RedactionHash, HashKey: []byte("key")}},
+		{name: "hash without key", cfg: RedactionConfig{Mode: RedactionHash}, wantErr: true},
+		{name: "unknown mode", cfg: RedactionConfig{Mode: "plain"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS notification_jobs;
+DROP INDEX IF EXISTS idx_notification_jobs_user_id;
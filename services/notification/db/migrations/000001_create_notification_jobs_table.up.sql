@@ -0,0 +1,16 @@
+-- users テーブルは User Service 側のDBにあるため user_id に外部キーは張らない
+CREATE TABLE notification_jobs (
+  id BIGSERIAL PRIMARY KEY,
+  correlation_id UUID UNIQUE NOT NULL,
+  user_id UUID NOT NULL,
+  channel TEXT NOT NULL,
+  destination TEXT NOT NULL,
+  template TEXT NOT NULL,
+  variables JSONB NOT NULL DEFAULT '{}',
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed')),
+  retry_count INT NOT NULL DEFAULT 0,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  sent_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX idx_notification_jobs_user_id ON notification_jobs(user_id);
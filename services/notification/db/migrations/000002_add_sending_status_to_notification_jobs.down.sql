@@ -0,0 +1,3 @@
+UPDATE notification_jobs SET status = 'pending' WHERE status = 'sending';
+ALTER TABLE notification_jobs DROP CONSTRAINT notification_jobs_status_check;
+ALTER TABLE notification_jobs ADD CONSTRAINT notification_jobs_status_check CHECK (status IN ('pending', 'sent', 'failed'));
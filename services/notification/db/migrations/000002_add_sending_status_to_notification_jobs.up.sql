@@ -0,0 +1,3 @@
+-- 送信中のジョブを 'sending' にして、重複配信されたイベントが同時に送信しないようにする
+ALTER TABLE notification_jobs DROP CONSTRAINT notification_jobs_status_check;
+ALTER TABLE notification_jobs ADD CONSTRAINT notification_jobs_status_check CHECK (status IN ('pending', 'sending', 'sent', 'failed'));
@@ -0,0 +1,30 @@
+-- name: CreateNotificationJob :one
+INSERT INTO notification_jobs (
+    correlation_id,
+    user_id,
+    channel,
+    destination,
+    template,
+    variables
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (correlation_id) DO NOTHING
+RETURNING *;
+
+-- name: GetNotificationJobByCorrelationID :one
+SELECT * FROM notification_jobs WHERE correlation_id = $1;
+
+-- name: ClaimNotificationJob :one
+-- 送信前にジョブを 'sending' にする。行が返ったときだけ送信してよい
+-- 送信中にプロセスが落ちたジョブは 5 分経てば再び取得できる
+UPDATE notification_jobs SET status = 'sending', updated_at = NOW()
+WHERE id = $1
+  AND (status IN ('pending', 'failed') OR (status = 'sending' AND updated_at < NOW() - INTERVAL '5 minutes'))
+RETURNING id;
+
+-- name: MarkNotificationJobSent :exec
+UPDATE notification_jobs SET status = 'sent', sent_at = NOW(), updated_at = NOW() WHERE id = $1;
+
+-- name: MarkNotificationJobFailed :exec
+UPDATE notification_jobs SET status = 'failed', retry_count = retry_count + 1, updated_at = NOW() WHERE id = $1;
@@ -0,0 +1,15 @@
+package domain
+
+import "fmt"
+
+var (
+	ErrInvalidEvent       = NewError("[N001]invalid event")
+	ErrInvalidPayload     = NewError("[N002]invalid event payload")
+	ErrUnsupportedVersion = NewError("[N003]unsupported event version")
+	ErrNotFound           = NewError("[N004]not found")
+	ErrInvalidJob         = NewError("[N005]invalid notification job")
+)
+
+func NewError(message string) error {
+	return fmt.Errorf("domain: %s", message)
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EventTypeUserCreated = "user.created"
+	// UserEventVersion is the user event schema version this service understands
+	UserEventVersion = 1
+)
+
+// UserEvent is the event envelope published by the User Service
+type UserEvent struct {
+	EventID    uuid.UUID       `json:"event_id"`
+	EventType  string          `json:"event_type"`
+	Version    int             `json:"version"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Producer   string          `json:"producer"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// UserCreatedPayload is the payload of user.created events
+type UserCreatedPayload struct {
+	UserID uuid.UUID `json:"user_id"`
+	Email  string    `json:"email"`
+	Name   string    `json:"name"`
+}
+
+// Validate validates the event envelope
+func (e *UserEvent) Validate() error {
+	if e.EventID == uuid.Nil {
+		return fmt.Errorf("event_id is empty: %w", ErrInvalidEvent)
+	}
+	if e.EventType == "" {
+		return fmt.Errorf("event_type is empty: %w", ErrInvalidEvent)
+	}
+	return nil
+}
+
+// UserCreatedPayload decodes the payload of a user.created event
+func (e *UserEvent) UserCreatedPayload() (*UserCreatedPayload, error) {
+	if e.Version != UserEventVersion {
+		return nil, fmt.Errorf("version %d: %w", e.Version, ErrUnsupportedVersion)
+	}
+
+	var payload UserCreatedPayload
+	if err := json.Unmarshal(e.Payload, &payload); err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrInvalidPayload)
+	}
+	if payload.UserID == uuid.Nil || payload.Email == "" {
+		return nil, fmt.Errorf("user_id and email are required: %w", ErrInvalidPayload)
+	}
+	return &payload, nil
+}
@@ -0,0 +1,100 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/services/notification/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newUserCreatedEvent(t *testing.T, payload any) *domain.UserEvent {
+	t.Helper()
+	raw, err := json.Marshal(payload)
+	require.NoError(t, err)
+	return &domain.UserEvent{
+		EventID:   uuid.New(),
+		EventType: domain.EventTypeUserCreated,
+		Version:   domain.UserEventVersion,
+		Producer:  "user-service",
+		Payload:   raw,
+	}
+}
+
+func TestUserEvent_Validate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		event   domain.UserEvent
+		wantErr error
+	}{
+		{
+			name:  "正常系：必須項目あり",
+			event: domain.UserEvent{EventID: uuid.New(), EventType: domain.EventTypeUserCreated},
+		},
+		{
+			name:    "異常系：event_idが空",
+			event:   domain.UserEvent{EventType: domain.EventTypeUserCreated},
+			wantErr: domain.ErrInvalidEvent,
+		},
+		{
+			name:    "異常系：event_typeが空",
+			event:   domain.UserEvent{EventID: uuid.New()},
+			wantErr: domain.ErrInvalidEvent,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.event.Validate()
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUserEvent_UserCreatedPayload(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("正常系：ペイロードをデコードできる", func(t *testing.T) {
+		event := newUserCreatedEvent(t, map[string]any{"user_id": userID, "email": "new@example.com", "name": "New User"})
+
+		payload, err := event.UserCreatedPayload()
+
+		require.NoError(t, err)
+		assert.Equal(t, userID, payload.UserID)
+		assert.Equal(t, "new@example.com", payload.Email)
+		assert.Equal(t, "New User", payload.Name)
+	})
+
+	t.Run("異常系：未対応のバージョン", func(t *testing.T) {
+		event := newUserCreatedEvent(t, map[string]any{"user_id": userID, "email": "new@example.com"})
+		event.Version = 2
+
+		_, err := event.UserCreatedPayload()
+
+		assert.ErrorIs(t, err, domain.ErrUnsupportedVersion)
+	})
+
+	t.Run("異常系：emailが空", func(t *testing.T) {
+		event := newUserCreatedEvent(t, map[string]any{"user_id": userID})
+
+		_, err := event.UserCreatedPayload()
+
+		assert.ErrorIs(t, err, domain.ErrInvalidPayload)
+	})
+
+	t.Run("異常系：JSONが壊れている", func(t *testing.T) {
+		event := newUserCreatedEvent(t, map[string]any{})
+		event.Payload = []byte(`{"user_id":`)
+
+		_, err := event.UserCreatedPayload()
+
+		assert.ErrorIs(t, err, domain.ErrInvalidPayload)
+	})
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Channel string
+
+const (
+	ChannelEmail Channel = "email"
+)
+
+type JobStatus string
+
+const (
+	JobStatusPending JobStatus = "pending"
+	JobStatusSending JobStatus = "sending"
+	JobStatusSent    JobStatus = "sent"
+	JobStatusFailed  JobStatus = "failed"
+)
+
+const (
+	TemplateWelcome = "welcome"
+)
+
+// NotificationJob is a notification to be delivered; CorrelationID is the idempotency key
+type NotificationJob struct {
+	ID            int64
+	CorrelationID uuid.UUID
+	UserID        uuid.UUID
+	Channel       Channel
+	Destination   string
+	Template      string
+	Variables     map[string]string
+	Status        JobStatus
+	RetryCount    int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	SentAt        *time.Time
+}
+
+// NewWelcomeEmailJob creates a welcome email job for a newly created user
+func NewWelcomeEmailJob(correlationID uuid.UUID, payload *UserCreatedPayload) *NotificationJob {
+	return &NotificationJob{
+		CorrelationID: correlationID,
+		UserID:        payload.UserID,
+		Channel:       ChannelEmail,
+		Destination:   payload.Email,
+		Template:      TemplateWelcome,
+		Variables: map[string]string{
+			"name": payload.Name,
+		},
+		Status: JobStatusPending,
+	}
+}
+
+// Validate validates the notification job
+func (j *NotificationJob) Validate() error {
+	if j.CorrelationID == uuid.Nil {
+		return fmt.Errorf("correlation_id is empty: %w", ErrInvalidJob)
+	}
+	if j.UserID == uuid.Nil {
+		return fmt.Errorf("user_id is empty: %w", ErrInvalidJob)
+	}
+	if j.Destination == "" {
+		return fmt.Errorf("destination is empty: %w", ErrInvalidJob)
+	}
+	return nil
+}
+
+// IsSent reports whether the job has already been delivered
+func (j *NotificationJob) IsSent() bool {
+	return j.Status == JobStatusSent
+}
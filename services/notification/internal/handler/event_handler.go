@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/lot-koichi/sre-skill-up-project/services/notification/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/notification/internal/service"
+	"github.com/lot-koichi/sre-skill-up-project/services/notification/internal/subscriber"
+	"go.uber.org/zap"
+)
+
+type EventHandler struct {
+	svc    service.NotificationService
+	logger *zap.Logger
+}
+
+func NewEventHandler(svc service.NotificationService, logger *zap.Logger) *EventHandler {
+	return &EventHandler{
+		svc:    svc,
+		logger: logger,
+	}
+}
+
+// HandleMessage decodes a user event message and passes it to the service
+func (h *EventHandler) HandleMessage(ctx context.Context, msg *subscriber.Message) error {
+	var event domain.UserEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		// 壊れたメッセージは再送しても回復しないため破棄する
+		h.logger.Error("Discarding malformed message", zap.String("message_id", msg.ID), zap.Error(err))
+		return nil
+	}
+
+	err := h.svc.HandleUserEvent(ctx, &event)
+	if err == nil {
+		return nil
+	}
+	if isPermanentError(err) {
+		h.logger.Error("Discarding invalid event",
+			zap.String("message_id", msg.ID),
+			zap.String("event_id", event.EventID.String()),
+			zap.Error(err))
+		return nil
+	}
+	return err
+}
+
+// isPermanentError reports whether redelivering the message cannot succeed
+func isPermanentError(err error) bool {
+	return errors.Is(err, domain.ErrInvalidEvent) ||
+		errors.Is(err, domain.ErrInvalidPayload) ||
+		errors.Is(err, domain.ErrUnsupportedVersion) ||
+		errors.Is(err, domain.ErrInvalidJob)
+}
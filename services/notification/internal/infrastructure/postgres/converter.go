@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+
+	db "github.com/lot-koichi/sre-skill-up-project/services/notification/db/sqlc/generated"
+	"github.com/lot-koichi/sre-skill-up-project/services/notification/internal/domain"
+)
+
+// toDomainNotificationJob converts SQLC generated NotificationJob to domain NotificationJob
+func toDomainNotificationJob(sqlcJob db.NotificationJob) (*domain.NotificationJob, error) {
+	variables := map[string]string{}
+	if len(sqlcJob.Variables) > 0 {
+		if err := json.Unmarshal(sqlcJob.Variables, &variables); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal variables of job %d: %w", sqlcJob.ID, err)
+		}
+	}
+
+	job := &domain.NotificationJob{
+		ID:            sqlcJob.ID,
+		CorrelationID: sqlcJob.CorrelationID,
+		UserID:        sqlcJob.UserID,
+		Channel:       domain.Channel(sqlcJob.Channel),
+		Destination:   sqlcJob.Destination,
+		Template:      sqlcJob.Template,
+		Variables:     variables,
+		Status:        domain.JobStatus(sqlcJob.Status),
+		RetryCount:    int(sqlcJob.RetryCount),
+		CreatedAt:     sqlcJob.CreatedAt,
+		UpdatedAt:     sqlcJob.UpdatedAt,
+	}
+	if sqlcJob.SentAt.Valid {
+		sentAt := sqlcJob.SentAt.Time
+		job.SentAt = &sentAt
+	}
+	return job, nil
+}
+
+// toCreateNotificationJobParams converts domain NotificationJob to SQLC CreateNotificationJobParams
+func toCreateNotificationJobParams(job *domain.NotificationJob) (db.CreateNotificationJobParams, error) {
+	variables, err := json.Marshal(job.Variables)
+	if err != nil {
+		return db.CreateNotificationJobParams{}, fmt.Errorf("failed to marshal variables: %w", err)
+	}
+
+	return db.CreateNotificationJobParams{
+		CorrelationID: job.CorrelationID,
+		UserID:        job.UserID,
+		Channel:       string(job.Channel),
+		Destination:   job.Destination,
+		Template:      job.Template,
+		Variables:     variables,
+	}, nil
+}
@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+	"github.com/lot-koichi/sre-skill-up-project/services/notification/internal/domain"
+)
+
+// PostgreSQL Error Codes
+const (
+	PgErrNotNullViolation = "23502" // NOT NULL違反
+	PgErrCheckViolation   = "23514" // CHECK制約違反
+	PgErrInvalidTextValue = "22P02" // 不正なテキスト表現
+)
+
+// handlePostgresError converts PostgreSQL errors to domain errors
+func handlePostgresError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+
+	if pgErr, ok := err.(*pq.Error); ok {
+		switch string(pgErr.Code) {
+		case PgErrNotNullViolation, PgErrCheckViolation, PgErrInvalidTextValue:
+			return domain.ErrInvalidJob
+		default:
+			return err
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	db "github.com/lot-koichi/sre-skill-up-project/services/notification/db/sqlc/generated"
+	"github.com/lot-koichi/sre-skill-up-project/services/notification/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/notification/internal/repository"
+)
+
+type postgresNotificationJobRepository struct {
+	db      *sql.DB
+	queries *db.Queries
+}
+
+// NewNotificationJobRepository creates a new PostgreSQL notification job repository
+func NewNotificationJobRepository(database *sql.DB) repository.NotificationJobRepository {
+	return &postgresNotificationJobRepository{
+		db:      database,
+		queries: db.New(database),
+	}
+}
+
+func (r *postgresNotificationJobRepository) CreateIfNotExists(ctx context.Context, job *domain.NotificationJob) (bool, error) {
+	params, err := toCreateNotificationJobParams(job)
+	if err != nil {
+		return false, err
+	}
+
+	created, err := r.queries.CreateNotificationJob(ctx, params)
+	if err != nil {
+		// ON CONFLICT DO NOTHING で行が返らない = 同じ correlation_id のジョブが既に存在する
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, handlePostgresError(err)
+	}
+
+	createdJob, err := toDomainNotificationJob(created)
+	if err != nil {
+		return false, err
+	}
+	*job = *createdJob
+	return true, nil
+}
+
+func (r *postgresNotificationJobRepository) GetByCorrelationID(ctx context.Context, correlationID uuid.UUID) (*domain.NotificationJob, error) {
+	job, err := r.queries.GetNotificationJobByCorrelationID(ctx, correlationID)
+	if err != nil {
+		return nil, handlePostgresError(err)
+	}
+	return toDomainNotificationJob(job)
+}
+
+func (r *postgresNotificationJobRepository) Claim(ctx context.Context, id int64) (bool, error) {
+	if _, err := r.queries.ClaimNotificationJob(ctx, id); err != nil {
+		// 行が返らない = 送信済み、または別のコンシューマーが送信中
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, handlePostgresError(err)
+	}
+	return true, nil
+}
+
+func (r *postgresNotificationJobRepository) MarkSent(ctx context.Context, id int64) error {
+	if err := r.queries.MarkNotificationJobSent(ctx, id); err != nil {
+		return handlePostgresError(err)
+	}
+	return nil
+}
+
+func (r *postgresNotificationJobRepository) MarkFailed(ctx context.Context, id int64) error {
+	if err := r.queries.MarkNotificationJobFailed(ctx, id); err != nil {
+		return handlePostgresError(err)
+	}
+	return nil
+}
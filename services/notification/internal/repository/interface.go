@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/services/notification/internal/domain"
+)
+
+type NotificationJobRepository interface {
+	// CreateIfNotExists inserts the job unless a job with the same correlation_id exists; it reports whether a row was inserted
+	CreateIfNotExists(ctx context.Context, job *domain.NotificationJob) (bool, error)
+	GetByCorrelationID(ctx context.Context, correlationID uuid.UUID) (*domain.NotificationJob, error)
+	// Claim marks a pending or failed job as sending; it reports false if the job is sent or being sent by another consumer
+	Claim(ctx context.Context, id int64) (bool, error)
+	MarkSent(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64) error
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/services/notification/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// コンパイル時にインターフェースを満たしているか確認
+var _ NotificationJobRepository = (*MockNotificationJobRepository)(nil)
+
+// MockNotificationJobRepository is a mock implementation of NotificationJobRepository interface
+type MockNotificationJobRepository struct {
+	mock.Mock
+}
+
+// CreateIfNotExists mocks the CreateIfNotExists method
+func (m *MockNotificationJobRepository) CreateIfNotExists(ctx context.Context, job *domain.NotificationJob) (bool, error) {
+	args := m.Called(ctx, job)
+	return args.Bool(0), args.Error(1)
+}
+
+// GetByCorrelationID mocks the GetByCorrelationID method
+func (m *MockNotificationJobRepository) GetByCorrelationID(ctx context.Context, correlationID uuid.UUID) (*domain.NotificationJob, error) {
+	args := m.Called(ctx, correlationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.NotificationJob), args.Error(1)
+}
+
+// Claim mocks the Claim method
+func (m *MockNotificationJobRepository) Claim(ctx context.Context, id int64) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+// MarkSent mocks the MarkSent method
+func (m *MockNotificationJobRepository) MarkSent(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MarkFailed mocks the MarkFailed method
+func (m *MockNotificationJobRepository) MarkFailed(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
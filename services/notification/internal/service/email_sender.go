@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	"github.com/lot-koichi/sre-skill-up-project/services/notification/internal/domain"
+	"go.uber.org/zap"
+)
+
+type EmailSender interface {
+	Send(ctx context.Context, job *domain.NotificationJob) error
+}
+
+// logEmailSender is a dummy sender that only records "sent" mails to the log
+type logEmailSender struct {
+	logger *zap.Logger
+}
+
+func NewLogEmailSender(logger *zap.Logger) EmailSender {
+	return &logEmailSender{
+		logger: logger,
+	}
+}
+
+func (s *logEmailSender) Send(ctx context.Context, job *domain.NotificationJob) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.logger.Info("Email sent (dummy)",
+		zap.String("correlation_id", job.CorrelationID.String()),
+		zap.String("user_id", job.UserID.String()),
+		zap.String("template", job.Template))
+	return nil
+}
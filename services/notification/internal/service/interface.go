@@ -0,0 +1,11 @@
+package service
+
+import (
+	"context"
+
+	"github.com/lot-koichi/sre-skill-up-project/services/notification/internal/domain"
+)
+
+type NotificationService interface {
+	HandleUserEvent(ctx context.Context, event *domain.UserEvent) error
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lot-koichi/sre-skill-up-project/services/notification/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/notification/internal/repository"
+	"go.uber.org/zap"
+)
+
+// Ensure notificationService implements NotificationService interface
+var _ NotificationService = (*notificationService)(nil)
+
+// notificationService turns user events into notification jobs and delivers them
+type notificationService struct {
+	repo   repository.NotificationJobRepository
+	sender EmailSender
+	logger *zap.Logger
+}
+
+// NewNotificationService creates a new NotificationService instance
+func NewNotificationService(repo repository.NotificationJobRepository, sender EmailSender, logger *zap.Logger) NotificationService {
+	return &notificationService{
+		repo:   repo,
+		sender: sender,
+		logger: logger,
+	}
+}
+
+// HandleUserEvent handles a user event idempotently; redelivered events never send twice
+func (s *notificationService) HandleUserEvent(ctx context.Context, event *domain.UserEvent) error {
+	if err := event.Validate(); err != nil {
+		return err
+	}
+
+	// 現状は user.created のみ通知対象
+	if event.EventType != domain.EventTypeUserCreated {
+		s.logger.Debug("Ignoring event", zap.String("event_type", event.EventType))
+		return nil
+	}
+
+	payload, err := event.UserCreatedPayload()
+	if err != nil {
+		return err
+	}
+
+	// event_id を correlation_id として冪等にジョブを登録
+	job := domain.NewWelcomeEmailJob(event.EventID, payload)
+	if err := job.Validate(); err != nil {
+		return err
+	}
+
+	created, err := s.repo.CreateIfNotExists(ctx, job)
+	if err != nil {
+		return fmt.Errorf("failed to create notification job: %w", err)
+	}
+	if !created {
+		existing, err := s.repo.GetByCorrelationID(ctx, event.EventID)
+		if err != nil {
+			return fmt.Errorf("failed to get notification job: %w", err)
+		}
+		if existing.IsSent() {
+			s.logger.Info("Duplicate event, notification already sent",
+				zap.String("correlation_id", event.EventID.String()))
+			return nil
+		}
+		// 前回の送信が失敗している場合は再送する
+		job = existing
+	}
+
+	// 同じイベントが同時に再配信されても、ジョブを取得できたコンシューマーだけが送信する
+	claimed, err := s.repo.Claim(ctx, job.ID)
+	if err != nil {
+		return fmt.Errorf("failed to claim notification job: %w", err)
+	}
+	if !claimed {
+		s.logger.Info("Duplicate event, notification already sent or being sent",
+			zap.String("correlation_id", event.EventID.String()))
+		return nil
+	}
+
+	if err := s.sender.Send(ctx, job); err != nil {
+		if markErr := s.repo.MarkFailed(ctx, job.ID); markErr != nil {
+			s.logger.Error("Failed to mark notification job as failed", zap.Error(markErr))
+		}
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+
+	if err := s.repo.MarkSent(ctx, job.ID); err != nil {
+		return fmt.Errorf("failed to mark notification job as sent: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/services/notification/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/notification/internal/repository"
+	"github.com/lot-koichi/sre-skill-up-project/services/notification/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// MockEmailSender is a mock implementation of EmailSender
+type MockEmailSender struct {
+	mock.Mock
+}
+
+func (m *MockEmailSender) Send(ctx context.Context, job *domain.NotificationJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func newUserCreatedEvent(t *testing.T) *domain.UserEvent {
+	t.Helper()
+	payload, err := json.Marshal(domain.UserCreatedPayload{
+		UserID: uuid.New(),
+		Email:  "new@example.com",
+		Name:   "New User",
+	})
+	require.NoError(t, err)
+	return &domain.UserEvent{
+		EventID:   uuid.New(),
+		EventType: domain.EventTypeUserCreated,
+		Version:   domain.UserEventVersion,
+		Producer:  "user-service",
+		Payload:   payload,
+	}
+}
+
+func TestNotificationService_HandleUserEvent(t *testing.T) {
+	sendErr := errors.New("smtp unavailable")
+	claimErr := errors.New("connection reset")
+
+	tests := []struct {
+		name      string
+		mockSetup func(*repository.MockNotificationJobRepository, *MockEmailSender, *domain.UserEvent)
+		wantErr   error
+	}{
+		{
+			name: "正常系：新規ジョブを送信する",
+			mockSetup: func(repo *repository.MockNotificationJobRepository, sender *MockEmailSender, event *domain.UserEvent) {
+				repo.On("CreateIfNotExists", mock.Anything, mock.MatchedBy(func(job *domain.NotificationJob) bool {
+					return job.CorrelationID == event.EventID && job.Destination == "new@example.com" && job.Template == domain.TemplateWelcome
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.NotificationJob).ID = 1
+				}).Return(true, nil).Once()
+				repo.On("Claim", mock.Anything, int64(1)).Return(true, nil).Once()
+				sender.On("Send", mock.Anything, mock.AnythingOfType("*domain.NotificationJob")).Return(nil).Once()
+				repo.On("MarkSent", mock.Anything, int64(1)).Return(nil).Once()
+			},
+		},
+		{
+			name: "正常系：送信済みの重複イベントは再送しない",
+			mockSetup: func(repo *repository.MockNotificationJobRepository, sender *MockEmailSender, event *domain.UserEvent) {
+				repo.On("CreateIfNotExists", mock.Anything, mock.Anything).Return(false, nil).Once()
+				repo.On("GetByCorrelationID", mock.Anything, event.EventID).
+					Return(&domain.NotificationJob{ID: 1, CorrelationID: event.EventID, Status: domain.JobStatusSent}, nil).Once()
+			},
+		},
+		{
+			name: "正常系：前回失敗したジョブは再送する",
+			mockSetup: func(repo *repository.MockNotificationJobRepository, sender *MockEmailSender, event *domain.UserEvent) {
+				existing := &domain.NotificationJob{ID: 2, CorrelationID: event.EventID, Status: domain.JobStatusFailed}
+				repo.On("CreateIfNotExists", mock.Anything, mock.Anything).Return(false, nil).Once()
+				repo.On("GetByCorrelationID", mock.Anything, event.EventID).Return(existing, nil).Once()
+				repo.On("Claim", mock.Anything, int64(2)).Return(true, nil).Once()
+				sender.On("Send", mock.Anything, existing).Return(nil).Once()
+				repo.On("MarkSent", mock.Anything, int64(2)).Return(nil).Once()
+			},
+		},
+		{
+			name: "正常系：別のコンシューマーが送信中のジョブは送信しない",
+			mockSetup: func(repo *repository.MockNotificationJobRepository, sender *MockEmailSender, event *domain.UserEvent) {
+				existing := &domain.NotificationJob{ID: 4, CorrelationID: event.EventID, Status: domain.JobStatusPending}
+				repo.On("CreateIfNotExists", mock.Anything, mock.Anything).Return(false, nil).Once()
+				repo.On("GetByCorrelationID", mock.Anything, event.EventID).Return(existing, nil).Once()
+				repo.On("Claim", mock.Anything, int64(4)).Return(false, nil).Once()
+			},
+		},
+		{
+			name: "異常系：ジョブの取得に失敗した場合は送信しない",
+			mockSetup: func(repo *repository.MockNotificationJobRepository, sender *MockEmailSender, event *domain.UserEvent) {
+				repo.On("CreateIfNotExists", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.NotificationJob).ID = 5
+				}).Return(true, nil).Once()
+				repo.On("Claim", mock.Anything, int64(5)).Return(false, claimErr).Once()
+			},
+			wantErr: claimErr,
+		},
+		{
+			name: "異常系：送信失敗時は失敗を記録してエラーを返す",
+			mockSetup: func(repo *repository.MockNotificationJobRepository, sender *MockEmailSender, event *domain.UserEvent) {
+				repo.On("CreateIfNotExists", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.NotificationJob).ID = 3
+				}).Return(true, nil).Once()
+				repo.On("Claim", mock.Anything, int64(3)).Return(true, nil).Once()
+				sender.On("Send", mock.Anything, mock.Anything).Return(sendErr).Once()
+				repo.On("MarkFailed", mock.Anything, int64(3)).Return(nil).Once()
+			},
+			wantErr: sendErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockNotificationJobRepository)
+			mockSender := new(MockEmailSender)
+			event := newUserCreatedEvent(t)
+			tt.mockSetup(mockRepo, mockSender, event)
+
+			svc := service.NewNotificationService(mockRepo, mockSender, zap.NewNop())
+			err := svc.HandleUserEvent(context.Background(), event)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+			mockSender.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNotificationService_HandleUserEvent_Ignored(t *testing.T) {
+	t.Run("正常系：user.created以外は無視する", func(t *testing.T) {
+		mockRepo := new(repository.MockNotificationJobRepository)
+		mockSender := new(MockEmailSender)
+		event := newUserCreatedEvent(t)
+		event.EventType = "user.updated"
+
+		svc := service.NewNotificationService(mockRepo, mockSender, zap.NewNop())
+		err := svc.HandleUserEvent(context.Background(), event)
+
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "CreateIfNotExists", mock.Anything, mock.Anything)
+		mockSender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系：未対応のバージョン", func(t *testing.T) {
+		mockRepo := new(repository.MockNotificationJobRepository)
+		mockSender := new(MockEmailSender)
+		event := newUserCreatedEvent(t)
+		event.Version = 99
+
+		svc := service.NewNotificationService(mockRepo, mockSender, zap.NewNop())
+		err := svc.HandleUserEvent(context.Background(), event)
+
+		assert.ErrorIs(t, err, domain.ErrUnsupportedVersion)
+	})
+}
@@ -0,0 +1,126 @@
+package subscriber
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+// Message is a message received from a subscription
+type Message struct {
+	ID              string
+	Data            []byte
+	DeliveryAttempt int
+}
+
+// Handler processes a message; returning an error requests redelivery
+type Handler func(ctx context.Context, msg *Message) error
+
+// Subscriber abstracts a message subscription (Pub/Sub, in-process channel, file, ...)
+type Subscriber interface {
+	Receive(ctx context.Context, handler Handler) error
+}
+
+// コンパイル時にインターフェースを満たしているか確認
+var (
+	_ Subscriber = (*ChannelSubscriber)(nil)
+	_ Subscriber = (*ReaderSubscriber)(nil)
+)
+
+// ChannelSubscriber receives messages from a Go channel (in-process delivery / tests)
+type ChannelSubscriber struct {
+	ch            <-chan []byte
+	maxDeliveries int
+	logger        *zap.Logger
+}
+
+// NewChannelSubscriber creates a new ChannelSubscriber
+func NewChannelSubscriber(ch <-chan []byte, maxDeliveries int, logger *zap.Logger) *ChannelSubscriber {
+	return &ChannelSubscriber{
+		ch:            ch,
+		maxDeliveries: maxDeliveries,
+		logger:        logger,
+	}
+}
+
+// Receive blocks until ctx is cancelled or the channel is closed
+func (s *ChannelSubscriber) Receive(ctx context.Context, handler Handler) error {
+	var seq int
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case data, ok := <-s.ch:
+			if !ok {
+				return nil
+			}
+			seq++
+			deliver(ctx, handler, &Message{ID: strconv.Itoa(seq), Data: data}, s.maxDeliveries, s.logger)
+		}
+	}
+}
+
+// ReaderSubscriber receives newline-delimited messages from an io.Reader
+// (e.g. the NDJSON written by the User Service outbox relay)
+type ReaderSubscriber struct {
+	r             io.Reader
+	maxDeliveries int
+	logger        *zap.Logger
+}
+
+// NewReaderSubscriber creates a new ReaderSubscriber
+func NewReaderSubscriber(r io.Reader, maxDeliveries int, logger *zap.Logger) *ReaderSubscriber {
+	return &ReaderSubscriber{
+		r:             r,
+		maxDeliveries: maxDeliveries,
+		logger:        logger,
+	}
+}
+
+// Receive blocks until ctx is cancelled or the reader reaches EOF
+func (s *ReaderSubscriber) Receive(ctx context.Context, handler Handler) error {
+	scanner := bufio.NewScanner(s.r)
+	var line int
+	for scanner.Scan() {
+		line++
+		if ctx.Err() != nil {
+			return nil
+		}
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		// Scanner のバッファは再利用されるためコピーして渡す
+		data := append([]byte(nil), scanner.Bytes()...)
+		deliver(ctx, handler, &Message{ID: strconv.Itoa(line), Data: data}, s.maxDeliveries, s.logger)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read messages: %w", err)
+	}
+	return nil
+}
+
+// deliver calls handler until it succeeds or maxDeliveries is reached (then the message is dropped)
+func deliver(ctx context.Context, handler Handler, msg *Message, maxDeliveries int, logger *zap.Logger) {
+	for attempt := 1; attempt <= maxDeliveries; attempt++ {
+		msg.DeliveryAttempt = attempt
+		err := handler(ctx, msg)
+		if err == nil {
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Warn("Failed to handle message",
+			zap.String("message_id", msg.ID),
+			zap.Int("delivery_attempt", attempt),
+			zap.Error(err))
+	}
+	logger.Error("Dropping message after max deliveries",
+		zap.String("message_id", msg.ID),
+		zap.Int("max_deliveries", maxDeliveries))
+}
@@ -0,0 +1,62 @@
+package subscriber_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lot-koichi/sre-skill-up-project/services/notification/internal/subscriber"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestReaderSubscriber_Receive(t *testing.T) {
+	input := "{\"n\":1}\n\n{\"n\":2}\n"
+	sub := subscriber.NewReaderSubscriber(strings.NewReader(input), 3, zap.NewNop())
+
+	var received []string
+	err := sub.Receive(context.Background(), func(ctx context.Context, msg *subscriber.Message) error {
+		received = append(received, string(msg.Data))
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{`{"n":1}`, `{"n":2}`}, received)
+}
+
+func TestChannelSubscriber_Receive(t *testing.T) {
+	testCases := []struct {
+		name          string
+		failures      int
+		wantAttempts  int
+		maxDeliveries int
+	}{
+		{name: "正常系：初回で成功", failures: 0, wantAttempts: 1, maxDeliveries: 3},
+		{name: "正常系：失敗後に再配信で成功", failures: 2, wantAttempts: 3, maxDeliveries: 3},
+		{name: "異常系：最大配信回数で破棄", failures: 5, wantAttempts: 3, maxDeliveries: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ch := make(chan []byte, 1)
+			ch <- []byte("message")
+			close(ch)
+			sub := subscriber.NewChannelSubscriber(ch, tc.maxDeliveries, zap.NewNop())
+
+			var attempts []int
+			err := sub.Receive(context.Background(), func(ctx context.Context, msg *subscriber.Message) error {
+				attempts = append(attempts, msg.DeliveryAttempt)
+				if len(attempts) <= tc.failures {
+					return errors.New("temporary failure")
+				}
+				return nil
+			})
+
+			require.NoError(t, err)
+			assert.Len(t, attempts, tc.wantAttempts)
+			assert.Equal(t, tc.wantAttempts, attempts[len(attempts)-1])
+		})
+	}
+}
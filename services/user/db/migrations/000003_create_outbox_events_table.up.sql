@@ -0,0 +1,12 @@
+CREATE TABLE outbox_events (
+  seq_id BIGSERIAL PRIMARY KEY,
+  event_id UUID UNIQUE NOT NULL,
+  event_type TEXT NOT NULL,
+  payload JSONB NOT NULL,
+  version INT NOT NULL DEFAULT 1,
+  occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  published_at TIMESTAMP WITH TIME ZONE,
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'published', 'failed')),
+  error_reason TEXT
+);
+CREATE INDEX idx_outbox_events_pending ON outbox_events(seq_id) WHERE status = 'pending';
@@ -0,0 +1,17 @@
+CREATE TABLE roles (
+  name TEXT PRIMARY KEY,
+  description TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- 権限の定義はアプリケーション側（domain.rolePermissions）で管理する
+INSERT INTO roles (name, description) VALUES
+  ('admin', 'Full access to all users and role management'),
+  ('support', 'Read-only access to all users');
+
+CREATE TABLE user_roles (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  role TEXT NOT NULL REFERENCES roles(name),
+  granted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (user_id, role)
+);
@@ -0,0 +1,9 @@
+-- ログイン失敗の追跡（scope = 'user' は subject にユーザーID、'ip' はクライアントIP）
+CREATE TABLE login_throttles (
+  scope TEXT NOT NULL CHECK (scope IN ('user', 'ip')),
+  subject TEXT NOT NULL,
+  failures INT NOT NULL DEFAULT 0,
+  last_failure_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  locked_until TIMESTAMP WITH TIME ZONE,
+  PRIMARY KEY (scope, subject)
+);
@@ -0,0 +1,8 @@
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- 論理削除されたユーザーのメールアドレスは再登録できるよう、一意性は有効なユーザーの間でのみ保証する
+ALTER TABLE users DROP CONSTRAINT users_email_key;
+CREATE UNIQUE INDEX users_email_live_key ON users(email) WHERE deleted_at IS NULL;
+
+-- 物理削除ジョブが保持期間を過ぎた行を探すためのインデックス
+CREATE INDEX idx_users_deleted_at ON users(deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,2 @@
+-- メールアドレスの大文字小文字を区別しない検索用のインデックス
+CREATE INDEX idx_users_email_lower ON users(lower(email)) WHERE deleted_at IS NULL;
@@ -0,0 +1,4 @@
+-- 正規化済みのメールアドレスは元に戻さない
+DROP INDEX IF EXISTS users_email_live_key;
+CREATE UNIQUE INDEX users_email_live_key ON users(email) WHERE deleted_at IS NULL;
+CREATE INDEX idx_users_email_lower ON users(lower(email)) WHERE deleted_at IS NULL;
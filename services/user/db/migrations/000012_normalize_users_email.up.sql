@@ -0,0 +1,34 @@
+-- 正規化すると有効なユーザー同士でメールアドレスが重複する場合は、手動で解消するまで移行を中止する
+DO $$
+DECLARE
+  collisions TEXT;
+BEGIN
+  SELECT string_agg(normalized || ' (' || n || ')', ', ' ORDER BY normalized) INTO collisions
+  FROM (
+    SELECT lower(btrim(email)) AS normalized, count(*) AS n
+    FROM users
+    WHERE deleted_at IS NULL
+    GROUP BY 1
+    HAVING count(*) > 1
+  ) duplicated;
+
+  IF collisions IS NOT NULL THEN
+    RAISE EXCEPTION 'users.email has case-insensitive duplicates: %', collisions
+      USING HINT = 'merge or soft-delete the duplicated accounts, then rerun the migration';
+  END IF;
+END $$;
+
+-- 前後の空白を除き、ドメイン部を小文字にそろえる（既存のアドレスは ASCII のみのため IDN 変換は不要）
+UPDATE users
+SET email = normalized.email
+FROM (
+  SELECT id, substring(btrim(email) FROM '^(.*)@') || '@' || lower(substring(btrim(email) FROM '^.*@(.*)$')) AS email
+  FROM users
+  WHERE position('@' IN email) > 0
+) normalized
+WHERE users.id = normalized.id AND users.email <> normalized.email;
+
+-- 有効なユーザーの間で大文字小文字を区別せずに一意にする（検索用のインデックスも兼ねる）
+DROP INDEX IF EXISTS idx_users_email_lower;
+DROP INDEX IF EXISTS users_email_live_key;
+CREATE UNIQUE INDEX users_email_live_key ON users(lower(email)) WHERE deleted_at IS NULL;
@@ -0,0 +1,18 @@
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP WITH TIME ZONE;
+
+-- 既存のユーザーは移行時点で検証済みとみなす（検証必須のポリシーを有効にしてもログインできるように）
+UPDATE users SET email_verified_at = NOW();
+
+CREATE TABLE email_verification_tokens (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  -- 発行時点のメールアドレス（その後に変更された場合はトークンを使えない）
+  email VARCHAR(255) NOT NULL,
+  -- トークン本体は保存せず SHA-256 ハッシュのみ保持する
+  token_hash TEXT UNIQUE NOT NULL,
+  expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  used_at TIMESTAMP WITH TIME ZONE,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+-- 再送の回数制限で直近の発行数を数えるためのインデックス
+CREATE INDEX idx_email_verification_tokens_user_id_created_at ON email_verification_tokens(user_id, created_at);
@@ -0,0 +1,2 @@
+-- 楽観的排他制御のためのバージョン（UpdateUser のたびに 1 ずつ増える）
+ALTER TABLE users ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,16 @@
+CREATE TABLE idempotency_keys (
+  -- キーはリクエストした主体ごとに独立している（未認証のリクエストは 'anonymous'）
+  scope TEXT NOT NULL,
+  idempotency_key TEXT NOT NULL,
+  -- メソッド・パス・本文の HMAC-SHA256（本文にパスワードを含むため平文のハッシュにはしない）
+  request_hash TEXT NOT NULL,
+  -- 最初のリクエストの処理中は NULL
+  status_code INTEGER,
+  response_headers JSONB NOT NULL DEFAULT '{}',
+  response_body BYTEA,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  PRIMARY KEY (scope, idempotency_key)
+);
+-- 期限切れのキーを定期的に削除するためのインデックス
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
@@ -0,0 +1,19 @@
+-- ユーザーに対する操作の監査ログ（ユーザーの物理削除後も残すため users への外部キーは張らない）
+CREATE TABLE audit_events (
+  id UUID PRIMARY KEY,
+  action TEXT NOT NULL,
+  outcome TEXT NOT NULL CHECK (outcome IN ('success', 'failure')),
+  -- 操作した認証済みユーザー（登録・ログインなど未認証の操作は NULL）
+  actor_id UUID,
+  -- 操作対象のユーザー（登録されていないメールアドレスでのログインなど特定できない場合は NULL）
+  user_id UUID,
+  request_id TEXT NOT NULL DEFAULT '',
+  client_ip TEXT NOT NULL DEFAULT '',
+  -- フィールドごとの変更前後の値（パスワードは伏せ字にする）
+  changes JSONB NOT NULL DEFAULT '[]',
+  -- 失敗した操作の理由
+  error TEXT NOT NULL DEFAULT '',
+  occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+-- ユーザーごとに新しい順で読むためのインデックス
+CREATE INDEX idx_audit_events_user_id_occurred_at ON audit_events(user_id, occurred_at DESC, id DESC);
@@ -6,15 +6,106 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 )
 
-type User struct {
+type AuditEvent struct {
+	ID         uuid.UUID       `db:"id" json:"id"`
+	Action     string          `db:"action" json:"action"`
+	Outcome    string          `db:"outcome" json:"outcome"`
+	ActorID    uuid.NullUUID   `db:"actor_id" json:"actor_id"`
+	UserID     uuid.NullUUID   `db:"user_id" json:"user_id"`
+	RequestID  string          `db:"request_id" json:"request_id"`
+	ClientIp   string          `db:"client_ip" json:"client_ip"`
+	Changes    json.RawMessage `db:"changes" json:"changes"`
+	Error      string          `db:"error" json:"error"`
+	OccurredAt time.Time       `db:"occurred_at" json:"occurred_at"`
+}
+
+type EmailVerificationToken struct {
 	ID        uuid.UUID    `db:"id" json:"id"`
+	UserID    uuid.UUID    `db:"user_id" json:"user_id"`
 	Email     string       `db:"email" json:"email"`
-	Name      string       `db:"name" json:"name"`
-	CreatedAt sql.NullTime `db:"created_at" json:"created_at"`
-	UpdatedAt sql.NullTime `db:"updated_at" json:"updated_at"`
-	Password  string       `db:"password" json:"password"`
+	TokenHash string       `db:"token_hash" json:"token_hash"`
+	ExpiresAt time.Time    `db:"expires_at" json:"expires_at"`
+	UsedAt    sql.NullTime `db:"used_at" json:"used_at"`
+	CreatedAt time.Time    `db:"created_at" json:"created_at"`
+}
+
+type IdempotencyKey struct {
+	Scope           string          `db:"scope" json:"scope"`
+	IdempotencyKey  string          `db:"idempotency_key" json:"idempotency_key"`
+	RequestHash     string          `db:"request_hash" json:"request_hash"`
+	StatusCode      sql.NullInt32   `db:"status_code" json:"status_code"`
+	ResponseHeaders json.RawMessage `db:"response_headers" json:"response_headers"`
+	ResponseBody    []byte          `db:"response_body" json:"response_body"`
+	CreatedAt       time.Time       `db:"created_at" json:"created_at"`
+	ExpiresAt       time.Time       `db:"expires_at" json:"expires_at"`
+}
+
+type LoginThrottle struct {
+	Scope         string       `db:"scope" json:"scope"`
+	Subject       string       `db:"subject" json:"subject"`
+	Failures      int32        `db:"failures" json:"failures"`
+	LastFailureAt time.Time    `db:"last_failure_at" json:"last_failure_at"`
+	LockedUntil   sql.NullTime `db:"locked_until" json:"locked_until"`
+}
+
+type OutboxEvent struct {
+	SeqID         int64           `db:"seq_id" json:"seq_id"`
+	EventID       uuid.UUID       `db:"event_id" json:"event_id"`
+	EventType     string          `db:"event_type" json:"event_type"`
+	Payload       json.RawMessage `db:"payload" json:"payload"`
+	Version       int32           `db:"version" json:"version"`
+	OccurredAt    time.Time       `db:"occurred_at" json:"occurred_at"`
+	PublishedAt   sql.NullTime    `db:"published_at" json:"published_at"`
+	Status        string          `db:"status" json:"status"`
+	ErrorReason   sql.NullString  `db:"error_reason" json:"error_reason"`
+	Attempts      int32           `db:"attempts" json:"attempts"`
+	NextAttemptAt time.Time       `db:"next_attempt_at" json:"next_attempt_at"`
+}
+
+type PasswordResetToken struct {
+	ID        uuid.UUID    `db:"id" json:"id"`
+	UserID    uuid.UUID    `db:"user_id" json:"user_id"`
+	TokenHash string       `db:"token_hash" json:"token_hash"`
+	ExpiresAt time.Time    `db:"expires_at" json:"expires_at"`
+	UsedAt    sql.NullTime `db:"used_at" json:"used_at"`
+	CreatedAt time.Time    `db:"created_at" json:"created_at"`
+}
+
+type RefreshToken struct {
+	ID        uuid.UUID    `db:"id" json:"id"`
+	UserID    uuid.UUID    `db:"user_id" json:"user_id"`
+	TokenHash string       `db:"token_hash" json:"token_hash"`
+	ExpiresAt time.Time    `db:"expires_at" json:"expires_at"`
+	RevokedAt sql.NullTime `db:"revoked_at" json:"revoked_at"`
+	CreatedAt time.Time    `db:"created_at" json:"created_at"`
+}
+
+type Role struct {
+	Name        string    `db:"name" json:"name"`
+	Description string    `db:"description" json:"description"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+}
+
+type User struct {
+	ID              uuid.UUID    `db:"id" json:"id"`
+	Email           string       `db:"email" json:"email"`
+	Name            string       `db:"name" json:"name"`
+	CreatedAt       sql.NullTime `db:"created_at" json:"created_at"`
+	UpdatedAt       sql.NullTime `db:"updated_at" json:"updated_at"`
+	Password        string       `db:"password" json:"password"`
+	DeletedAt       sql.NullTime `db:"deleted_at" json:"deleted_at"`
+	EmailVerifiedAt sql.NullTime `db:"email_verified_at" json:"email_verified_at"`
+	Version         int64        `db:"version" json:"version"`
+}
+
+type UserRole struct {
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`
+	Role      string    `db:"role" json:"role"`
+	GrantedAt time.Time `db:"granted_at" json:"granted_at"`
 }
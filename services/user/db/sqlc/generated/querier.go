@@ -11,13 +11,76 @@ import (
 )
 
 type Querier interface {
+	CheckUserExistsByEmail(ctx context.Context, email string) (bool, error)
+	CheckUserExistsByID(ctx context.Context, id uuid.UUID) (bool, error)
+	ClaimOutboxEvents(ctx context.Context, arg ClaimOutboxEventsParams) ([]OutboxEvent, error)
+	CompleteIdempotencyKey(ctx context.Context, arg CompleteIdempotencyKeyParams) error
+	CountAuditEventsByUser(ctx context.Context, userID uuid.NullUUID) (int64, error)
+	CountEmailVerificationTokensSince(ctx context.Context, arg CountEmailVerificationTokensSinceParams) (int64, error)
+	CountSearchUsers(ctx context.Context, arg CountSearchUsersParams) (int64, error)
+	CountUsers(ctx context.Context, arg CountUsersParams) (int64, error)
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) error
+	CreateEmailVerificationToken(ctx context.Context, arg CreateEmailVerificationTokenParams) (EmailVerificationToken, error)
+	CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) (PasswordResetToken, error)
+	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (RefreshToken, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
-	DeleteUser(ctx context.Context, id uuid.UUID) error
+	CreateUserWithID(ctx context.Context, arg CreateUserWithIDParams) (User, error)
+	DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error
+	DeleteLoginThrottle(ctx context.Context, arg DeleteLoginThrottleParams) error
+	// 統計情報に基づく概算の行数（論理削除済みを含む。未 ANALYZE のテーブルでは -1）
+	EstimateUserCount(ctx context.Context) (int64, error)
+	GetEmailVerificationTokenByHash(ctx context.Context, tokenHash string) (EmailVerificationToken, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetLoginThrottle(ctx context.Context, arg GetLoginThrottleParams) (LoginThrottle, error)
+	GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (PasswordResetToken, error)
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (RefreshToken, error)
+	// メールアドレスは大文字小文字を区別せずに一意（users_email_live_key）
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (User, error)
-	GetUserByName(ctx context.Context, name string) (User, error)
+	GrantUserRole(ctx context.Context, arg GrantUserRoleParams) error
+	InsertOutboxEvent(ctx context.Context, arg InsertOutboxEventParams) (OutboxEvent, error)
+	InvalidateUserEmailVerificationTokens(ctx context.Context, userID uuid.UUID) error
+	InvalidateUserPasswordResetTokens(ctx context.Context, userID uuid.UUID) error
+	// 渡したアドレスのうち、有効なユーザーが大文字小文字を区別せずに使っているものを返す
+	ListAuditEventsByUser(ctx context.Context, arg ListAuditEventsByUserParams) ([]AuditEvent, error)
+	ListLiveUserEmails(ctx context.Context, emails []string) ([]string, error)
+	ListUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error)
+	ListUserRolesByUserIDs(ctx context.Context, userIds []uuid.UUID) ([]ListUserRolesByUserIDsRow, error)
+	// 並び順は sort_column / sort_desc で切り替え、同値のユーザーは id で順序付ける
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	// created_at の条件で idx_users_created_at を使い、同時刻のユーザーは id で順序付ける
+	ListUsersAfter(ctx context.Context, arg ListUsersAfterParams) ([]User, error)
+	// 前のページは古い順に取得する（呼び出し側で新しい順に並べ替える）
+	ListUsersBefore(ctx context.Context, arg ListUsersBeforeParams) ([]User, error)
+	LockLoginThrottle(ctx context.Context, arg LockLoginThrottleParams) error
+	MarkOutboxEventFailed(ctx context.Context, arg MarkOutboxEventFailedParams) error
+	MarkOutboxEventPublished(ctx context.Context, seqID int64) error
+	// トークンの発行後にメールアドレスが変更されていれば 0 件になる
+	MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVerifiedParams) (User, error)
+	// NULL の引数の列は書き換えない。version の確認とメールアドレス変更時の扱いは UpdateUser と同じ
+	PatchUser(ctx context.Context, arg PatchUserParams) (User, error)
+	PurgeDeletedUsers(ctx context.Context, retentionMs int64) (int64, error)
+	PurgeExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+	// ロックされておらず最後の失敗がウィンドウ外の行は、次の失敗でカウントがリセットされるため削除してよい
+	PurgeExpiredLoginThrottles(ctx context.Context, windowMs int64) (int64, error)
+	// ウィンドウ外の失敗はカウントをリセットする
+	RecordLoginFailure(ctx context.Context, arg RecordLoginFailureParams) (LoginThrottle, error)
+	// 期限切れの同じキーは上書きする。有効なキーが既にあれば 0 件（sql.ErrNoRows）になる
+	ReserveIdempotencyKey(ctx context.Context, arg ReserveIdempotencyKeyParams) (IdempotencyKey, error)
+	RestoreUser(ctx context.Context, id uuid.UUID) (User, error)
+	RevokeRefreshToken(ctx context.Context, id uuid.UUID) (int64, error)
+	RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) error
+	RevokeUserRole(ctx context.Context, arg RevokeUserRoleParams) error
+	ScheduleOutboxEventRetry(ctx context.Context, arg ScheduleOutboxEventRetryParams) error
+	// 名前・メールアドレスの部分一致またはトライグラム類似度で検索し、類似度の高い順に返す
+	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]SearchUsersRow, error)
+	SoftDeleteUser(ctx context.Context, id uuid.UUID) (User, error)
+	// メールアドレスが（大文字小文字の違いを除いて）変わった場合は未検証に戻す
+	// 読み込んだ時点から version が進んでいれば 0 件（sql.ErrNoRows）になる
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (User, error)
+	UseEmailVerificationToken(ctx context.Context, id uuid.UUID) (int64, error)
+	UsePasswordResetToken(ctx context.Context, id uuid.UUID) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
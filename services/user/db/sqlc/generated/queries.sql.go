@@ -7,10 +7,338 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+const checkUserExistsByEmail = `-- name: CheckUserExistsByEmail :one
+SELECT EXISTS(SELECT 1 FROM users WHERE lower(email) = lower($1::text) AND deleted_at IS NULL)
+`
+
+func (q *Queries) CheckUserExistsByEmail(ctx context.Context, email string) (bool, error) {
+	row := q.db.QueryRowContext(ctx, checkUserExistsByEmail, email)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const checkUserExistsByID = `-- name: CheckUserExistsByID :one
+SELECT EXISTS(SELECT 1 FROM users WHERE id = $1 AND deleted_at IS NULL)
+`
+
+func (q *Queries) CheckUserExistsByID(ctx context.Context, id uuid.UUID) (bool, error) {
+	row := q.db.QueryRowContext(ctx, checkUserExistsByID, id)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const claimOutboxEvents = `-- name: ClaimOutboxEvents :many
+UPDATE outbox_events
+SET attempts = attempts + 1,
+    next_attempt_at = NOW() + ($1::bigint * INTERVAL '1 millisecond')
+WHERE seq_id IN (
+    SELECT seq_id FROM outbox_events
+    WHERE status = 'pending' AND next_attempt_at <= NOW()
+    ORDER BY seq_id
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING seq_id, event_id, event_type, payload, version, occurred_at, published_at, status, error_reason, attempts, next_attempt_at
+`
+
+type ClaimOutboxEventsParams struct {
+	LeaseMs   int64 `db:"lease_ms" json:"lease_ms"`
+	BatchSize int32 `db:"batch_size" json:"batch_size"`
+}
+
+func (q *Queries) ClaimOutboxEvents(ctx context.Context, arg ClaimOutboxEventsParams) ([]OutboxEvent, error) {
+	rows, err := q.db.QueryContext(ctx, claimOutboxEvents, arg.LeaseMs, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []OutboxEvent{}
+	for rows.Next() {
+		var i OutboxEvent
+		if err := rows.Scan(
+			&i.SeqID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Version,
+			&i.OccurredAt,
+			&i.PublishedAt,
+			&i.Status,
+			&i.ErrorReason,
+			&i.Attempts,
+			&i.NextAttemptAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const completeIdempotencyKey = `-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET status_code = $1, response_headers = $2, response_body = $3
+WHERE scope = $4 AND idempotency_key = $5
+`
+
+type CompleteIdempotencyKeyParams struct {
+	StatusCode      sql.NullInt32   `db:"status_code" json:"status_code"`
+	ResponseHeaders json.RawMessage `db:"response_headers" json:"response_headers"`
+	ResponseBody    []byte          `db:"response_body" json:"response_body"`
+	Scope           string          `db:"scope" json:"scope"`
+	IdempotencyKey  string          `db:"idempotency_key" json:"idempotency_key"`
+}
+
+func (q *Queries) CompleteIdempotencyKey(ctx context.Context, arg CompleteIdempotencyKeyParams) error {
+	_, err := q.db.ExecContext(ctx, completeIdempotencyKey,
+		arg.StatusCode,
+		arg.ResponseHeaders,
+		arg.ResponseBody,
+		arg.Scope,
+		arg.IdempotencyKey,
+	)
+	return err
+}
+
+const countAuditEventsByUser = `-- name: CountAuditEventsByUser :one
+SELECT COUNT(*) FROM audit_events WHERE user_id = $1
+`
+
+func (q *Queries) CountAuditEventsByUser(ctx context.Context, userID uuid.NullUUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAuditEventsByUser, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countEmailVerificationTokensSince = `-- name: CountEmailVerificationTokensSince :one
+SELECT count(*) FROM email_verification_tokens WHERE user_id = $1 AND created_at >= $2
+`
+
+type CountEmailVerificationTokensSinceParams struct {
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+	Since  time.Time `db:"since" json:"since"`
+}
+
+func (q *Queries) CountEmailVerificationTokensSince(ctx context.Context, arg CountEmailVerificationTokensSinceParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countEmailVerificationTokensSince, arg.UserID, arg.Since)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countSearchUsers = `-- name: CountSearchUsers :one
+SELECT COUNT(*) FROM users
+WHERE deleted_at IS NULL
+  AND (name ILIKE $1::text OR email ILIKE $1::text
+       OR name % $2::text OR email % $2::text)
+`
+
+type CountSearchUsersParams struct {
+	Pattern string `db:"pattern" json:"pattern"`
+	Query   string `db:"query" json:"query"`
+}
+
+func (q *Queries) CountSearchUsers(ctx context.Context, arg CountSearchUsersParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countSearchUsers, arg.Pattern, arg.Query)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countUsers = `-- name: CountUsers :one
+SELECT COUNT(*) FROM users
+WHERE deleted_at IS NULL
+  AND ($1::text IS NULL OR email ILIKE $1 || '%')
+  AND ($2::text IS NULL OR name ILIKE $2 || '%')
+  AND ($3::timestamptz IS NULL OR created_at >= $3)
+  AND ($4::timestamptz IS NULL OR created_at < $4)
+`
+
+type CountUsersParams struct {
+	EmailPrefix sql.NullString `db:"email_prefix" json:"email_prefix"`
+	NamePrefix  sql.NullString `db:"name_prefix" json:"name_prefix"`
+	CreatedFrom sql.NullTime   `db:"created_from" json:"created_from"`
+	CreatedTo   sql.NullTime   `db:"created_to" json:"created_to"`
+}
+
+func (q *Queries) CountUsers(ctx context.Context, arg CountUsersParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUsers,
+		arg.EmailPrefix,
+		arg.NamePrefix,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAuditEvent = `-- name: CreateAuditEvent :exec
+INSERT INTO audit_events (id, action, outcome, actor_id, user_id, request_id, client_ip, changes, error, occurred_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+`
+
+type CreateAuditEventParams struct {
+	ID         uuid.UUID       `db:"id" json:"id"`
+	Action     string          `db:"action" json:"action"`
+	Outcome    string          `db:"outcome" json:"outcome"`
+	ActorID    uuid.NullUUID   `db:"actor_id" json:"actor_id"`
+	UserID     uuid.NullUUID   `db:"user_id" json:"user_id"`
+	RequestID  string          `db:"request_id" json:"request_id"`
+	ClientIp   string          `db:"client_ip" json:"client_ip"`
+	Changes    json.RawMessage `db:"changes" json:"changes"`
+	Error      string          `db:"error" json:"error"`
+	OccurredAt time.Time       `db:"occurred_at" json:"occurred_at"`
+}
+
+func (q *Queries) CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) error {
+	_, err := q.db.ExecContext(ctx, createAuditEvent,
+		arg.ID,
+		arg.Action,
+		arg.Outcome,
+		arg.ActorID,
+		arg.UserID,
+		arg.RequestID,
+		arg.ClientIp,
+		arg.Changes,
+		arg.Error,
+		arg.OccurredAt,
+	)
+	return err
+}
+
+const createEmailVerificationToken = `-- name: CreateEmailVerificationToken :one
+INSERT INTO email_verification_tokens (
+    id,
+    user_id,
+    email,
+    token_hash,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, user_id, email, token_hash, expires_at, used_at, created_at
+`
+
+type CreateEmailVerificationTokenParams struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`
+	Email     string    `db:"email" json:"email"`
+	TokenHash string    `db:"token_hash" json:"token_hash"`
+	ExpiresAt time.Time `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) CreateEmailVerificationToken(ctx context.Context, arg CreateEmailVerificationTokenParams) (EmailVerificationToken, error) {
+	row := q.db.QueryRowContext(ctx, createEmailVerificationToken,
+		arg.ID,
+		arg.UserID,
+		arg.Email,
+		arg.TokenHash,
+		arg.ExpiresAt,
+	)
+	var i EmailVerificationToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Email,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createPasswordResetToken = `-- name: CreatePasswordResetToken :one
+INSERT INTO password_reset_tokens (
+    id,
+    user_id,
+    token_hash,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING id, user_id, token_hash, expires_at, used_at, created_at
+`
+
+type CreatePasswordResetTokenParams struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`
+	TokenHash string    `db:"token_hash" json:"token_hash"`
+	ExpiresAt time.Time `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) (PasswordResetToken, error) {
+	row := q.db.QueryRowContext(ctx, createPasswordResetToken,
+		arg.ID,
+		arg.UserID,
+		arg.TokenHash,
+		arg.ExpiresAt,
+	)
+	var i PasswordResetToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createRefreshToken = `-- name: CreateRefreshToken :one
+INSERT INTO refresh_tokens (
+    id,
+    user_id,
+    token_hash,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING id, user_id, token_hash, expires_at, revoked_at, created_at
+`
+
+type CreateRefreshTokenParams struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`
+	TokenHash string    `db:"token_hash" json:"token_hash"`
+	ExpiresAt time.Time `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (RefreshToken, error) {
+	row := q.db.QueryRowContext(ctx, createRefreshToken,
+		arg.ID,
+		arg.UserID,
+		arg.TokenHash,
+		arg.ExpiresAt,
+	)
+	var i RefreshToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (
     email,
@@ -18,7 +346,7 @@ INSERT INTO users (
     name
 ) VALUES (
     $1, $2, $3
-) RETURNING id, email, name, created_at, updated_at, password
+) RETURNING id, email, name, created_at, updated_at, password, deleted_at, email_verified_at, version
 `
 
 type CreateUserParams struct {
@@ -37,23 +365,200 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Password,
+		&i.DeletedAt,
+		&i.EmailVerifiedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const createUserWithID = `-- name: CreateUserWithID :one
+INSERT INTO users (
+    id,
+    email,
+    password,
+    name
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING id, email, name, created_at, updated_at, password, deleted_at, email_verified_at, version
+`
+
+type CreateUserWithIDParams struct {
+	ID       uuid.UUID `db:"id" json:"id"`
+	Email    string    `db:"email" json:"email"`
+	Password string    `db:"password" json:"password"`
+	Name     string    `db:"name" json:"name"`
+}
+
+func (q *Queries) CreateUserWithID(ctx context.Context, arg CreateUserWithIDParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, createUserWithID,
+		arg.ID,
+		arg.Email,
+		arg.Password,
+		arg.Name,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Password,
+		&i.DeletedAt,
+		&i.EmailVerifiedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
-const deleteUser = `-- name: DeleteUser :exec
-DELETE FROM users WHERE id = $1
+const deleteIdempotencyKey = `-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys WHERE scope = $1 AND idempotency_key = $2
+`
+
+type DeleteIdempotencyKeyParams struct {
+	Scope          string `db:"scope" json:"scope"`
+	IdempotencyKey string `db:"idempotency_key" json:"idempotency_key"`
+}
+
+func (q *Queries) DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error {
+	_, err := q.db.ExecContext(ctx, deleteIdempotencyKey, arg.Scope, arg.IdempotencyKey)
+	return err
+}
+
+const deleteLoginThrottle = `-- name: DeleteLoginThrottle :exec
+DELETE FROM login_throttles WHERE scope = $1 AND subject = $2
 `
 
-func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteUser, id)
+type DeleteLoginThrottleParams struct {
+	Scope   string `db:"scope" json:"scope"`
+	Subject string `db:"subject" json:"subject"`
+}
+
+func (q *Queries) DeleteLoginThrottle(ctx context.Context, arg DeleteLoginThrottleParams) error {
+	_, err := q.db.ExecContext(ctx, deleteLoginThrottle, arg.Scope, arg.Subject)
 	return err
 }
 
+const estimateUserCount = `-- name: EstimateUserCount :one
+SELECT reltuples::bigint AS estimate FROM pg_class WHERE oid = 'users'::regclass
+`
+
+// 統計情報に基づく概算の行数（論理削除済みを含む。未 ANALYZE のテーブルでは -1）
+func (q *Queries) EstimateUserCount(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, estimateUserCount)
+	var estimate int64
+	err := row.Scan(&estimate)
+	return estimate, err
+}
+
+const getEmailVerificationTokenByHash = `-- name: GetEmailVerificationTokenByHash :one
+SELECT id, user_id, email, token_hash, expires_at, used_at, created_at FROM email_verification_tokens WHERE token_hash = $1
+`
+
+func (q *Queries) GetEmailVerificationTokenByHash(ctx context.Context, tokenHash string) (EmailVerificationToken, error) {
+	row := q.db.QueryRowContext(ctx, getEmailVerificationTokenByHash, tokenHash)
+	var i EmailVerificationToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Email,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getIdempotencyKey = `-- name: GetIdempotencyKey :one
+SELECT scope, idempotency_key, request_hash, status_code, response_headers, response_body, created_at, expires_at FROM idempotency_keys WHERE scope = $1 AND idempotency_key = $2
+`
+
+type GetIdempotencyKeyParams struct {
+	Scope          string `db:"scope" json:"scope"`
+	IdempotencyKey string `db:"idempotency_key" json:"idempotency_key"`
+}
+
+func (q *Queries) GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.db.QueryRowContext(ctx, getIdempotencyKey, arg.Scope, arg.IdempotencyKey)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Scope,
+		&i.IdempotencyKey,
+		&i.RequestHash,
+		&i.StatusCode,
+		&i.ResponseHeaders,
+		&i.ResponseBody,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getLoginThrottle = `-- name: GetLoginThrottle :one
+SELECT scope, subject, failures, last_failure_at, locked_until FROM login_throttles WHERE scope = $1 AND subject = $2
+`
+
+type GetLoginThrottleParams struct {
+	Scope   string `db:"scope" json:"scope"`
+	Subject string `db:"subject" json:"subject"`
+}
+
+func (q *Queries) GetLoginThrottle(ctx context.Context, arg GetLoginThrottleParams) (LoginThrottle, error) {
+	row := q.db.QueryRowContext(ctx, getLoginThrottle, arg.Scope, arg.Subject)
+	var i LoginThrottle
+	err := row.Scan(
+		&i.Scope,
+		&i.Subject,
+		&i.Failures,
+		&i.LastFailureAt,
+		&i.LockedUntil,
+	)
+	return i, err
+}
+
+const getPasswordResetTokenByHash = `-- name: GetPasswordResetTokenByHash :one
+SELECT id, user_id, token_hash, expires_at, used_at, created_at FROM password_reset_tokens WHERE token_hash = $1
+`
+
+func (q *Queries) GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (PasswordResetToken, error) {
+	row := q.db.QueryRowContext(ctx, getPasswordResetTokenByHash, tokenHash)
+	var i PasswordResetToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getRefreshTokenByHash = `-- name: GetRefreshTokenByHash :one
+SELECT id, user_id, token_hash, expires_at, revoked_at, created_at FROM refresh_tokens WHERE token_hash = $1
+`
+
+func (q *Queries) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (RefreshToken, error) {
+	row := q.db.QueryRowContext(ctx, getRefreshTokenByHash, tokenHash)
+	var i RefreshToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, name, created_at, updated_at, password FROM users WHERE email = $1
+SELECT id, email, name, created_at, updated_at, password, deleted_at, email_verified_at, version FROM users WHERE lower(email) = lower($1::text) AND deleted_at IS NULL
 `
 
+// メールアドレスは大文字小文字を区別せずに一意（users_email_live_key）
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
 	row := q.db.QueryRowContext(ctx, getUserByEmail, email)
 	var i User
@@ -64,12 +569,15 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Password,
+		&i.DeletedAt,
+		&i.EmailVerifiedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, name, created_at, updated_at, password FROM users WHERE id = $1
+SELECT id, email, name, created_at, updated_at, password, deleted_at, email_verified_at, version FROM users WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
@@ -82,53 +590,123 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Password,
+		&i.DeletedAt,
+		&i.EmailVerifiedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
-const getUserByName = `-- name: GetUserByName :one
-SELECT id, email, name, created_at, updated_at, password FROM users WHERE name = $1
+const grantUserRole = `-- name: GrantUserRole :exec
+INSERT INTO user_roles (user_id, role) VALUES ($1, $2) ON CONFLICT DO NOTHING
 `
 
-func (q *Queries) GetUserByName(ctx context.Context, name string) (User, error) {
-	row := q.db.QueryRowContext(ctx, getUserByName, name)
-	var i User
+type GrantUserRoleParams struct {
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+	Role   string    `db:"role" json:"role"`
+}
+
+func (q *Queries) GrantUserRole(ctx context.Context, arg GrantUserRoleParams) error {
+	_, err := q.db.ExecContext(ctx, grantUserRole, arg.UserID, arg.Role)
+	return err
+}
+
+const insertOutboxEvent = `-- name: InsertOutboxEvent :one
+INSERT INTO outbox_events (
+    event_id,
+    event_type,
+    payload,
+    version,
+    occurred_at
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING seq_id, event_id, event_type, payload, version, occurred_at, published_at, status, error_reason, attempts, next_attempt_at
+`
+
+type InsertOutboxEventParams struct {
+	EventID    uuid.UUID       `db:"event_id" json:"event_id"`
+	EventType  string          `db:"event_type" json:"event_type"`
+	Payload    json.RawMessage `db:"payload" json:"payload"`
+	Version    int32           `db:"version" json:"version"`
+	OccurredAt time.Time       `db:"occurred_at" json:"occurred_at"`
+}
+
+func (q *Queries) InsertOutboxEvent(ctx context.Context, arg InsertOutboxEventParams) (OutboxEvent, error) {
+	row := q.db.QueryRowContext(ctx, insertOutboxEvent,
+		arg.EventID,
+		arg.EventType,
+		arg.Payload,
+		arg.Version,
+		arg.OccurredAt,
+	)
+	var i OutboxEvent
 	err := row.Scan(
-		&i.ID,
-		&i.Email,
-		&i.Name,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.Password,
+		&i.SeqID,
+		&i.EventID,
+		&i.EventType,
+		&i.Payload,
+		&i.Version,
+		&i.OccurredAt,
+		&i.PublishedAt,
+		&i.Status,
+		&i.ErrorReason,
+		&i.Attempts,
+		&i.NextAttemptAt,
 	)
 	return i, err
 }
 
-const listUsers = `-- name: ListUsers :many
-SELECT id, email, name, created_at, updated_at, password FROM users ORDER BY created_at DESC LIMIT $1 OFFSET $2
+const invalidateUserEmailVerificationTokens = `-- name: InvalidateUserEmailVerificationTokens :exec
+UPDATE email_verification_tokens SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL
 `
 
-type ListUsersParams struct {
-	Limit  int32 `db:"limit" json:"limit"`
-	Offset int32 `db:"offset" json:"offset"`
+func (q *Queries) InvalidateUserEmailVerificationTokens(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, invalidateUserEmailVerificationTokens, userID)
+	return err
 }
 
-func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
-	rows, err := q.db.QueryContext(ctx, listUsers, arg.Limit, arg.Offset)
+const invalidateUserPasswordResetTokens = `-- name: InvalidateUserPasswordResetTokens :exec
+UPDATE password_reset_tokens SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL
+`
+
+func (q *Queries) InvalidateUserPasswordResetTokens(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, invalidateUserPasswordResetTokens, userID)
+	return err
+}
+
+const listAuditEventsByUser = `-- name: ListAuditEventsByUser :many
+SELECT id, action, outcome, actor_id, user_id, request_id, client_ip, changes, error, occurred_at FROM audit_events
+WHERE user_id = $1
+ORDER BY occurred_at DESC, id DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListAuditEventsByUserParams struct {
+	UserID uuid.NullUUID `db:"user_id" json:"user_id"`
+	Limit  int32         `db:"limit" json:"limit"`
+	Offset int32         `db:"offset" json:"offset"`
+}
+
+func (q *Queries) ListAuditEventsByUser(ctx context.Context, arg ListAuditEventsByUserParams) ([]AuditEvent, error) {
+	rows, err := q.db.QueryContext(ctx, listAuditEventsByUser, arg.UserID, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []User{}
+	items := []AuditEvent{}
 	for rows.Next() {
-		var i User
+		var i AuditEvent
 		if err := rows.Scan(
 			&i.ID,
-			&i.Email,
-			&i.Name,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.Password,
+			&i.Action,
+			&i.Outcome,
+			&i.ActorID,
+			&i.UserID,
+			&i.RequestID,
+			&i.ClientIp,
+			&i.Changes,
+			&i.Error,
+			&i.OccurredAt,
 		); err != nil {
 			return nil, err
 		}
@@ -143,26 +721,770 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 	return items, nil
 }
 
-const updateUser = `-- name: UpdateUser :one
-UPDATE users SET email = $1, name = $2, updated_at = NOW() WHERE id = $3 RETURNING id, email, name, created_at, updated_at, password
+const listLiveUserEmails = `-- name: ListLiveUserEmails :many
+SELECT email FROM users WHERE lower(email) = ANY($1::text[]) AND deleted_at IS NULL
 `
 
-type UpdateUserParams struct {
-	Email string    `db:"email" json:"email"`
-	Name  string    `db:"name" json:"name"`
-	ID    uuid.UUID `db:"id" json:"id"`
+// 渡したアドレスのうち、有効なユーザーが大文字小文字を区別せずに使っているものを返す
+func (q *Queries) ListLiveUserEmails(ctx context.Context, emails []string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listLiveUserEmails, pq.Array(emails))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, err
+		}
+		items = append(items, email)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
-	row := q.db.QueryRowContext(ctx, updateUser, arg.Email, arg.Name, arg.ID)
-	var i User
-	err := row.Scan(
-		&i.ID,
+const listUserRoles = `-- name: ListUserRoles :many
+SELECT role FROM user_roles WHERE user_id = $1 ORDER BY role
+`
+
+func (q *Queries) ListUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listUserRoles, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+		items = append(items, role)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserRolesByUserIDs = `-- name: ListUserRolesByUserIDs :many
+SELECT user_id, role FROM user_roles WHERE user_id = ANY($1::uuid[]) ORDER BY user_id, role
+`
+
+type ListUserRolesByUserIDsRow struct {
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+	Role   string    `db:"role" json:"role"`
+}
+
+func (q *Queries) ListUserRolesByUserIDs(ctx context.Context, userIds []uuid.UUID) ([]ListUserRolesByUserIDsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUserRolesByUserIDs, pq.Array(userIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUserRolesByUserIDsRow{}
+	for rows.Next() {
+		var i ListUserRolesByUserIDsRow
+		if err := rows.Scan(&i.UserID, &i.Role); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT id, email, name, created_at, updated_at, password, deleted_at, email_verified_at, version FROM users
+WHERE deleted_at IS NULL
+  AND ($1::text IS NULL OR email ILIKE $1 || '%')
+  AND ($2::text IS NULL OR name ILIKE $2 || '%')
+  AND ($3::timestamptz IS NULL OR created_at >= $3)
+  AND ($4::timestamptz IS NULL OR created_at < $4)
+ORDER BY
+  CASE WHEN $5::text = 'created_at' AND NOT $6::boolean THEN created_at END ASC,
+  CASE WHEN $5::text = 'created_at' AND $6::boolean THEN created_at END DESC,
+  CASE WHEN $5::text = 'updated_at' AND NOT $6::boolean THEN updated_at END ASC,
+  CASE WHEN $5::text = 'updated_at' AND $6::boolean THEN updated_at END DESC,
+  CASE WHEN $5::text = 'name' AND NOT $6::boolean THEN name END ASC,
+  CASE WHEN $5::text = 'name' AND $6::boolean THEN name END DESC,
+  CASE WHEN $5::text = 'email' AND NOT $6::boolean THEN email END ASC,
+  CASE WHEN $5::text = 'email' AND $6::boolean THEN email END DESC,
+  CASE WHEN NOT $6::boolean THEN id END ASC,
+  CASE WHEN $6::boolean THEN id END DESC
+LIMIT $7 OFFSET $8
+`
+
+type ListUsersParams struct {
+	EmailPrefix sql.NullString `db:"email_prefix" json:"email_prefix"`
+	NamePrefix  sql.NullString `db:"name_prefix" json:"name_prefix"`
+	CreatedFrom sql.NullTime   `db:"created_from" json:"created_from"`
+	CreatedTo   sql.NullTime   `db:"created_to" json:"created_to"`
+	SortColumn  string         `db:"sort_column" json:"sort_column"`
+	SortDesc    bool           `db:"sort_desc" json:"sort_desc"`
+	RowLimit    int32          `db:"row_limit" json:"row_limit"`
+	RowOffset   int32          `db:"row_offset" json:"row_offset"`
+}
+
+// 並び順は sort_column / sort_desc で切り替え、同値のユーザーは id で順序付ける
+func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsers,
+		arg.EmailPrefix,
+		arg.NamePrefix,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.SortColumn,
+		arg.SortDesc,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Password,
+			&i.DeletedAt,
+			&i.EmailVerifiedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersAfter = `-- name: ListUsersAfter :many
+SELECT id, email, name, created_at, updated_at, password, deleted_at, email_verified_at, version FROM users
+WHERE deleted_at IS NULL
+  AND created_at <= $1
+  AND (created_at, id) < ($1, $2)
+  AND ($3::text IS NULL OR email ILIKE $3 || '%')
+  AND ($4::text IS NULL OR name ILIKE $4 || '%')
+  AND ($5::timestamptz IS NULL OR created_at >= $5)
+  AND ($6::timestamptz IS NULL OR created_at < $6)
+ORDER BY created_at DESC, id DESC
+LIMIT $7
+`
+
+type ListUsersAfterParams struct {
+	CreatedAt   sql.NullTime   `db:"created_at" json:"created_at"`
+	ID          uuid.UUID      `db:"id" json:"id"`
+	EmailPrefix sql.NullString `db:"email_prefix" json:"email_prefix"`
+	NamePrefix  sql.NullString `db:"name_prefix" json:"name_prefix"`
+	CreatedFrom sql.NullTime   `db:"created_from" json:"created_from"`
+	CreatedTo   sql.NullTime   `db:"created_to" json:"created_to"`
+	RowLimit    int32          `db:"row_limit" json:"row_limit"`
+}
+
+// created_at の条件で idx_users_created_at を使い、同時刻のユーザーは id で順序付ける
+func (q *Queries) ListUsersAfter(ctx context.Context, arg ListUsersAfterParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsersAfter,
+		arg.CreatedAt,
+		arg.ID,
+		arg.EmailPrefix,
+		arg.NamePrefix,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Password,
+			&i.DeletedAt,
+			&i.EmailVerifiedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersBefore = `-- name: ListUsersBefore :many
+SELECT id, email, name, created_at, updated_at, password, deleted_at, email_verified_at, version FROM users
+WHERE deleted_at IS NULL
+  AND created_at >= $1
+  AND (created_at, id) > ($1, $2)
+  AND ($3::text IS NULL OR email ILIKE $3 || '%')
+  AND ($4::text IS NULL OR name ILIKE $4 || '%')
+  AND ($5::timestamptz IS NULL OR created_at >= $5)
+  AND ($6::timestamptz IS NULL OR created_at < $6)
+ORDER BY created_at ASC, id ASC
+LIMIT $7
+`
+
+type ListUsersBeforeParams struct {
+	CreatedAt   sql.NullTime   `db:"created_at" json:"created_at"`
+	ID          uuid.UUID      `db:"id" json:"id"`
+	EmailPrefix sql.NullString `db:"email_prefix" json:"email_prefix"`
+	NamePrefix  sql.NullString `db:"name_prefix" json:"name_prefix"`
+	CreatedFrom sql.NullTime   `db:"created_from" json:"created_from"`
+	CreatedTo   sql.NullTime   `db:"created_to" json:"created_to"`
+	RowLimit    int32          `db:"row_limit" json:"row_limit"`
+}
+
+// 前のページは古い順に取得する（呼び出し側で新しい順に並べ替える）
+func (q *Queries) ListUsersBefore(ctx context.Context, arg ListUsersBeforeParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsersBefore,
+		arg.CreatedAt,
+		arg.ID,
+		arg.EmailPrefix,
+		arg.NamePrefix,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Password,
+			&i.DeletedAt,
+			&i.EmailVerifiedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockLoginThrottle = `-- name: LockLoginThrottle :exec
+UPDATE login_throttles
+SET failures = 0,
+    locked_until = NOW() + ($1::bigint * INTERVAL '1 millisecond')
+WHERE scope = $2 AND subject = $3
+`
+
+type LockLoginThrottleParams struct {
+	LockoutMs int64  `db:"lockout_ms" json:"lockout_ms"`
+	Scope     string `db:"scope" json:"scope"`
+	Subject   string `db:"subject" json:"subject"`
+}
+
+func (q *Queries) LockLoginThrottle(ctx context.Context, arg LockLoginThrottleParams) error {
+	_, err := q.db.ExecContext(ctx, lockLoginThrottle, arg.LockoutMs, arg.Scope, arg.Subject)
+	return err
+}
+
+const markOutboxEventFailed = `-- name: MarkOutboxEventFailed :exec
+UPDATE outbox_events SET status = 'failed', error_reason = $2 WHERE seq_id = $1
+`
+
+type MarkOutboxEventFailedParams struct {
+	SeqID       int64          `db:"seq_id" json:"seq_id"`
+	ErrorReason sql.NullString `db:"error_reason" json:"error_reason"`
+}
+
+func (q *Queries) MarkOutboxEventFailed(ctx context.Context, arg MarkOutboxEventFailedParams) error {
+	_, err := q.db.ExecContext(ctx, markOutboxEventFailed, arg.SeqID, arg.ErrorReason)
+	return err
+}
+
+const markOutboxEventPublished = `-- name: MarkOutboxEventPublished :exec
+UPDATE outbox_events SET status = 'published', published_at = NOW(), error_reason = NULL WHERE seq_id = $1
+`
+
+func (q *Queries) MarkOutboxEventPublished(ctx context.Context, seqID int64) error {
+	_, err := q.db.ExecContext(ctx, markOutboxEventPublished, seqID)
+	return err
+}
+
+const markUserEmailVerified = `-- name: MarkUserEmailVerified :one
+UPDATE users SET email_verified_at = COALESCE(email_verified_at, NOW()), updated_at = NOW(), version = version + 1
+WHERE id = $1 AND lower(email) = lower($2::text) AND deleted_at IS NULL RETURNING id, email, name, created_at, updated_at, password, deleted_at, email_verified_at, version
+`
+
+type MarkUserEmailVerifiedParams struct {
+	ID    uuid.UUID `db:"id" json:"id"`
+	Email string    `db:"email" json:"email"`
+}
+
+// トークンの発行後にメールアドレスが変更されていれば該当行なしになる
+func (q *Queries) MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVerifiedParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, markUserEmailVerified, arg.ID, arg.Email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Password,
+		&i.DeletedAt,
+		&i.EmailVerifiedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const patchUser = `-- name: PatchUser :one
+UPDATE users
+SET email = COALESCE($1::text, email),
+    name = COALESCE($2::text, name),
+    email_verified_at = CASE WHEN $1::text IS NULL OR lower(email) = lower($1::text) THEN email_verified_at END,
+    updated_at = NOW(), version = version + 1
+WHERE id = $3 AND version = $4 AND deleted_at IS NULL RETURNING id, email, name, created_at, updated_at, password, deleted_at, email_verified_at, version
+`
+
+type PatchUserParams struct {
+	Email   sql.NullString `db:"email" json:"email"`
+	Name    sql.NullString `db:"name" json:"name"`
+	ID      uuid.UUID      `db:"id" json:"id"`
+	Version int64          `db:"version" json:"version"`
+}
+
+// NULL の引数の列は書き換えない。version の確認とメールアドレス変更時の扱いは UpdateUser と同じ
+func (q *Queries) PatchUser(ctx context.Context, arg PatchUserParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, patchUser,
+		arg.Email,
+		arg.Name,
+		arg.ID,
+		arg.Version,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Password,
+		&i.DeletedAt,
+		&i.EmailVerifiedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const purgeDeletedUsers = `-- name: PurgeDeletedUsers :execrows
+DELETE FROM users WHERE deleted_at < NOW() - ($1::bigint * INTERVAL '1 millisecond')
+`
+
+func (q *Queries) PurgeDeletedUsers(ctx context.Context, retentionMs int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeDeletedUsers, retentionMs)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeExpiredIdempotencyKeys = `-- name: PurgeExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys WHERE expires_at <= NOW()
+`
+
+func (q *Queries) PurgeExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeExpiredIdempotencyKeys)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeExpiredLoginThrottles = `-- name: PurgeExpiredLoginThrottles :execrows
+DELETE FROM login_throttles
+WHERE (locked_until IS NULL OR locked_until <= NOW())
+  AND last_failure_at < NOW() - ($1::bigint * INTERVAL '1 millisecond')
+`
+
+// ロックされておらず最後の失敗がウィンドウ外の行は、次の失敗でカウントがリセットされるため削除してよい
+func (q *Queries) PurgeExpiredLoginThrottles(ctx context.Context, windowMs int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeExpiredLoginThrottles, windowMs)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const recordLoginFailure = `-- name: RecordLoginFailure :one
+INSERT INTO login_throttles (scope, subject, failures, last_failure_at)
+VALUES ($1, $2, 1, NOW())
+ON CONFLICT (scope, subject) DO UPDATE
+SET failures = CASE
+        WHEN login_throttles.last_failure_at < NOW() - ($3::bigint * INTERVAL '1 millisecond') THEN 1
+        ELSE login_throttles.failures + 1
+    END,
+    last_failure_at = NOW()
+RETURNING scope, subject, failures, last_failure_at, locked_until
+`
+
+type RecordLoginFailureParams struct {
+	Scope    string `db:"scope" json:"scope"`
+	Subject  string `db:"subject" json:"subject"`
+	WindowMs int64  `db:"window_ms" json:"window_ms"`
+}
+
+// ウィンドウ外の失敗はカウントをリセットする
+func (q *Queries) RecordLoginFailure(ctx context.Context, arg RecordLoginFailureParams) (LoginThrottle, error) {
+	row := q.db.QueryRowContext(ctx, recordLoginFailure, arg.Scope, arg.Subject, arg.WindowMs)
+	var i LoginThrottle
+	err := row.Scan(
+		&i.Scope,
+		&i.Subject,
+		&i.Failures,
+		&i.LastFailureAt,
+		&i.LockedUntil,
+	)
+	return i, err
+}
+
+const reserveIdempotencyKey = `-- name: ReserveIdempotencyKey :one
+INSERT INTO idempotency_keys (scope, idempotency_key, request_hash, expires_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (scope, idempotency_key) DO UPDATE
+SET request_hash = EXCLUDED.request_hash,
+    status_code = NULL,
+    response_headers = '{}',
+    response_body = NULL,
+    created_at = NOW(),
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= NOW()
+RETURNING scope, idempotency_key, request_hash, status_code, response_headers, response_body, created_at, expires_at
+`
+
+type ReserveIdempotencyKeyParams struct {
+	Scope          string    `db:"scope" json:"scope"`
+	IdempotencyKey string    `db:"idempotency_key" json:"idempotency_key"`
+	RequestHash    string    `db:"request_hash" json:"request_hash"`
+	ExpiresAt      time.Time `db:"expires_at" json:"expires_at"`
+}
+
+// 期限切れの同じキーは上書きする。有効なキーが既にあれば 0 件（sql.ErrNoRows）になる
+func (q *Queries) ReserveIdempotencyKey(ctx context.Context, arg ReserveIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.db.QueryRowContext(ctx, reserveIdempotencyKey,
+		arg.Scope,
+		arg.IdempotencyKey,
+		arg.RequestHash,
+		arg.ExpiresAt,
+	)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Scope,
+		&i.IdempotencyKey,
+		&i.RequestHash,
+		&i.StatusCode,
+		&i.ResponseHeaders,
+		&i.ResponseBody,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const restoreUser = `-- name: RestoreUser :one
+UPDATE users SET deleted_at = NULL, updated_at = NOW(), version = version + 1 WHERE id = $1 AND deleted_at IS NOT NULL RETURNING id, email, name, created_at, updated_at, password, deleted_at, email_verified_at, version
+`
+
+func (q *Queries) RestoreUser(ctx context.Context, id uuid.UUID) (User, error) {
+	row := q.db.QueryRowContext(ctx, restoreUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Password,
+		&i.DeletedAt,
+		&i.EmailVerifiedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const revokeRefreshToken = `-- name: RevokeRefreshToken :execrows
+UPDATE refresh_tokens SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL
+`
+
+func (q *Queries) RevokeRefreshToken(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeRefreshToken, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const revokeUserRefreshTokens = `-- name: RevokeUserRefreshTokens :exec
+UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL
+`
+
+func (q *Queries) RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, revokeUserRefreshTokens, userID)
+	return err
+}
+
+const revokeUserRole = `-- name: RevokeUserRole :exec
+DELETE FROM user_roles WHERE user_id = $1 AND role = $2
+`
+
+type RevokeUserRoleParams struct {
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+	Role   string    `db:"role" json:"role"`
+}
+
+func (q *Queries) RevokeUserRole(ctx context.Context, arg RevokeUserRoleParams) error {
+	_, err := q.db.ExecContext(ctx, revokeUserRole, arg.UserID, arg.Role)
+	return err
+}
+
+const scheduleOutboxEventRetry = `-- name: ScheduleOutboxEventRetry :exec
+UPDATE outbox_events
+SET next_attempt_at = NOW() + ($1::bigint * INTERVAL '1 millisecond'),
+    error_reason = $2
+WHERE seq_id = $3
+`
+
+type ScheduleOutboxEventRetryParams struct {
+	DelayMs     int64          `db:"delay_ms" json:"delay_ms"`
+	ErrorReason sql.NullString `db:"error_reason" json:"error_reason"`
+	SeqID       int64          `db:"seq_id" json:"seq_id"`
+}
+
+func (q *Queries) ScheduleOutboxEventRetry(ctx context.Context, arg ScheduleOutboxEventRetryParams) error {
+	_, err := q.db.ExecContext(ctx, scheduleOutboxEventRetry, arg.DelayMs, arg.ErrorReason, arg.SeqID)
+	return err
+}
+
+const searchUsers = `-- name: SearchUsers :many
+SELECT id, email, name, created_at, updated_at, password, deleted_at, email_verified_at, version,
+       GREATEST(similarity(name, $1::text), similarity(email, $1::text))::float8 AS score
+FROM users
+WHERE deleted_at IS NULL
+  AND (name ILIKE $2::text OR email ILIKE $2::text
+       OR name % $1::text OR email % $1::text)
+ORDER BY score DESC, id
+LIMIT $3 OFFSET $4
+`
+
+type SearchUsersParams struct {
+	Query     string `db:"query" json:"query"`
+	Pattern   string `db:"pattern" json:"pattern"`
+	RowLimit  int32  `db:"row_limit" json:"row_limit"`
+	RowOffset int32  `db:"row_offset" json:"row_offset"`
+}
+
+type SearchUsersRow struct {
+	ID              uuid.UUID    `db:"id" json:"id"`
+	Email           string       `db:"email" json:"email"`
+	Name            string       `db:"name" json:"name"`
+	CreatedAt       sql.NullTime `db:"created_at" json:"created_at"`
+	UpdatedAt       sql.NullTime `db:"updated_at" json:"updated_at"`
+	Password        string       `db:"password" json:"password"`
+	DeletedAt       sql.NullTime `db:"deleted_at" json:"deleted_at"`
+	EmailVerifiedAt sql.NullTime `db:"email_verified_at" json:"email_verified_at"`
+	Version         int64        `db:"version" json:"version"`
+	Score           float64      `db:"score" json:"score"`
+}
+
+// 名前・メールアドレスの部分一致またはトライグラム類似度で検索し、類似度の高い順に返す
+func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]SearchUsersRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchUsers,
+		arg.Query,
+		arg.Pattern,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchUsersRow{}
+	for rows.Next() {
+		var i SearchUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Password,
+			&i.DeletedAt,
+			&i.EmailVerifiedAt,
+			&i.Version,
+			&i.Score,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const softDeleteUser = `-- name: SoftDeleteUser :one
+UPDATE users SET deleted_at = NOW(), updated_at = NOW(), version = version + 1 WHERE id = $1 AND deleted_at IS NULL RETURNING id, email, name, created_at, updated_at, password, deleted_at, email_verified_at, version
+`
+
+func (q *Queries) SoftDeleteUser(ctx context.Context, id uuid.UUID) (User, error) {
+	row := q.db.QueryRowContext(ctx, softDeleteUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Password,
+		&i.DeletedAt,
+		&i.EmailVerifiedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const updateUser = `-- name: UpdateUser :one
+UPDATE users
+SET email = $1, name = $2, updated_at = NOW(), version = version + 1,
+    email_verified_at = CASE WHEN lower(email) = lower($1) THEN email_verified_at END
+WHERE id = $3 AND version = $4 AND deleted_at IS NULL RETURNING id, email, name, created_at, updated_at, password, deleted_at, email_verified_at, version
+`
+
+type UpdateUserParams struct {
+	Email   string    `db:"email" json:"email"`
+	Name    string    `db:"name" json:"name"`
+	ID      uuid.UUID `db:"id" json:"id"`
+	Version int64     `db:"version" json:"version"`
+}
+
+// メールアドレスが（大文字小文字の違いを除いて）変わった場合は未検証に戻す
+// 読み込んだ時点から version が進んでいれば 0 件（sql.ErrNoRows）になる
+func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUser,
+		arg.Email,
+		arg.Name,
+		arg.ID,
+		arg.Version,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
 		&i.Email,
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Password,
+		&i.DeletedAt,
+		&i.EmailVerifiedAt,
+		&i.Version,
 	)
 	return i, err
 }
+
+const updateUserPassword = `-- name: UpdateUserPassword :one
+UPDATE users SET password = $2, updated_at = NOW(), version = version + 1 WHERE id = $1 AND deleted_at IS NULL RETURNING id, email, name, created_at, updated_at, password, deleted_at, email_verified_at, version
+`
+
+type UpdateUserPasswordParams struct {
+	ID       uuid.UUID `db:"id" json:"id"`
+	Password string    `db:"password" json:"password"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserPassword, arg.ID, arg.Password)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Password,
+		&i.DeletedAt,
+		&i.EmailVerifiedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const useEmailVerificationToken = `-- name: UseEmailVerificationToken :execrows
+UPDATE email_verification_tokens SET used_at = NOW() WHERE id = $1 AND used_at IS NULL
+`
+
+func (q *Queries) UseEmailVerificationToken(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, useEmailVerificationToken, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const usePasswordResetToken = `-- name: UsePasswordResetToken :execrows
+UPDATE password_reset_tokens SET used_at = NOW() WHERE id = $1 AND used_at IS NULL
+`
+
+func (q *Queries) UsePasswordResetToken(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, usePasswordResetToken, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
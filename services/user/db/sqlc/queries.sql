@@ -18,25 +18,308 @@ INSERT INTO users (
 ) RETURNING *;
 
 -- name: CheckUserExistsByID :one
-SELECT EXISTS(SELECT 1 FROM users WHERE id = $1);
+SELECT EXISTS(SELECT 1 FROM users WHERE id = $1 AND deleted_at IS NULL);
 
 -- name: CheckUserExistsByEmail :one
-SELECT EXISTS(SELECT 1 FROM users WHERE email = $1);
+SELECT EXISTS(SELECT 1 FROM users WHERE lower(email) = lower(sqlc.arg(email)::text) AND deleted_at IS NULL);
 
 -- name: GetUserByID :one
-SELECT * FROM users WHERE id = $1;
+SELECT * FROM users WHERE id = $1 AND deleted_at IS NULL;
 
 -- name: GetUserByEmail :one
-SELECT * FROM users WHERE email = $1;
-
--- name: GetUserByName :one
-SELECT * FROM users WHERE name = $1;
+-- メールアドレスは大文字小文字を区別せずに一意（users_email_live_key）
+SELECT * FROM users WHERE lower(email) = lower(sqlc.arg(email)::text) AND deleted_at IS NULL;
 
 -- name: UpdateUser :one
-UPDATE users SET email = $1, name = $2, updated_at = NOW() WHERE id = $3 RETURNING *;
+-- メールアドレスが（大文字小文字の違いを除いて）変わった場合は未検証に戻す
+-- 読み込んだ時点から version が進んでいれば 0 件（sql.ErrNoRows）になる
+UPDATE users
+SET email = $1, name = $2, updated_at = NOW(), version = version + 1,
+    email_verified_at = CASE WHEN lower(email) = lower($1) THEN email_verified_at END
+WHERE id = $3 AND version = $4 AND deleted_at IS NULL RETURNING *;
+
+-- name: PatchUser :one
+-- NULL の引数の列は書き換えない。version の確認とメールアドレス変更時の扱いは UpdateUser と同じ
+UPDATE users
+SET email = COALESCE(sqlc.narg(email)::text, email),
+    name = COALESCE(sqlc.narg(name)::text, name),
+    email_verified_at = CASE WHEN sqlc.narg(email)::text IS NULL OR lower(email) = lower(sqlc.narg(email)::text) THEN email_verified_at END,
+    updated_at = NOW(), version = version + 1
+WHERE id = sqlc.arg(id) AND version = sqlc.arg(version) AND deleted_at IS NULL RETURNING *;
+
+-- name: MarkUserEmailVerified :one
+-- トークンの発行後にメールアドレスが変更されていれば該当行なしになる
+UPDATE users SET email_verified_at = COALESCE(email_verified_at, NOW()), updated_at = NOW(), version = version + 1
+WHERE id = sqlc.arg(id) AND lower(email) = lower(sqlc.arg(email)::text) AND deleted_at IS NULL RETURNING *;
+
+-- name: SoftDeleteUser :one
+UPDATE users SET deleted_at = NOW(), updated_at = NOW(), version = version + 1 WHERE id = $1 AND deleted_at IS NULL RETURNING *;
 
--- name: DeleteUser :exec
-DELETE FROM users WHERE id = $1;
+-- name: RestoreUser :one
+UPDATE users SET deleted_at = NULL, updated_at = NOW(), version = version + 1 WHERE id = $1 AND deleted_at IS NOT NULL RETURNING *;
+
+-- name: PurgeDeletedUsers :execrows
+DELETE FROM users WHERE deleted_at < NOW() - (sqlc.arg(retention_ms)::bigint * INTERVAL '1 millisecond');
 
 -- name: ListUsers :many
-SELECT * FROM users ORDER BY created_at DESC LIMIT $1 OFFSET $2;
+-- 並び順は sort_column / sort_desc で切り替え、同値のユーザーは id で順序付ける
+SELECT * FROM users
+WHERE deleted_at IS NULL
+  AND (sqlc.narg(email_prefix)::text IS NULL OR email ILIKE sqlc.narg(email_prefix) || '%')
+  AND (sqlc.narg(name_prefix)::text IS NULL OR name ILIKE sqlc.narg(name_prefix) || '%')
+  AND (sqlc.narg(created_from)::timestamptz IS NULL OR created_at >= sqlc.narg(created_from))
+  AND (sqlc.narg(created_to)::timestamptz IS NULL OR created_at < sqlc.narg(created_to))
+ORDER BY
+  CASE WHEN sqlc.arg(sort_column)::text = 'created_at' AND NOT sqlc.arg(sort_desc)::boolean THEN created_at END ASC,
+  CASE WHEN sqlc.arg(sort_column)::text = 'created_at' AND sqlc.arg(sort_desc)::boolean THEN created_at END DESC,
+  CASE WHEN sqlc.arg(sort_column)::text = 'updated_at' AND NOT sqlc.arg(sort_desc)::boolean THEN updated_at END ASC,
+  CASE WHEN sqlc.arg(sort_column)::text = 'updated_at' AND sqlc.arg(sort_desc)::boolean THEN updated_at END DESC,
+  CASE WHEN sqlc.arg(sort_column)::text = 'name' AND NOT sqlc.arg(sort_desc)::boolean THEN name END ASC,
+  CASE WHEN sqlc.arg(sort_column)::text = 'name' AND sqlc.arg(sort_desc)::boolean THEN name END DESC,
+  CASE WHEN sqlc.arg(sort_column)::text = 'email' AND NOT sqlc.arg(sort_desc)::boolean THEN email END ASC,
+  CASE WHEN sqlc.arg(sort_column)::text = 'email' AND sqlc.arg(sort_desc)::boolean THEN email END DESC,
+  CASE WHEN NOT sqlc.arg(sort_desc)::boolean THEN id END ASC,
+  CASE WHEN sqlc.arg(sort_desc)::boolean THEN id END DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: ListUsersAfter :many
+-- created_at の条件で idx_users_created_at を使い、同時刻のユーザーは id で順序付ける
+SELECT * FROM users
+WHERE deleted_at IS NULL
+  AND created_at <= sqlc.arg(created_at)
+  AND (created_at, id) < (sqlc.arg(created_at), sqlc.arg(id))
+  AND (sqlc.narg(email_prefix)::text IS NULL OR email ILIKE sqlc.narg(email_prefix) || '%')
+  AND (sqlc.narg(name_prefix)::text IS NULL OR name ILIKE sqlc.narg(name_prefix) || '%')
+  AND (sqlc.narg(created_from)::timestamptz IS NULL OR created_at >= sqlc.narg(created_from))
+  AND (sqlc.narg(created_to)::timestamptz IS NULL OR created_at < sqlc.narg(created_to))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListUsersBefore :many
+-- 前のページは古い順に取得する（呼び出し側で新しい順に並べ替える）
+SELECT * FROM users
+WHERE deleted_at IS NULL
+  AND created_at >= sqlc.arg(created_at)
+  AND (created_at, id) > (sqlc.arg(created_at), sqlc.arg(id))
+  AND (sqlc.narg(email_prefix)::text IS NULL OR email ILIKE sqlc.narg(email_prefix) || '%')
+  AND (sqlc.narg(name_prefix)::text IS NULL OR name ILIKE sqlc.narg(name_prefix) || '%')
+  AND (sqlc.narg(created_from)::timestamptz IS NULL OR created_at >= sqlc.narg(created_from))
+  AND (sqlc.narg(created_to)::timestamptz IS NULL OR created_at < sqlc.narg(created_to))
+ORDER BY created_at ASC, id ASC
+LIMIT sqlc.arg(row_limit);
+
+-- name: CountUsers :one
+SELECT COUNT(*) FROM users
+WHERE deleted_at IS NULL
+  AND (sqlc.narg(email_prefix)::text IS NULL OR email ILIKE sqlc.narg(email_prefix) || '%')
+  AND (sqlc.narg(name_prefix)::text IS NULL OR name ILIKE sqlc.narg(name_prefix) || '%')
+  AND (sqlc.narg(created_from)::timestamptz IS NULL OR created_at >= sqlc.narg(created_from))
+  AND (sqlc.narg(created_to)::timestamptz IS NULL OR created_at < sqlc.narg(created_to));
+
+-- name: EstimateUserCount :one
+-- 統計情報に基づく概算の行数（論理削除済みを含む。未 ANALYZE のテーブルでは -1）
+SELECT reltuples::bigint AS estimate FROM pg_class WHERE oid = 'users'::regclass;
+
+-- name: SearchUsers :many
+-- 名前・メールアドレスの部分一致またはトライグラム類似度で検索し、類似度の高い順に返す
+SELECT id, email, name, created_at, updated_at, password, deleted_at, email_verified_at, version,
+       GREATEST(similarity(name, sqlc.arg(query)::text), similarity(email, sqlc.arg(query)::text))::float8 AS score
+FROM users
+WHERE deleted_at IS NULL
+  AND (name ILIKE sqlc.arg(pattern)::text OR email ILIKE sqlc.arg(pattern)::text
+       OR name % sqlc.arg(query)::text OR email % sqlc.arg(query)::text)
+ORDER BY score DESC, id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountSearchUsers :one
+SELECT COUNT(*) FROM users
+WHERE deleted_at IS NULL
+  AND (name ILIKE sqlc.arg(pattern)::text OR email ILIKE sqlc.arg(pattern)::text
+       OR name % sqlc.arg(query)::text OR email % sqlc.arg(query)::text);
+
+-- name: InsertOutboxEvent :one
+INSERT INTO outbox_events (
+    event_id,
+    event_type,
+    payload,
+    version,
+    occurred_at
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ClaimOutboxEvents :many
+UPDATE outbox_events
+SET attempts = attempts + 1,
+    next_attempt_at = NOW() + (sqlc.arg(lease_ms)::bigint * INTERVAL '1 millisecond')
+WHERE seq_id IN (
+    SELECT seq_id FROM outbox_events
+    WHERE status = 'pending' AND next_attempt_at <= NOW()
+    ORDER BY seq_id
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkOutboxEventPublished :exec
+UPDATE outbox_events SET status = 'published', published_at = NOW(), error_reason = NULL WHERE seq_id = $1;
+
+-- name: MarkOutboxEventFailed :exec
+UPDATE outbox_events SET status = 'failed', error_reason = $2 WHERE seq_id = $1;
+
+-- name: ScheduleOutboxEventRetry :exec
+UPDATE outbox_events
+SET next_attempt_at = NOW() + (sqlc.arg(delay_ms)::bigint * INTERVAL '1 millisecond'),
+    error_reason = sqlc.arg(error_reason)
+WHERE seq_id = sqlc.arg(seq_id);
+
+-- name: UpdateUserPassword :one
+UPDATE users SET password = $2, updated_at = NOW(), version = version + 1 WHERE id = $1 AND deleted_at IS NULL RETURNING *;
+
+-- name: CreateRefreshToken :one
+INSERT INTO refresh_tokens (
+    id,
+    user_id,
+    token_hash,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetRefreshTokenByHash :one
+SELECT * FROM refresh_tokens WHERE token_hash = $1;
+
+-- name: RevokeRefreshToken :execrows
+UPDATE refresh_tokens SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeUserRefreshTokens :exec
+UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL;
+
+-- name: GrantUserRole :exec
+INSERT INTO user_roles (user_id, role) VALUES ($1, $2) ON CONFLICT DO NOTHING;
+
+-- name: RevokeUserRole :exec
+DELETE FROM user_roles WHERE user_id = $1 AND role = $2;
+
+-- name: ListUserRoles :many
+SELECT role FROM user_roles WHERE user_id = $1 ORDER BY role;
+
+-- name: ListLiveUserEmails :many
+-- 渡したアドレスのうち、有効なユーザーが大文字小文字を区別せずに使っているものを返す
+SELECT email FROM users WHERE lower(email) = ANY(sqlc.arg(emails)::text[]) AND deleted_at IS NULL;
+
+-- name: ListUserRolesByUserIDs :many
+SELECT user_id, role FROM user_roles WHERE user_id = ANY(sqlc.arg(user_ids)::uuid[]) ORDER BY user_id, role;
+
+-- name: CreatePasswordResetToken :one
+INSERT INTO password_reset_tokens (
+    id,
+    user_id,
+    token_hash,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetPasswordResetTokenByHash :one
+SELECT * FROM password_reset_tokens WHERE token_hash = $1;
+
+-- name: UsePasswordResetToken :execrows
+UPDATE password_reset_tokens SET used_at = NOW() WHERE id = $1 AND used_at IS NULL;
+
+-- name: InvalidateUserPasswordResetTokens :exec
+UPDATE password_reset_tokens SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL;
+
+-- name: CreateEmailVerificationToken :one
+INSERT INTO email_verification_tokens (
+    id,
+    user_id,
+    email,
+    token_hash,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetEmailVerificationTokenByHash :one
+SELECT * FROM email_verification_tokens WHERE token_hash = $1;
+
+-- name: UseEmailVerificationToken :execrows
+UPDATE email_verification_tokens SET used_at = NOW() WHERE id = $1 AND used_at IS NULL;
+
+-- name: InvalidateUserEmailVerificationTokens :exec
+UPDATE email_verification_tokens SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL;
+
+-- name: CountEmailVerificationTokensSince :one
+SELECT count(*) FROM email_verification_tokens WHERE user_id = $1 AND created_at >= sqlc.arg(since);
+
+-- name: GetLoginThrottle :one
+SELECT * FROM login_throttles WHERE scope = $1 AND subject = $2;
+
+-- name: RecordLoginFailure :one
+-- ウィンドウ外の失敗はカウントをリセットする
+INSERT INTO login_throttles (scope, subject, failures, last_failure_at)
+VALUES (sqlc.arg(scope), sqlc.arg(subject), 1, NOW())
+ON CONFLICT (scope, subject) DO UPDATE
+SET failures = CASE
+        WHEN login_throttles.last_failure_at < NOW() - (sqlc.arg(window_ms)::bigint * INTERVAL '1 millisecond') THEN 1
+        ELSE login_throttles.failures + 1
+    END,
+    last_failure_at = NOW()
+RETURNING *;
+
+-- name: LockLoginThrottle :exec
+UPDATE login_throttles
+SET failures = 0,
+    locked_until = NOW() + (sqlc.arg(lockout_ms)::bigint * INTERVAL '1 millisecond')
+WHERE scope = sqlc.arg(scope) AND subject = sqlc.arg(subject);
+
+-- name: DeleteLoginThrottle :exec
+DELETE FROM login_throttles WHERE scope = $1 AND subject = $2;
+
+-- name: PurgeExpiredLoginThrottles :execrows
+-- ロックされておらず最後の失敗がウィンドウ外の行は、次の失敗でカウントがリセットされるため削除してよい
+DELETE FROM login_throttles
+WHERE (locked_until IS NULL OR locked_until <= NOW())
+  AND last_failure_at < NOW() - (sqlc.arg(window_ms)::bigint * INTERVAL '1 millisecond');
+
+-- name: ReserveIdempotencyKey :one
+-- 期限切れの同じキーは上書きする。有効なキーが既にあれば 0 件（sql.ErrNoRows）になる
+INSERT INTO idempotency_keys (scope, idempotency_key, request_hash, expires_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (scope, idempotency_key) DO UPDATE
+SET request_hash = EXCLUDED.request_hash,
+    status_code = NULL,
+    response_headers = '{}',
+    response_body = NULL,
+    created_at = NOW(),
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= NOW()
+RETURNING *;
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys WHERE scope = $1 AND idempotency_key = $2;
+
+-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET status_code = sqlc.arg(status_code), response_headers = sqlc.arg(response_headers), response_body = sqlc.arg(response_body)
+WHERE scope = sqlc.arg(scope) AND idempotency_key = sqlc.arg(idempotency_key);
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys WHERE scope = $1 AND idempotency_key = $2;
+
+-- name: PurgeExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys WHERE expires_at <= NOW();
+
+-- name: CreateAuditEvent :exec
+INSERT INTO audit_events (id, action, outcome, actor_id, user_id, request_id, client_ip, changes, error, occurred_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: ListAuditEventsByUser :many
+SELECT * FROM audit_events
+WHERE user_id = $1
+ORDER BY occurred_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountAuditEventsByUser :one
+SELECT COUNT(*) FROM audit_events WHERE user_id = $1;
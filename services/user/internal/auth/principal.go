@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+)
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	UserID uuid.UUID
+	Roles  []domain.Role
+}
+
+// HasRole reports whether the principal has the given role
+func (p *Principal) HasRole(role domain.Role) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+// HasPermission reports whether any role of the principal allows the permission
+func (p *Principal) HasPermission(permission domain.Permission) bool {
+	return domain.HasPermission(p.Roles, permission)
+}
+
+// CanActOnUser reports whether the principal may act on the given user (themselves, or anyone with the permission)
+func (p *Principal) CanActOnUser(userID uuid.UUID, permission domain.Permission) bool {
+	return p.UserID == userID || p.HasPermission(permission)
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the principal
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the principal stored in ctx, if any
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
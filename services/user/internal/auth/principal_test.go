@@ -0,0 +1,55 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/auth"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrincipal_CanActOnUser(t *testing.T) {
+	self := uuid.New()
+	other := uuid.New()
+
+	testCases := []struct {
+		name       string
+		principal  auth.Principal
+		target     uuid.UUID
+		permission domain.Permission
+		want       bool
+	}{
+		{name: "正常系：本人", principal: auth.Principal{UserID: self}, target: self, permission: domain.PermissionWriteAnyUser, want: true},
+		{name: "正常系：管理者は他人も可", principal: auth.Principal{UserID: self, Roles: []domain.Role{domain.RoleAdmin}}, target: other, permission: domain.PermissionWriteAnyUser, want: true},
+		{name: "正常系：サポートは他人を参照可", principal: auth.Principal{UserID: self, Roles: []domain.Role{domain.RoleSupport}}, target: other, permission: domain.PermissionReadAnyUser, want: true},
+		{name: "異常系：サポートは他人を更新不可", principal: auth.Principal{UserID: self, Roles: []domain.Role{domain.RoleSupport}}, target: other, permission: domain.PermissionWriteAnyUser, want: false},
+		{name: "異常系：一般ユーザーは他人不可", principal: auth.Principal{UserID: self}, target: other, permission: domain.PermissionReadAnyUser, want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.principal.CanActOnUser(tc.target, tc.permission))
+		})
+	}
+}
+
+func TestPrincipalContext(t *testing.T) {
+	t.Run("正常系：コンテキストから取得できる", func(t *testing.T) {
+		p := &auth.Principal{UserID: uuid.New()}
+		ctx := auth.WithPrincipal(context.Background(), p)
+
+		got, ok := auth.PrincipalFromContext(ctx)
+
+		require.True(t, ok)
+		assert.Equal(t, p, got)
+	})
+
+	t.Run("異常系：未設定", func(t *testing.T) {
+		_, ok := auth.PrincipalFromContext(context.Background())
+
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,23 @@
+package auth
+
+import "context"
+
+// RequestInfo identifies the request an operation was made in, for audit records
+type RequestInfo struct {
+	// RequestID is the ID assigned by the request ID middleware
+	RequestID string
+	ClientIP  string
+}
+
+type requestInfoKey struct{}
+
+// WithRequestInfo returns a copy of ctx carrying the request info
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFromContext returns the request info stored in ctx; it is empty outside a request, such as in background jobs
+func RequestInfoFromContext(ctx context.Context) RequestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info
+}
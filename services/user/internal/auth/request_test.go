@@ -0,0 +1,22 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestInfoContext(t *testing.T) {
+	t.Run("正常系：コンテキストから取得できる", func(t *testing.T) {
+		info := auth.RequestInfo{RequestID: "host/abc-000001", ClientIP: "192.0.2.1"}
+		ctx := auth.WithRequestInfo(context.Background(), info)
+
+		assert.Equal(t, info, auth.RequestInfoFromContext(ctx))
+	})
+
+	t.Run("正常系：未設定なら空", func(t *testing.T) {
+		assert.Equal(t, auth.RequestInfo{}, auth.RequestInfoFromContext(context.Background()))
+	})
+}
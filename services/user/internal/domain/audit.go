@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditAction is the operation an audit event records
+type AuditAction string
+
+const (
+	AuditActionUserCreate       AuditAction = "user.create"
+	AuditActionUserUpdate       AuditAction = "user.update"
+	AuditActionUserDelete       AuditAction = "user.delete"
+	AuditActionUserRestore      AuditAction = "user.restore"
+	AuditActionUserAuthenticate AuditAction = "user.authenticate"
+	AuditActionUserUnlock       AuditAction = "user.unlock"
+	AuditActionPasswordChange   AuditAction = "user.password_change"
+	AuditActionPasswordReset    AuditAction = "user.password_reset"
+	AuditActionEmailVerify      AuditAction = "user.email_verify"
+	AuditActionRoleGrant        AuditAction = "user.role_grant"
+	AuditActionRoleRevoke       AuditAction = "user.role_revoke"
+)
+
+// AuditOutcome tells whether the audited operation succeeded
+type AuditOutcome string
+
+const (
+	AuditOutcomeSuccess AuditOutcome = "success"
+	AuditOutcomeFailure AuditOutcome = "failure"
+)
+
+// AuditRedacted replaces the values of secret fields, such as password hashes, in audit changes
+const AuditRedacted = "[REDACTED]"
+
+// AuditChange is the value of one field before and after an operation; nil means the field had no value
+type AuditChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// AuditEvent records who performed an operation on a user, from where, and what it changed
+type AuditEvent struct {
+	ID      uuid.UUID
+	Action  AuditAction
+	Outcome AuditOutcome
+	// ActorID is the authenticated caller; nil for anonymous requests such as sign-up and login
+	ActorID *uuid.UUID
+	// UserID is the user acted on; nil when it is unknown, such as a login with an unregistered email
+	UserID    *uuid.UUID
+	RequestID string
+	ClientIP  string
+	// Changes is empty for failed operations and for operations that do not change fields
+	Changes []AuditChange
+	// Error tells why a failed operation failed
+	Error      string
+	OccurredAt time.Time
+}
+
+// NewAuditEvent creates an audit event for the outcome of an operation on the user; err is nil on success
+func NewAuditEvent(action AuditAction, userID uuid.UUID, err error) *AuditEvent {
+	event := &AuditEvent{
+		ID:         uuid.New(),
+		Action:     action,
+		Outcome:    AuditOutcomeSuccess,
+		Changes:    []AuditChange{},
+		OccurredAt: time.Now(),
+	}
+	if userID != uuid.Nil {
+		event.UserID = &userID
+	}
+	if err != nil {
+		event.Outcome = AuditOutcomeFailure
+		event.Error = err.Error()
+	}
+	return event
+}
+
+// DiffUsers returns the fields that differ between two states of a user; before is nil for a created user.
+// Password hashes are never included, only whether the password changed.
+func DiffUsers(before, after *User) []AuditChange {
+	changes := []AuditChange{}
+	if after == nil {
+		return changes
+	}
+	if before == nil {
+		before = &User{}
+	}
+
+	if before.Email != after.Email {
+		changes = append(changes, AuditChange{Field: "email", Before: auditValue(string(before.Email)), After: string(after.Email)})
+	}
+	if before.Name != after.Name {
+		changes = append(changes, AuditChange{Field: "name", Before: auditValue(string(before.Name)), After: string(after.Name)})
+	}
+	if before.Password != after.Password {
+		changes = append(changes, AuditChange{Field: "password", Before: redactAuditValue(string(before.Password)), After: AuditRedacted})
+	}
+	if !slices.Equal(before.Roles, after.Roles) {
+		changes = append(changes, AuditChange{Field: "roles", Before: before.Roles, After: after.Roles})
+	}
+	return changes
+}
+
+// auditValue records an empty field as no value
+func auditValue(value string) any {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// redactAuditValue hides a secret value but keeps whether it was set
+func redactAuditValue(value string) any {
+	if value == "" {
+		return nil
+	}
+	return AuditRedacted
+}
@@ -0,0 +1,87 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffUsers(t *testing.T) {
+	user := &domain.User{Email: "alice@example.com", Name: "Alice", Password: "hash-1", Roles: []domain.Role{}}
+
+	testCases := []struct {
+		name   string
+		before *domain.User
+		after  *domain.User
+		want   []domain.AuditChange
+	}{
+		{
+			name:   "正常系：作成時は全フィールドを記録し、パスワードは伏せる",
+			before: nil,
+			after:  user,
+			want: []domain.AuditChange{
+				{Field: "email", Before: nil, After: "alice@example.com"},
+				{Field: "name", Before: nil, After: "Alice"},
+				{Field: "password", Before: nil, After: domain.AuditRedacted},
+			},
+		},
+		{
+			name:   "正常系：変わったフィールドのみ",
+			before: user,
+			after:  &domain.User{Email: "alice@example.com", Name: "Alice Smith", Password: "hash-1"},
+			want: []domain.AuditChange{
+				{Field: "name", Before: "Alice", After: "Alice Smith"},
+			},
+		},
+		{
+			name:   "正常系：パスワードの変更はハッシュを含めない",
+			before: user,
+			after:  &domain.User{Email: "alice@example.com", Name: "Alice", Password: "hash-2"},
+			want: []domain.AuditChange{
+				{Field: "password", Before: domain.AuditRedacted, After: domain.AuditRedacted},
+			},
+		},
+		{
+			name:   "正常系：ロールの変更",
+			before: user,
+			after:  &domain.User{Email: "alice@example.com", Name: "Alice", Password: "hash-1", Roles: []domain.Role{domain.RoleAdmin}},
+			want: []domain.AuditChange{
+				{Field: "roles", Before: []domain.Role{}, After: []domain.Role{domain.RoleAdmin}},
+			},
+		},
+		{
+			name:   "正常系：変更後が不明なら何も記録しない",
+			before: user,
+			after:  nil,
+			want:   []domain.AuditChange{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := domain.DiffUsers(tc.before, tc.after)
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestNewAuditEvent(t *testing.T) {
+	userID := uuid.New()
+
+	success := domain.NewAuditEvent(domain.AuditActionUserUpdate, userID, nil)
+	assert.Equal(t, domain.AuditOutcomeSuccess, success.Outcome)
+	require.NotNil(t, success.UserID)
+	assert.Equal(t, userID, *success.UserID)
+	assert.Empty(t, success.Error)
+
+	// 対象のユーザーが不明な失敗
+	failure := domain.NewAuditEvent(domain.AuditActionUserAuthenticate, uuid.Nil, errors.New("invalid credentials"))
+	assert.Equal(t, domain.AuditOutcomeFailure, failure.Outcome)
+	assert.Nil(t, failure.UserID)
+	assert.Equal(t, "invalid credentials", failure.Error)
+}
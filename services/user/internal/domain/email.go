@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// NormalizeEmail returns the canonical form of an email address: surrounding spaces are removed and
+// the domain is lowercased and converted to its ASCII (punycode) form. The local part keeps its case;
+// addresses that differ only in case are still treated as the same identity by the repository.
+func NormalizeEmail(email Email) Email {
+	trimmed := strings.TrimSpace(string(email))
+	at := strings.LastIndex(trimmed, "@")
+	if at < 0 {
+		return Email(trimmed)
+	}
+
+	local, host := trimmed[:at], trimmed[at+1:]
+	// 変換できないドメインは小文字化だけ行い、ValidateEmail で不正な形式として扱う
+	if ascii, err := idna.Lookup.ToASCII(host); err == nil {
+		host = ascii
+	}
+	return Email(local + "@" + strings.ToLower(host))
+}
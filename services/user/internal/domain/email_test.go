@@ -0,0 +1,37 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	testCases := []struct {
+		name  string
+		email domain.Email
+		want  domain.Email
+	}{
+		{name: "正常系：正規化済み", email: "alice@example.com", want: "alice@example.com"},
+		{name: "正常系：前後の空白を除く", email: "  alice@example.com\t", want: "alice@example.com"},
+		{name: "正常系：ドメイン部を小文字にする", email: "Alice@Example.COM", want: "Alice@example.com"},
+		{name: "正常系：国際化ドメインは punycode に変換する", email: "alice@Bücher.example", want: "alice@xn--bcher-kva.example"},
+		{name: "正常系：最後の @ 以降をドメインとして扱う", email: `"a@b"@Example.com`, want: `"a@b"@example.com`},
+		{name: "異常系：@ がなければ空白を除くだけ", email: " Alice ", want: "Alice"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := domain.NormalizeEmail(tc.email)
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestNormalizeEmail_Validate(t *testing.T) {
+	// 正規化後のアドレスはそのまま ValidateEmail を通る
+	assert.NoError(t, domain.ValidateEmail(domain.NormalizeEmail(" alice@Bücher.example ")))
+	assert.ErrorIs(t, domain.ValidateEmail(domain.NormalizeEmail("alice@exa mple.com")), domain.ErrInvalidEmail)
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailVerificationToken is a single-use token that proves ownership of Email; only the hash of the token value is stored
+type EmailVerificationToken struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	// Email is the address the token was sent to; the token is void once the user changes it
+	Email     Email
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// NewEmailVerificationToken creates a new email verification token for the current email of the user
+func NewEmailVerificationToken(user *User, tokenHash string, expiresAt time.Time) *EmailVerificationToken {
+	return &EmailVerificationToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		Email:     user.Email,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}
+}
+
+// IsUsed reports whether the token has already been used (or invalidated)
+func (t *EmailVerificationToken) IsUsed() bool {
+	return t.UsedAt != nil
+}
+
+// IsActive reports whether the token can still be used at the given time
+func (t *EmailVerificationToken) IsActive(now time.Time) bool {
+	return !t.IsUsed() && now.Before(t.ExpiresAt)
+}
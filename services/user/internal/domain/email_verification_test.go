@@ -0,0 +1,36 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailVerificationToken_IsActive(t *testing.T) {
+	now := time.Now()
+	usedAt := now.Add(-time.Minute)
+	user := domain.NewUser(domain.Email("user@example.com"), domain.Password("password123"), domain.Name("User"))
+
+	testCases := []struct {
+		name      string
+		expiresAt time.Time
+		usedAt    *time.Time
+		want      bool
+	}{
+		{name: "正常系：有効期限内", expiresAt: now.Add(time.Hour), want: true},
+		{name: "異常系：有効期限切れ", expiresAt: now.Add(-time.Second), want: false},
+		{name: "異常系：使用済み", expiresAt: now.Add(time.Hour), usedAt: &usedAt, want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token := domain.NewEmailVerificationToken(user, "hash", tc.expiresAt)
+			token.UsedAt = tc.usedAt
+
+			assert.Equal(t, user.Email, token.Email)
+			assert.Equal(t, tc.want, token.IsActive(now))
+		})
+	}
+}
@@ -16,6 +16,21 @@ var (
 	ErrDuplicateID        = NewError("[E011]id already exists")
 	ErrDuplicateEmail     = NewError("[E012]email already exists")
 	ErrInvalidInput       = NewError("[E013]invalid input")
+	ErrInvalidCredentials = NewError("[E014]invalid credentials")
+	ErrInvalidToken       = NewError("[E015]invalid token")
+	ErrInvalidRole        = NewError("[E016]invalid role")
+	ErrAccountLocked      = NewError("[E017]account is temporarily locked")
+	ErrTooManyAttempts    = NewError("[E018]too many login attempts")
+	ErrInvalidCursor      = NewError("[E019]invalid cursor")
+	ErrInvalidSort        = NewError("[E020]invalid sort")
+	ErrInvalidFilter      = NewError("[E021]invalid filter")
+	ErrInvalidSearchQuery = NewError("[E022]invalid search query")
+	ErrEmailNotVerified   = NewError("[E023]email address is not verified")
+	ErrTooManyEmails      = NewError("[E024]too many verification emails")
+	// ErrConcurrentModification is returned when the user was updated after the caller read it
+	ErrConcurrentModification = NewError("[E025]user was modified concurrently")
+	// ErrBatchTooLarge is returned when a bulk import has more rows than allowed
+	ErrBatchTooLarge = NewError("[E026]too many rows in batch")
 )
 
 func NewError(message string) error {
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	EventTypeUserCreated EventType = "user.created"
+	EventTypeUserUpdated EventType = "user.updated"
+	EventTypeUserDeleted EventType = "user.deleted"
+	// EventTypeUserRestored is emitted when a soft-deleted user is restored
+	EventTypeUserRestored EventType = "user.restored"
+)
+
+const (
+	// EventProducer is the producer name attached to events emitted by this service
+	EventProducer = "user-service"
+	// UserEventVersion is the schema version of UserEventPayload
+	UserEventVersion = 1
+)
+
+// Event is a domain event written to the outbox
+type Event struct {
+	ID         uuid.UUID       `json:"event_id"`
+	Type       EventType       `json:"event_type"`
+	Version    int             `json:"version"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Producer   string          `json:"producer"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+type OutboxStatus string
+
+const (
+	OutboxStatusPending   OutboxStatus = "pending"
+	OutboxStatusPublished OutboxStatus = "published"
+	OutboxStatusFailed    OutboxStatus = "failed"
+)
+
+// OutboxEvent is an event stored in the outbox together with its delivery state
+type OutboxEvent struct {
+	SeqID       int64
+	Event       Event
+	Status      OutboxStatus
+	Attempts    int
+	ErrorReason string
+}
+
+// UserEventPayload is the payload of user.* events
+type UserEventPayload struct {
+	UserID          uuid.UUID  `json:"user_id"`
+	Email           Email      `json:"email"`
+	Name            Name       `json:"name"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+}
+
+// NewUserEvent creates a new user event for the given user
+func NewUserEvent(eventType EventType, user *User) (*Event, error) {
+	payload, err := json.Marshal(UserEventPayload{
+		UserID:          user.ID,
+		Email:           user.Email,
+		Name:            user.Name,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s payload: %w", eventType, err)
+	}
+
+	return &Event{
+		ID:         uuid.New(),
+		Type:       eventType,
+		Version:    UserEventVersion,
+		OccurredAt: time.Now(),
+		Producer:   EventProducer,
+		Payload:    payload,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUserEvent(t *testing.T) {
+	user := domain.NewUser(domain.Email("event@example.com"), domain.Password("password123"), domain.Name("Event User"))
+
+	testCases := []struct {
+		name      string
+		eventType domain.EventType
+	}{
+		{name: "正常系：user.created", eventType: domain.EventTypeUserCreated},
+		{name: "正常系：user.updated", eventType: domain.EventTypeUserUpdated},
+		{name: "正常系：user.deleted", eventType: domain.EventTypeUserDeleted},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// 実行
+			event, err := domain.NewUserEvent(tc.eventType, user)
+
+			// 検証
+			require.NoError(t, err)
+			assert.NotEqual(t, uuid.Nil, event.ID)
+			assert.Equal(t, tc.eventType, event.Type)
+			assert.Equal(t, domain.UserEventVersion, event.Version)
+			assert.Equal(t, domain.EventProducer, event.Producer)
+			assert.NotZero(t, event.OccurredAt)
+
+			var payload domain.UserEventPayload
+			require.NoError(t, json.Unmarshal(event.Payload, &payload))
+			assert.Equal(t, user.ID, payload.UserID)
+			assert.Equal(t, user.Email, payload.Email)
+			assert.Equal(t, user.Name, payload.Name)
+		})
+	}
+
+	t.Run("正常系：パスワードはペイロードに含まれない", func(t *testing.T) {
+		event, err := domain.NewUserEvent(domain.EventTypeUserCreated, user)
+		require.NoError(t, err)
+		assert.NotContains(t, string(event.Payload), "password")
+	})
+
+	t.Run("正常系：メール確認日時はペイロードに含まれる", func(t *testing.T) {
+		unverified, err := domain.NewUserEvent(domain.EventTypeUserCreated, user)
+		require.NoError(t, err)
+		assert.NotContains(t, string(unverified.Payload), "email_verified_at")
+
+		verifiedAt := time.Now().UTC().Truncate(time.Second)
+		verified := *user
+		verified.EmailVerifiedAt = &verifiedAt
+		event, err := domain.NewUserEvent(domain.EventTypeUserUpdated, &verified)
+		require.NoError(t, err)
+
+		var payload domain.UserEventPayload
+		require.NoError(t, json.Unmarshal(event.Payload, &payload))
+		require.NotNil(t, payload.EmailVerifiedAt)
+		assert.True(t, verifiedAt.Equal(*payload.EmailVerifiedAt))
+	})
+}
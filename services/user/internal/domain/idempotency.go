@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// IdempotencyKey records the first request sent with an Idempotency-Key header and, once it has finished, its response
+type IdempotencyKey struct {
+	// Scope separates the keys of different callers (the authenticated user ID or "anonymous")
+	Scope string
+	Key   string
+	// RequestHash identifies the request body the key was first used with
+	RequestHash string
+	// StatusCode is 0 while the first request is still in progress
+	StatusCode      int
+	ResponseHeaders map[string]string
+	ResponseBody    []byte
+	CreatedAt       time.Time
+	ExpiresAt       time.Time
+}
+
+// NewIdempotencyKey creates a new in-progress idempotency key
+func NewIdempotencyKey(scope, key, requestHash string, expiresAt time.Time) *IdempotencyKey {
+	return &IdempotencyKey{
+		Scope:       scope,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   time.Now(),
+		ExpiresAt:   expiresAt,
+	}
+}
+
+// IsCompleted reports whether the response of the first request has been stored
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.StatusCode != 0
+}
+
+// Matches reports whether a retried request is the same as the first one
+func (k *IdempotencyKey) Matches(requestHash string) bool {
+	return k.RequestHash == requestHash
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// LoginThrottleScope is what failed login attempts are counted against
+type LoginThrottleScope string
+
+const (
+	LoginThrottleScopeUser LoginThrottleScope = "user"
+	LoginThrottleScopeIP   LoginThrottleScope = "ip"
+)
+
+// LoginThrottle tracks recent failed login attempts of a user or a client IP
+type LoginThrottle struct {
+	Scope         LoginThrottleScope
+	Subject       string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+}
+
+// IsLocked reports whether logins are blocked at the given time
+func (t *LoginThrottle) IsLocked(now time.Time) bool {
+	return t.LockedUntil != nil && now.Before(*t.LockedUntil)
+}
+
+// LockedError returns the error reported while the scope is locked
+func (s LoginThrottleScope) LockedError() error {
+	if s == LoginThrottleScopeIP {
+		return ErrTooManyAttempts
+	}
+	return ErrAccountLocked
+}
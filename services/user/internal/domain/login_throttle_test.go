@@ -0,0 +1,38 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginThrottle_IsLocked(t *testing.T) {
+	now := time.Now()
+	future := now.Add(time.Minute)
+	past := now.Add(-time.Minute)
+
+	testCases := []struct {
+		name        string
+		lockedUntil *time.Time
+		want        bool
+	}{
+		{name: "正常系：ロック中", lockedUntil: &future, want: true},
+		{name: "正常系：クールダウン経過", lockedUntil: &past, want: false},
+		{name: "正常系：ロックなし", lockedUntil: nil, want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			throttle := &domain.LoginThrottle{Scope: domain.LoginThrottleScopeUser, Subject: "subject", LockedUntil: tc.lockedUntil}
+
+			assert.Equal(t, tc.want, throttle.IsLocked(now))
+		})
+	}
+}
+
+func TestLoginThrottleScope_LockedError(t *testing.T) {
+	assert.ErrorIs(t, domain.LoginThrottleScopeUser.LockedError(), domain.ErrAccountLocked)
+	assert.ErrorIs(t, domain.LoginThrottleScopeIP.LockedError(), domain.ErrTooManyAttempts)
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CursorDirection is which side of the cursor position a page is read from
+type CursorDirection string
+
+const (
+	// CursorNext reads users older than the cursor position
+	CursorNext CursorDirection = "next"
+	// CursorPrev reads users newer than the cursor position
+	CursorPrev CursorDirection = "prev"
+)
+
+// UserCursor is a keyset position in the (created_at DESC, id DESC) ordering of users
+type UserCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+	Direction CursorDirection
+}
+
+// NextUserCursor returns the cursor of the page following the user
+func NextUserCursor(user *User) UserCursor {
+	return UserCursor{CreatedAt: user.CreatedAt, ID: user.ID, Direction: CursorNext}
+}
+
+// PrevUserCursor returns the cursor of the page preceding the user
+func PrevUserCursor(user *User) UserCursor {
+	return UserCursor{CreatedAt: user.CreatedAt, ID: user.ID, Direction: CursorPrev}
+}
+
+// Validate validates the cursor
+func (c UserCursor) Validate() error {
+	if c.ID == uuid.Nil || c.CreatedAt.IsZero() {
+		return ErrInvalidCursor
+	}
+	if c.Direction != CursorNext && c.Direction != CursorPrev {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+// UserFilter narrows the listed users; zero values do not filter
+type UserFilter struct {
+	EmailPrefix string
+	NamePrefix  string
+	// CreatedFrom is inclusive and CreatedTo is exclusive
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+}
+
+// IsEmpty reports whether the filter matches every user
+func (f UserFilter) IsEmpty() bool {
+	return f.EmailPrefix == "" && f.NamePrefix == "" && f.CreatedFrom == nil && f.CreatedTo == nil
+}
+
+// Validate validates the filter
+func (f UserFilter) Validate() error {
+	if f.CreatedFrom != nil && f.CreatedTo != nil && !f.CreatedFrom.Before(*f.CreatedTo) {
+		return ErrInvalidFilter
+	}
+	return nil
+}
+
+// UserSortField is a column users can be listed by
+type UserSortField string
+
+const (
+	UserSortCreatedAt UserSortField = "created_at"
+	UserSortUpdatedAt UserSortField = "updated_at"
+	UserSortName      UserSortField = "name"
+	UserSortEmail     UserSortField = "email"
+)
+
+// SortOrder is the direction of a sort
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// UserSort is the ordering of listed users; ties are broken by id in the same order
+type UserSort struct {
+	Field UserSortField
+	Order SortOrder
+}
+
+// DefaultUserSort returns the newest-first ordering that keyset cursors are based on
+func DefaultUserSort() UserSort {
+	return UserSort{Field: UserSortCreatedAt, Order: SortDesc}
+}
+
+// IsDefault reports whether the sort is the ordering of UserCursor
+func (s UserSort) IsDefault() bool {
+	return s == DefaultUserSort()
+}
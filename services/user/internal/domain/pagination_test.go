@@ -0,0 +1,52 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserFilter_Validate(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	testCases := []struct {
+		name    string
+		filter  domain.UserFilter
+		wantErr error
+	}{
+		{name: "正常系：フィルタなし", filter: domain.UserFilter{}},
+		{name: "正常系：下限のみ", filter: domain.UserFilter{CreatedFrom: &from}},
+		{name: "正常系：範囲指定", filter: domain.UserFilter{CreatedFrom: &from, CreatedTo: &to}},
+		{name: "異常系：下限と上限が同じ", filter: domain.UserFilter{CreatedFrom: &from, CreatedTo: &from}, wantErr: domain.ErrInvalidFilter},
+		{name: "異常系：範囲が逆転", filter: domain.UserFilter{CreatedFrom: &to, CreatedTo: &from}, wantErr: domain.ErrInvalidFilter},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.filter.Validate()
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUserFilter_IsEmpty(t *testing.T) {
+	from := time.Now()
+
+	assert.True(t, domain.UserFilter{}.IsEmpty())
+	assert.False(t, domain.UserFilter{NamePrefix: "a"}.IsEmpty())
+	assert.False(t, domain.UserFilter{CreatedFrom: &from}.IsEmpty())
+}
+
+func TestUserSort_IsDefault(t *testing.T) {
+	assert.True(t, domain.DefaultUserSort().IsDefault())
+	assert.False(t, domain.UserSort{Field: domain.UserSortCreatedAt, Order: domain.SortAsc}.IsDefault())
+	assert.False(t, domain.UserSort{Field: domain.UserSortName, Order: domain.SortDesc}.IsDefault())
+}
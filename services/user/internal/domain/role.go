@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Role is a role granted to a user
+type Role string
+
+const (
+	RoleAdmin   Role = "admin"
+	RoleSupport Role = "support"
+)
+
+// Permission is an action a role allows beyond acting on one's own account
+type Permission string
+
+const (
+	PermissionReadAnyUser  Permission = "users:read"
+	PermissionWriteAnyUser Permission = "users:write"
+	PermissionListUsers    Permission = "users:list"
+	PermissionManageRoles  Permission = "roles:manage"
+	PermissionUnlockUsers  Permission = "users:unlock"
+	PermissionImportUsers  Permission = "users:import"
+	// PermissionExportUsers allows downloading every user at once, so it is kept apart from PermissionListUsers
+	PermissionExportUsers Permission = "users:export"
+	// PermissionReadAuditLog allows reading the audit log of any user, which includes the client IPs of other callers
+	PermissionReadAuditLog Permission = "users:audit:read"
+)
+
+// rolePermissions defines the permissions of each role (must match the roles table)
+var rolePermissions = map[Role][]Permission{
+	RoleAdmin: {
+		PermissionReadAnyUser,
+		PermissionWriteAnyUser,
+		PermissionListUsers,
+		PermissionManageRoles,
+		PermissionUnlockUsers,
+		PermissionImportUsers,
+		PermissionExportUsers,
+		PermissionReadAuditLog,
+	},
+	RoleSupport: {
+		PermissionReadAnyUser,
+		PermissionListUsers,
+		PermissionReadAuditLog,
+	},
+}
+
+// ValidateRole validates that the role is a known role
+func ValidateRole(role Role) error {
+	if _, ok := rolePermissions[role]; !ok {
+		return fmt.Errorf("unknown role %q: %w", role, ErrInvalidRole)
+	}
+	return nil
+}
+
+// Permissions returns the permissions of the role
+func (r Role) Permissions() []Permission {
+	return rolePermissions[r]
+}
+
+// HasPermission reports whether the role allows the permission
+func (r Role) HasPermission(permission Permission) bool {
+	return slices.Contains(rolePermissions[r], permission)
+}
+
+// HasPermission reports whether any of the roles allows the permission
+func HasPermission(roles []Role, permission Permission) bool {
+	for _, role := range roles {
+		if role.HasPermission(permission) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRole(t *testing.T) {
+	testCases := []struct {
+		name    string
+		role    domain.Role
+		wantErr error
+	}{
+		{name: "正常系：admin", role: domain.RoleAdmin},
+		{name: "正常系：support", role: domain.RoleSupport},
+		{name: "異常系：未定義のロール", role: "owner", wantErr: domain.ErrInvalidRole},
+		{name: "異常系：空", role: "", wantErr: domain.ErrInvalidRole},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := domain.ValidateRole(tc.role)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHasPermission(t *testing.T) {
+	testCases := []struct {
+		name       string
+		roles      []domain.Role
+		permission domain.Permission
+		want       bool
+	}{
+		{name: "正常系：adminはロール管理可", roles: []domain.Role{domain.RoleAdmin}, permission: domain.PermissionManageRoles, want: true},
+		{name: "正常系：supportは参照可", roles: []domain.Role{domain.RoleSupport}, permission: domain.PermissionReadAnyUser, want: true},
+		{name: "正常系：supportは監査ログを参照可", roles: []domain.Role{domain.RoleSupport}, permission: domain.PermissionReadAuditLog, want: true},
+		{name: "異常系：supportは一括エクスポート不可", roles: []domain.Role{domain.RoleSupport}, permission: domain.PermissionExportUsers, want: false},
+		{name: "異常系：supportは更新不可", roles: []domain.Role{domain.RoleSupport}, permission: domain.PermissionWriteAnyUser, want: false},
+		{name: "異常系：ロールなし", roles: nil, permission: domain.PermissionListUsers, want: false},
+		{name: "異常系：未定義のロール", roles: []domain.Role{"owner"}, permission: domain.PermissionReadAnyUser, want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, domain.HasPermission(tc.roles, tc.permission))
+		})
+	}
+}
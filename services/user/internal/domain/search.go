@@ -0,0 +1,7 @@
+package domain
+
+// UserSearchHit is a user found by a search with its similarity score between 0 and 1
+type UserSearchHit struct {
+	User  *User
+	Score float64
+}
@@ -0,0 +1,35 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshToken_IsActive(t *testing.T) {
+	now := time.Now()
+	revokedAt := now.Add(-time.Minute)
+
+	testCases := []struct {
+		name      string
+		expiresAt time.Time
+		revokedAt *time.Time
+		want      bool
+	}{
+		{name: "正常系：有効期限内", expiresAt: now.Add(time.Hour), want: true},
+		{name: "異常系：有効期限切れ", expiresAt: now.Add(-time.Second), want: false},
+		{name: "異常系：失効済み", expiresAt: now.Add(time.Hour), revokedAt: &revokedAt, want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token := domain.NewRefreshToken(uuid.New(), "hash", tc.expiresAt)
+			token.RevokedAt = tc.revokedAt
+
+			assert.Equal(t, tc.want, token.IsActive(now))
+		})
+	}
+}
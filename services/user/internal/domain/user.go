@@ -2,6 +2,8 @@ package domain
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,8 +14,15 @@ type User struct {
 	Email     Email     `json:"email"`
 	Password  Password  `json:"password"`
 	Name      Name      `json:"name"`
+	Roles     []Role    `json:"roles"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// DeletedAt is set while the user is soft-deleted
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// EmailVerifiedAt is set once the user has confirmed the current email address
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	// Version is incremented on every update and used for optimistic concurrency control
+	Version int64 `json:"version"`
 }
 
 type Email string
@@ -22,13 +31,23 @@ type Name string
 
 type Password string
 
-// NewUser creates a new user
+// SensitiveKind marks email addresses as personal data, which the logger redacts
+func (Email) SensitiveKind() string { return "email" }
+
+// SensitiveKind marks names as personal data, which the logger redacts
+func (Name) SensitiveKind() string { return "name" }
+
+// SensitiveKind marks passwords and their hashes as secrets, which the logger redacts
+func (Password) SensitiveKind() string { return "password" }
+
+// NewUser creates a new user; the email is stored in its normalized form
 func NewUser(email Email, password Password, name Name) *User {
 	return &User{
 		ID:        uuid.New(),
-		Email:     email,
+		Email:     NormalizeEmail(email),
 		Password:  password,
 		Name:      name,
+		Roles:     []Role{},
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -58,16 +77,84 @@ func (u *User) UpdateName(name Name) error {
 	return nil
 }
 
-// UpdateEmail updates the email of the user
+// UpdateEmail updates the email of the user to the normalized form of email.
+// A different address (ignoring case) has to be verified again.
 func (u *User) UpdateEmail(email Email) error {
+	email = NormalizeEmail(email)
 	if err := ValidateEmail(email); err != nil {
 		return fmt.Errorf("invalid email: %w", err)
 	}
+	if !strings.EqualFold(string(u.Email), string(email)) {
+		u.EmailVerifiedAt = nil
+	}
 	u.Email = email
 	u.UpdatedAt = time.Now()
 	return nil
 }
 
+// UserPatch holds the fields of a partial update; nil fields are left unchanged
+type UserPatch struct {
+	Email *Email
+	Name  *Name
+}
+
+// IsEmpty reports whether the patch changes nothing
+func (p UserPatch) IsEmpty() bool {
+	return p.Email == nil && p.Name == nil
+}
+
+// ApplyPatch applies the patch and returns only the fields whose stored value actually changed
+func (u *User) ApplyPatch(patch UserPatch) (UserPatch, error) {
+	// 途中でエラーになってもユーザーを変更しないよう、先にすべて検証する
+	if patch.Email != nil {
+		if err := ValidateEmail(NormalizeEmail(*patch.Email)); err != nil {
+			return UserPatch{}, fmt.Errorf("invalid email: %w", err)
+		}
+	}
+	if patch.Name != nil {
+		if err := ValidateName(*patch.Name); err != nil {
+			return UserPatch{}, fmt.Errorf("invalid name: %w", err)
+		}
+	}
+
+	var changed UserPatch
+	if patch.Email != nil && NormalizeEmail(*patch.Email) != u.Email {
+		if err := u.UpdateEmail(*patch.Email); err != nil {
+			return UserPatch{}, err
+		}
+		email := u.Email
+		changed.Email = &email
+	}
+	if patch.Name != nil && *patch.Name != u.Name {
+		if err := u.UpdateName(*patch.Name); err != nil {
+			return UserPatch{}, err
+		}
+		name := u.Name
+		changed.Name = &name
+	}
+	return changed, nil
+}
+
+// IsEmailVerified reports whether the current email address has been verified
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// IsDeleted reports whether the user has been soft-deleted
+func (u *User) IsDeleted() bool {
+	return u.DeletedAt != nil
+}
+
+// HasRole reports whether the user has been granted the role
+func (u *User) HasRole(role Role) bool {
+	return slices.Contains(u.Roles, role)
+}
+
+// HasPermission reports whether any of the user's roles allows the permission
+func (u *User) HasPermission(permission Permission) bool {
+	return HasPermission(u.Roles, permission)
+}
+
 // UpdatePassword updates the password of the user
 func (u *User) UpdatePassword(password Password) error {
 	if err := ValidatePassword(password); err != nil {
@@ -76,4 +163,4 @@ func (u *User) UpdatePassword(password Password) error {
 	u.Password = password
 	u.UpdatedAt = time.Now()
 	return nil
-}
\ No newline at end of file
+}
@@ -238,6 +238,13 @@ func TestUser_UpdateEmail(t *testing.T) {
 			wantEmail:    domain.Email("user@new-domain.com"),
 			expectError:  false,
 		},
+		{
+			name:         "正常系：正規化して保存",
+			initialEmail: domain.Email("user@example.com"),
+			newEmail:     domain.Email(" User@New-Domain.COM "),
+			wantEmail:    domain.Email("User@new-domain.com"),
+			expectError:  false,
+		},
 		{
 			name:         "異常系：短いメールアドレスへの更新",
 			initialEmail: domain.Email("very.long.email@example.com"),
@@ -277,6 +284,98 @@ func TestUser_UpdateEmail(t *testing.T) {
 	}
 }
 
+func TestUser_UpdateEmail_ResetsVerification(t *testing.T) {
+	testCases := []struct {
+		name         string
+		newEmail     domain.Email
+		wantVerified bool
+	}{
+		{name: "正常系：別のアドレスへの変更で未確認に戻る", newEmail: domain.Email("other@example.com"), wantVerified: false},
+		{name: "正常系：大文字小文字のみの変更は確認済みのまま", newEmail: domain.Email("User@Example.com"), wantVerified: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			user := domain.NewUser(domain.Email("user@example.com"), domain.Password("password123"), domain.Name("User"))
+			verifiedAt := time.Now()
+			user.EmailVerifiedAt = &verifiedAt
+
+			err := user.UpdateEmail(tc.newEmail)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantVerified, user.IsEmailVerified())
+		})
+	}
+}
+
+func TestUser_ApplyPatch(t *testing.T) {
+	email := func(s string) *domain.Email { e := domain.Email(s); return &e }
+	name := func(s string) *domain.Name { n := domain.Name(s); return &n }
+
+	testCases := []struct {
+		name         string
+		patch        domain.UserPatch
+		wantChanged  domain.UserPatch
+		wantEmail    domain.Email
+		wantName     domain.Name
+		wantVerified bool
+		expectError  bool
+	}{
+		{
+			name:         "正常系：名前のみ変更",
+			patch:        domain.UserPatch{Name: name("New Name")},
+			wantChanged:  domain.UserPatch{Name: name("New Name")},
+			wantEmail:    "user@example.com",
+			wantName:     "New Name",
+			wantVerified: true,
+		},
+		{
+			name:         "正常系：メールアドレスの変更は未確認に戻る",
+			patch:        domain.UserPatch{Email: email(" New@Example.COM ")},
+			wantChanged:  domain.UserPatch{Email: email("New@example.com")},
+			wantEmail:    "New@example.com",
+			wantName:     "User",
+			wantVerified: false,
+		},
+		{
+			name:         "正常系：値が変わらないフィールドは変更に含めない",
+			patch:        domain.UserPatch{Email: email("user@EXAMPLE.com"), Name: name("User")},
+			wantChanged:  domain.UserPatch{},
+			wantEmail:    "user@example.com",
+			wantName:     "User",
+			wantVerified: true,
+		},
+		{
+			name:         "異常系：不正な値があれば何も変更しない",
+			patch:        domain.UserPatch{Email: email("other@example.com"), Name: name("")},
+			wantEmail:    "user@example.com",
+			wantName:     "User",
+			wantVerified: true,
+			expectError:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			user := domain.NewUser(domain.Email("user@example.com"), domain.Password("password123"), domain.Name("User"))
+			verifiedAt := time.Now()
+			user.EmailVerifiedAt = &verifiedAt
+
+			changed, err := user.ApplyPatch(tc.patch)
+
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantChanged, changed)
+			}
+			assert.Equal(t, tc.wantEmail, user.Email)
+			assert.Equal(t, tc.wantName, user.Name)
+			assert.Equal(t, tc.wantVerified, user.IsEmailVerified())
+		})
+	}
+}
+
 func TestUser_ComplexScenarios(t *testing.T) {
 	testCases := []struct {
 		name     string
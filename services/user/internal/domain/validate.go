@@ -3,6 +3,8 @@ package domain
 import (
 	"fmt"
 	"regexp"
+	"strings"
+	"unicode/utf8"
 )
 
 var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
@@ -36,3 +38,18 @@ func ValidatePassword(password Password) error {
 	}
 	return nil
 }
+
+// maxSearchQueryLength bounds search queries so that trigram matching stays cheap
+const maxSearchQueryLength = 100
+
+// ValidateSearchQuery validates a user search query; surrounding spaces are ignored
+func ValidateSearchQuery(query string) error {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return fmt.Errorf("search query is empty: %w", ErrInvalidSearchQuery)
+	}
+	if n := utf8.RuneCountInString(query); n > maxSearchQueryLength {
+		return fmt.Errorf("search query is too long (len=%d): %w", n, ErrInvalidSearchQuery)
+	}
+	return nil
+}
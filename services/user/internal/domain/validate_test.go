@@ -0,0 +1,36 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSearchQuery(t *testing.T) {
+	testCases := []struct {
+		name    string
+		query   string
+		wantErr error
+	}{
+		{name: "正常系：1文字", query: "a"},
+		{name: "正常系：前後の空白は無視する", query: "  alice  "},
+		{name: "正常系：100文字（マルチバイト）", query: strings.Repeat("あ", 100)},
+		{name: "異常系：空文字", query: "", wantErr: domain.ErrInvalidSearchQuery},
+		{name: "異常系：空白のみ", query: "   ", wantErr: domain.ErrInvalidSearchQuery},
+		{name: "異常系：101文字", query: strings.Repeat("a", 101), wantErr: domain.ErrInvalidSearchQuery},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := domain.ValidateSearchQuery(tc.query)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// accessLog logs every served request through the redacting logger. The raw URL is never written, as query strings
+// carry personal data (e.g. /users/lookup?email=...); the route and the other request fields come from logContext.
+// It must run after logContext and outside middleware.Recoverer so that panics are logged as 500.
+func accessLog(log logger.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			next.ServeHTTP(ww, r)
+
+			log.Info(r.Context(), "Request served",
+				zap.Int("status", responseStatus(ww)),
+				zap.Int("bytes", ww.BytesWritten()),
+				zap.Duration("elapsed", time.Since(start)))
+		})
+	}
+}
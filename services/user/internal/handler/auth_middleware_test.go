@@ -0,0 +1,383 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/auth"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/metrics"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthMiddleware_Router(t *testing.T) {
+	logger := logger.NewNop()
+	issuer := service.NewJWTTokenIssuer(service.DefaultTokenConfig([]byte("test-secret")))
+
+	selfID := uuid.New()
+	otherID := uuid.New()
+
+	issue := func(userID uuid.UUID, roles ...domain.Role) string {
+		token, _, err := issuer.IssueAccessToken(userID, roles)
+		require.NoError(t, err)
+		return "Bearer " + token
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		authorization  string
+		mockSetup      func(*MockUserService)
+		expectedStatus int
+	}{
+		{
+			name:           "失敗: トークンなし",
+			method:         http.MethodGet,
+			path:           "/api/v1/users/" + selfID.String(),
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "失敗: 不正なトークン",
+			method:         http.MethodGet,
+			path:           "/api/v1/users/" + selfID.String(),
+			authorization:  "Bearer invalid",
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "成功: 本人の取得",
+			method:        http.MethodGet,
+			path:          "/api/v1/users/" + selfID.String(),
+			authorization: issue(selfID),
+			mockSetup: func(m *MockUserService) {
+				m.On("GetUserByID", mock.Anything, selfID).Return(&service.UserResponse{ID: selfID}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "失敗: 他ユーザーの削除",
+			method:         http.MethodDelete,
+			path:           "/api/v1/users/" + otherID.String(),
+			authorization:  issue(selfID),
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:          "成功: 管理者による他ユーザーの削除",
+			method:        http.MethodDelete,
+			path:          "/api/v1/users/" + otherID.String(),
+			authorization: issue(selfID, domain.RoleAdmin),
+			mockSetup: func(m *MockUserService) {
+				m.On("DeleteUser", mock.Anything, service.DeleteUserRequest{ID: otherID}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "失敗: 一般ユーザーの一覧取得",
+			method:         http.MethodGet,
+			path:           "/api/v1/users",
+			authorization:  issue(selfID),
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:          "成功: 管理者の一覧取得",
+			method:        http.MethodGet,
+			path:          "/api/v1/users",
+			authorization: issue(selfID, domain.RoleAdmin),
+			mockSetup: func(m *MockUserService) {
+				m.On("ListUsers", mock.Anything, mock.Anything).Return(&service.ListUsersResponse{Users: []*service.UserResponse{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "失敗: 一般ユーザーのメールアドレス検索は存在有無に関わらず拒否",
+			method:         http.MethodGet,
+			path:           "/api/v1/users/lookup?email=other%40example.com",
+			authorization:  issue(selfID),
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:          "成功: サポートによるメールアドレス検索",
+			method:        http.MethodGet,
+			path:          "/api/v1/users/lookup?email=Other%40Example.com",
+			authorization: issue(selfID, domain.RoleSupport),
+			mockSetup: func(m *MockUserService) {
+				m.On("GetUserByEmail", mock.Anything, domain.Email("Other@example.com")).Return(&service.UserResponse{ID: otherID}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "失敗: 一般ユーザーの検索",
+			method:         http.MethodGet,
+			path:           "/api/v1/users/search?q=alice",
+			authorization:  issue(selfID),
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:          "成功: サポートによる検索",
+			method:        http.MethodGet,
+			path:          "/api/v1/users/search?q=alice",
+			authorization: issue(selfID, domain.RoleSupport),
+			mockSetup: func(m *MockUserService) {
+				m.On("SearchUsers", mock.Anything, service.SearchUsersRequest{Query: "alice", Limit: 10}).Return(&service.SearchUsersResponse{Hits: []*service.UserSearchHitResponse{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:          "成功: サポートによる他ユーザーの取得",
+			method:        http.MethodGet,
+			path:          "/api/v1/users/" + otherID.String(),
+			authorization: issue(selfID, domain.RoleSupport),
+			mockSetup: func(m *MockUserService) {
+				m.On("GetUserByID", mock.Anything, otherID).Return(&service.UserResponse{ID: otherID}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "失敗: サポートによる他ユーザーの削除",
+			method:         http.MethodDelete,
+			path:           "/api/v1/users/" + otherID.String(),
+			authorization:  issue(selfID, domain.RoleSupport),
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "成功: トークンなしでメールアドレス確認",
+			method: http.MethodPost,
+			path:   "/api/v1/users/verify-email",
+			body:   `{"token":"verification-token"}`,
+			mockSetup: func(m *MockUserService) {
+				m.On("VerifyEmail", mock.Anything, service.VerifyEmailRequest{Token: "verification-token"}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:   "成功: トークンなしで確認メール再送",
+			method: http.MethodPost,
+			path:   "/api/v1/users/verify-email/resend",
+			body:   `{"email":"test@example.com"}`,
+			mockSetup: func(m *MockUserService) {
+				m.On("ResendEmailVerification", mock.Anything, service.ResendEmailVerificationRequest{Email: "test@example.com"}).Return(nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "失敗: 他ユーザーの部分更新",
+			method:         http.MethodPatch,
+			path:           "/api/v1/users/" + otherID.String(),
+			body:           `{"name":"Patched"}`,
+			authorization:  issue(selfID),
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "失敗: 本人によるロール付与",
+			method:         http.MethodPost,
+			path:           "/api/v1/users/" + selfID.String() + "/roles",
+			body:           `{"role":"admin"}`,
+			authorization:  issue(selfID),
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:          "成功: 管理者によるロール付与",
+			method:        http.MethodPost,
+			path:          "/api/v1/users/" + otherID.String() + "/roles",
+			body:          `{"role":"support"}`,
+			authorization: issue(selfID, domain.RoleAdmin),
+			mockSetup: func(m *MockUserService) {
+				m.On("GrantRole", mock.Anything, service.GrantRoleRequest{UserID: otherID, Role: domain.RoleSupport}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:          "失敗: 未知のロールの付与",
+			method:        http.MethodPost,
+			path:          "/api/v1/users/" + otherID.String() + "/roles",
+			body:          `{"role":"owner"}`,
+			authorization: issue(selfID, domain.RoleAdmin),
+			mockSetup: func(m *MockUserService) {
+				m.On("GrantRole", mock.Anything, service.GrantRoleRequest{UserID: otherID, Role: "owner"}).Return(domain.ErrInvalidRole)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:          "成功: 管理者によるロール剥奪",
+			method:        http.MethodDelete,
+			path:          "/api/v1/users/" + otherID.String() + "/roles/support",
+			authorization: issue(selfID, domain.RoleAdmin),
+			mockSetup: func(m *MockUserService) {
+				m.On("RevokeRole", mock.Anything, service.RevokeRoleRequest{UserID: otherID, Role: domain.RoleSupport}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:          "成功: 管理者による削除済みユーザーの復元",
+			method:        http.MethodPost,
+			path:          "/api/v1/users/" + otherID.String() + "/restore",
+			authorization: issue(selfID, domain.RoleAdmin),
+			mockSetup: func(m *MockUserService) {
+				m.On("RestoreUser", mock.Anything, service.RestoreUserRequest{ID: otherID}).
+					Return(&service.UserResponse{ID: otherID, Roles: []domain.Role{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "失敗: 本人による復元",
+			method:         http.MethodPost,
+			path:           "/api/v1/users/" + selfID.String() + "/restore",
+			authorization:  issue(selfID),
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:          "失敗: 削除されていないユーザーの復元",
+			method:        http.MethodPost,
+			path:          "/api/v1/users/" + otherID.String() + "/restore",
+			authorization: issue(selfID, domain.RoleAdmin),
+			mockSetup: func(m *MockUserService) {
+				m.On("RestoreUser", mock.Anything, service.RestoreUserRequest{ID: otherID}).Return(nil, domain.ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:          "成功: 管理者によるロック解除",
+			method:        http.MethodPost,
+			path:          "/api/v1/users/" + otherID.String() + "/unlock",
+			authorization: issue(selfID, domain.RoleAdmin),
+			mockSetup: func(m *MockUserService) {
+				m.On("UnlockUser", mock.Anything, service.UnlockUserRequest{ID: otherID}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "失敗: サポートによるロック解除",
+			method:         http.MethodPost,
+			path:           "/api/v1/users/" + otherID.String() + "/unlock",
+			authorization:  issue(selfID, domain.RoleSupport),
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "失敗: 本人によるロック解除",
+			method:         http.MethodPost,
+			path:           "/api/v1/users/" + selfID.String() + "/unlock",
+			authorization:  issue(selfID),
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:          "成功: 管理者による一括エクスポート",
+			method:        http.MethodGet,
+			path:          "/api/v1/users:export",
+			authorization: issue(selfID, domain.RoleAdmin),
+			mockSetup: func(m *MockUserService) {
+				m.On("ExportUsers", mock.Anything, service.ExportUsersRequest{}).Return([]*service.UserResponse{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "失敗: サポートによる一括エクスポート",
+			method:         http.MethodGet,
+			path:           "/api/v1/users:export",
+			authorization:  issue(selfID, domain.RoleSupport),
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:          "成功: サポートによる監査ログの参照",
+			method:        http.MethodGet,
+			path:          "/api/v1/users/" + otherID.String() + "/audit",
+			authorization: issue(selfID, domain.RoleSupport),
+			mockSetup: func(m *MockUserService) {
+				// 監査ログに記録するリクエスト ID と接続元がサービス層に渡る
+				hasRequestInfo := mock.MatchedBy(func(ctx context.Context) bool {
+					info := auth.RequestInfoFromContext(ctx)
+					return info.RequestID != "" && info.ClientIP == "192.0.2.1"
+				})
+				m.On("ListUserAuditEvents", hasRequestInfo, service.ListUserAuditEventsRequest{UserID: otherID, Limit: 10}).
+					Return(&service.ListUserAuditEventsResponse{Events: []*service.AuditEventResponse{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "失敗: 本人による監査ログの参照",
+			method:         http.MethodGet,
+			path:           "/api/v1/users/" + selfID.String() + "/audit",
+			authorization:  issue(selfID),
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "失敗: 本人による一括作成",
+			method:         http.MethodPost,
+			path:           "/api/v1/users:batchCreate",
+			body:           `[]`,
+			authorization:  issue(selfID),
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(MockUserService)
+			tt.mockSetup(mockSvc)
+			// Idempotency-Key を付けないリクエストは冪等キーのリポジトリを呼ばない
+			idemMW := NewIdempotencyMiddleware(new(repository.MockIdempotencyKeyRepository), DefaultIdempotencyConfig([]byte("test-secret")), logger)
+			router := NewRouter(NewUserHandler(mockSvc, logger), NewAuthMiddleware(issuer, logger), idemMW, NewMetricsMiddleware(metrics.New(nil)), DefaultRouterConfig())
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	testCases := []struct {
+		name   string
+		header string
+		want   string
+		wantOK bool
+	}{
+		{name: "正常系：Bearer", header: "Bearer abc", want: "abc", wantOK: true},
+		{name: "正常系：小文字のbearer", header: "bearer abc", want: "abc", wantOK: true},
+		{name: "異常系：空", header: "", wantOK: false},
+		{name: "異常系：Basic", header: "Basic abc", wantOK: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := bearerToken(tc.header)
+
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"go.uber.org/zap"
+)
+
+// handleServiceError maps domain errors to Connect codes, mirroring the HTTP status mapping of UserHandler
+func (h *UserConnectHandler) handleServiceError(ctx context.Context, err error) error {
+	h.logger.Error(ctx, "Service error", zap.Error(err))
+
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		return connect.NewError(connect.CodeNotFound, fmt.Errorf("user not found"))
+	case errors.Is(err, domain.ErrUserAlreadyExists):
+		return connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("user already exists"))
+	case errors.Is(err, domain.ErrInvalidEmail):
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid email address"))
+	case errors.Is(err, domain.ErrInvalidName):
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid name"))
+	case errors.Is(err, domain.ErrInvalidPassword):
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid password"))
+	case errors.Is(err, domain.ErrInvalidRole):
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid role"))
+	case errors.Is(err, domain.ErrInvalidCursor):
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid cursor"))
+	case errors.Is(err, domain.ErrInvalidSort):
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid sort"))
+	case errors.Is(err, domain.ErrInvalidFilter):
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid filter"))
+	case errors.Is(err, domain.ErrInvalidSearchQuery):
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid search query"))
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		return connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid credentials"))
+	case errors.Is(err, domain.ErrAccountLocked):
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("account is temporarily locked"))
+	case errors.Is(err, domain.ErrTooManyAttempts):
+		return connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("too many login attempts"))
+	case errors.Is(err, domain.ErrEmailNotVerified):
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("email address is not verified"))
+	case errors.Is(err, domain.ErrInvalidToken):
+		return connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid or expired token"))
+	case errors.Is(err, domain.ErrConcurrentModification):
+		return connect.NewError(connect.CodeAborted, fmt.Errorf("user was modified concurrently"))
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
@@ -0,0 +1,468 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
+	userv1 "github.com/lot-koichi/sre-skill-up-project/services/user/gen/user/v1"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/gen/user/v1/userv1connect"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/auth"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/service"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// コンパイル時にインターフェースを満たしているか確認
+var _ userv1connect.UserServiceHandler = (*UserConnectHandler)(nil)
+
+// UserConnectHandler serves service.UserService over Connect / gRPC / gRPC-Web
+type UserConnectHandler struct {
+	svc    service.UserService
+	logger logger.Logger
+}
+
+func NewUserConnectHandler(svc service.UserService, logger logger.Logger) *UserConnectHandler {
+	return &UserConnectHandler{
+		svc:    svc,
+		logger: logger,
+	}
+}
+
+func (h *UserConnectHandler) CreateUser(ctx context.Context, req *connect.Request[userv1.CreateUserRequest]) (*connect.Response[userv1.CreateUserResponse], error) {
+	user, err := h.svc.CreateUser(ctx, service.CreateUserRequest{
+		Email:    domain.Email(req.Msg.GetEmail()),
+		Name:     domain.Name(req.Msg.GetName()),
+		Password: domain.Password(req.Msg.GetPassword()),
+	})
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return connect.NewResponse(&userv1.CreateUserResponse{User: toProtoUser(user)}), nil
+}
+
+func (h *UserConnectHandler) GetUserByID(ctx context.Context, req *connect.Request[userv1.GetUserByIDRequest]) (*connect.Response[userv1.GetUserByIDResponse], error) {
+	userID, err := parseUserID(req.Msg.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeUser(ctx, userID, domain.PermissionReadAnyUser); err != nil {
+		return nil, err
+	}
+
+	user, err := h.svc.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return connect.NewResponse(&userv1.GetUserByIDResponse{User: toProtoUser(user)}), nil
+}
+
+func (h *UserConnectHandler) GetUserByEmail(ctx context.Context, req *connect.Request[userv1.GetUserByEmailRequest]) (*connect.Response[userv1.GetUserByEmailResponse], error) {
+	// 他人のアカウントの有無を確認できないよう、検索前に参照権限を確認する
+	if err := authorizePermission(ctx, domain.PermissionReadAnyUser); err != nil {
+		return nil, err
+	}
+
+	email := domain.NormalizeEmail(domain.Email(req.Msg.GetEmail()))
+	if err := domain.ValidateEmail(email); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid email format"))
+	}
+
+	user, err := h.svc.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return connect.NewResponse(&userv1.GetUserByEmailResponse{User: toProtoUser(user)}), nil
+}
+
+func (h *UserConnectHandler) UpdateUser(ctx context.Context, req *connect.Request[userv1.UpdateUserRequest]) (*connect.Response[userv1.UpdateUserResponse], error) {
+	userID, err := parseUserID(req.Msg.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeUser(ctx, userID, domain.PermissionWriteAnyUser); err != nil {
+		return nil, err
+	}
+
+	err = h.svc.UpdateUser(ctx, service.UpdateUserRequest{
+		ID:    userID,
+		Email: domain.Email(req.Msg.GetEmail()),
+		Name:  domain.Name(req.Msg.GetName()),
+	})
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return connect.NewResponse(&userv1.UpdateUserResponse{}), nil
+}
+
+func (h *UserConnectHandler) DeleteUser(ctx context.Context, req *connect.Request[userv1.DeleteUserRequest]) (*connect.Response[userv1.DeleteUserResponse], error) {
+	userID, err := parseUserID(req.Msg.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeUser(ctx, userID, domain.PermissionWriteAnyUser); err != nil {
+		return nil, err
+	}
+
+	if err := h.svc.DeleteUser(ctx, service.DeleteUserRequest{ID: userID}); err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return connect.NewResponse(&userv1.DeleteUserResponse{}), nil
+}
+
+func (h *UserConnectHandler) RestoreUser(ctx context.Context, req *connect.Request[userv1.RestoreUserRequest]) (*connect.Response[userv1.RestoreUserResponse], error) {
+	if err := authorizePermission(ctx, domain.PermissionWriteAnyUser); err != nil {
+		return nil, err
+	}
+	userID, err := parseUserID(req.Msg.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := h.svc.RestoreUser(ctx, service.RestoreUserRequest{ID: userID})
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return connect.NewResponse(&userv1.RestoreUserResponse{User: toProtoUser(user)}), nil
+}
+
+func (h *UserConnectHandler) ListUsers(ctx context.Context, req *connect.Request[userv1.ListUsersRequest]) (*connect.Response[userv1.ListUsersResponse], error) {
+	if err := authorizePermission(ctx, domain.PermissionListUsers); err != nil {
+		return nil, err
+	}
+
+	// REST と同じデフォルト値・上限を適用
+	limit := req.Msg.GetLimit()
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	offset := req.Msg.GetOffset()
+	if offset < 0 {
+		offset = 0
+	}
+
+	listReq := service.ListUsersRequest{
+		Limit:       limit,
+		Offset:      offset,
+		Cursor:      req.Msg.GetCursor(),
+		SortBy:      req.Msg.GetSortBy(),
+		SortOrder:   req.Msg.GetSortOrder(),
+		EmailPrefix: req.Msg.GetEmailPrefix(),
+		NamePrefix:  req.Msg.GetNamePrefix(),
+	}
+	if req.Msg.CreatedFrom != nil {
+		createdFrom := req.Msg.GetCreatedFrom().AsTime()
+		listReq.CreatedFrom = &createdFrom
+	}
+	if req.Msg.CreatedTo != nil {
+		createdTo := req.Msg.GetCreatedTo().AsTime()
+		listReq.CreatedTo = &createdTo
+	}
+
+	page, err := h.svc.ListUsers(ctx, listReq)
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	protoUsers := make([]*userv1.User, 0, len(page.Users))
+	for _, user := range page.Users {
+		protoUsers = append(protoUsers, toProtoUser(user))
+	}
+
+	return connect.NewResponse(&userv1.ListUsersResponse{
+		Users:               protoUsers,
+		Limit:               limit,
+		Offset:              offset,
+		NextCursor:          page.NextCursor,
+		PrevCursor:          page.PrevCursor,
+		TotalCount:          page.TotalCount,
+		TotalCountEstimated: page.TotalCountEstimated,
+	}), nil
+}
+
+func (h *UserConnectHandler) SearchUsers(ctx context.Context, req *connect.Request[userv1.SearchUsersRequest]) (*connect.Response[userv1.SearchUsersResponse], error) {
+	if err := authorizePermission(ctx, domain.PermissionListUsers); err != nil {
+		return nil, err
+	}
+
+	// REST と同じデフォルト値・上限を適用
+	limit := req.Msg.GetLimit()
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	offset := req.Msg.GetOffset()
+	if offset < 0 {
+		offset = 0
+	}
+
+	result, err := h.svc.SearchUsers(ctx, service.SearchUsersRequest{
+		Query:  req.Msg.GetQuery(),
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	hits := make([]*userv1.UserSearchHit, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		hits = append(hits, &userv1.UserSearchHit{User: toProtoUser(hit.User), Score: hit.Score})
+	}
+
+	return connect.NewResponse(&userv1.SearchUsersResponse{
+		Hits:       hits,
+		TotalCount: result.TotalCount,
+		Limit:      limit,
+		Offset:     offset,
+	}), nil
+}
+
+func (h *UserConnectHandler) AuthenticateUser(ctx context.Context, req *connect.Request[userv1.AuthenticateUserRequest]) (*connect.Response[userv1.AuthenticateUserResponse], error) {
+	if req.Msg.GetEmail() == "" || req.Msg.GetPassword() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("email and password are required"))
+	}
+
+	tokens, err := h.svc.AuthenticateUser(ctx, service.AuthenticateUserRequest{
+		Email:    domain.Email(req.Msg.GetEmail()),
+		Password: domain.Password(req.Msg.GetPassword()),
+		ClientIP: remoteHost(req.Peer().Addr),
+	})
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return connect.NewResponse(&userv1.AuthenticateUserResponse{Tokens: toProtoAuthTokens(tokens)}), nil
+}
+
+func (h *UserConnectHandler) RefreshToken(ctx context.Context, req *connect.Request[userv1.RefreshTokenRequest]) (*connect.Response[userv1.RefreshTokenResponse], error) {
+	if req.Msg.GetRefreshToken() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("refresh token is required"))
+	}
+
+	tokens, err := h.svc.RefreshToken(ctx, service.RefreshTokenRequest{RefreshToken: req.Msg.GetRefreshToken()})
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return connect.NewResponse(&userv1.RefreshTokenResponse{Tokens: toProtoAuthTokens(tokens)}), nil
+}
+
+func (h *UserConnectHandler) Logout(ctx context.Context, req *connect.Request[userv1.LogoutRequest]) (*connect.Response[userv1.LogoutResponse], error) {
+	if req.Msg.GetRefreshToken() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("refresh token is required"))
+	}
+
+	if err := h.svc.Logout(ctx, service.LogoutRequest{RefreshToken: req.Msg.GetRefreshToken()}); err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return connect.NewResponse(&userv1.LogoutResponse{}), nil
+}
+
+func (h *UserConnectHandler) ChangePassword(ctx context.Context, req *connect.Request[userv1.ChangePasswordRequest]) (*connect.Response[userv1.ChangePasswordResponse], error) {
+	userID, err := parseUserID(req.Msg.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeUser(ctx, userID, domain.PermissionWriteAnyUser); err != nil {
+		return nil, err
+	}
+	if req.Msg.GetCurrentPassword() == "" || req.Msg.GetNewPassword() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("current and new password are required"))
+	}
+
+	err = h.svc.ChangePassword(ctx, service.ChangePasswordRequest{
+		ID:              userID,
+		CurrentPassword: domain.Password(req.Msg.GetCurrentPassword()),
+		NewPassword:     domain.Password(req.Msg.GetNewPassword()),
+	})
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return connect.NewResponse(&userv1.ChangePasswordResponse{}), nil
+}
+
+func (h *UserConnectHandler) RequestPasswordReset(ctx context.Context, req *connect.Request[userv1.RequestPasswordResetRequest]) (*connect.Response[userv1.RequestPasswordResetResponse], error) {
+	if req.Msg.GetEmail() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("email is required"))
+	}
+
+	err := h.svc.RequestPasswordReset(ctx, service.RequestPasswordResetRequest{Email: domain.Email(req.Msg.GetEmail())})
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return connect.NewResponse(&userv1.RequestPasswordResetResponse{}), nil
+}
+
+func (h *UserConnectHandler) ConfirmPasswordReset(ctx context.Context, req *connect.Request[userv1.ConfirmPasswordResetRequest]) (*connect.Response[userv1.ConfirmPasswordResetResponse], error) {
+	if req.Msg.GetToken() == "" || req.Msg.GetNewPassword() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("token and new password are required"))
+	}
+
+	err := h.svc.ConfirmPasswordReset(ctx, service.ConfirmPasswordResetRequest{
+		Token:       req.Msg.GetToken(),
+		NewPassword: domain.Password(req.Msg.GetNewPassword()),
+	})
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return connect.NewResponse(&userv1.ConfirmPasswordResetResponse{}), nil
+}
+
+func (h *UserConnectHandler) VerifyEmail(ctx context.Context, req *connect.Request[userv1.VerifyEmailRequest]) (*connect.Response[userv1.VerifyEmailResponse], error) {
+	if req.Msg.GetToken() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("token is required"))
+	}
+
+	if err := h.svc.VerifyEmail(ctx, service.VerifyEmailRequest{Token: req.Msg.GetToken()}); err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return connect.NewResponse(&userv1.VerifyEmailResponse{}), nil
+}
+
+func (h *UserConnectHandler) ResendEmailVerification(ctx context.Context, req *connect.Request[userv1.ResendEmailVerificationRequest]) (*connect.Response[userv1.ResendEmailVerificationResponse], error) {
+	if req.Msg.GetEmail() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("email is required"))
+	}
+
+	err := h.svc.ResendEmailVerification(ctx, service.ResendEmailVerificationRequest{Email: domain.Email(req.Msg.GetEmail())})
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return connect.NewResponse(&userv1.ResendEmailVerificationResponse{}), nil
+}
+
+func (h *UserConnectHandler) GrantRole(ctx context.Context, req *connect.Request[userv1.GrantRoleRequest]) (*connect.Response[userv1.GrantRoleResponse], error) {
+	if err := authorizePermission(ctx, domain.PermissionManageRoles); err != nil {
+		return nil, err
+	}
+	userID, err := parseUserID(req.Msg.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.svc.GrantRole(ctx, service.GrantRoleRequest{
+		UserID: userID,
+		Role:   domain.Role(req.Msg.GetRole()),
+	})
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return connect.NewResponse(&userv1.GrantRoleResponse{}), nil
+}
+
+func (h *UserConnectHandler) RevokeRole(ctx context.Context, req *connect.Request[userv1.RevokeRoleRequest]) (*connect.Response[userv1.RevokeRoleResponse], error) {
+	if err := authorizePermission(ctx, domain.PermissionManageRoles); err != nil {
+		return nil, err
+	}
+	userID, err := parseUserID(req.Msg.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.svc.RevokeRole(ctx, service.RevokeRoleRequest{
+		UserID: userID,
+		Role:   domain.Role(req.Msg.GetRole()),
+	})
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return connect.NewResponse(&userv1.RevokeRoleResponse{}), nil
+}
+
+func (h *UserConnectHandler) UnlockUser(ctx context.Context, req *connect.Request[userv1.UnlockUserRequest]) (*connect.Response[userv1.UnlockUserResponse], error) {
+	if err := authorizePermission(ctx, domain.PermissionUnlockUsers); err != nil {
+		return nil, err
+	}
+	userID, err := parseUserID(req.Msg.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.svc.UnlockUser(ctx, service.UnlockUserRequest{ID: userID}); err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return connect.NewResponse(&userv1.UnlockUserResponse{}), nil
+}
+
+// Helper methods
+
+// authorizeUser allows the caller to act on themselves, or on anyone with the permission
+func authorizeUser(ctx context.Context, userID uuid.UUID, permission domain.Permission) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("authentication required"))
+	}
+	if !principal.CanActOnUser(userID, permission) {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("forbidden"))
+	}
+	return nil
+}
+
+// authorizePermission allows only callers whose roles grant the permission
+func authorizePermission(ctx context.Context, permission domain.Permission) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("authentication required"))
+	}
+	if !principal.HasPermission(permission) {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("forbidden"))
+	}
+	return nil
+}
+
+func parseUserID(id string) (uuid.UUID, error) {
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid user ID format"))
+	}
+	return userID, nil
+}
+
+func toProtoAuthTokens(tokens *service.AuthTokens) *userv1.AuthTokens {
+	return &userv1.AuthTokens{
+		AccessToken:           tokens.AccessToken,
+		AccessTokenExpiresAt:  timestamppb.New(tokens.AccessTokenExpiresAt),
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: timestamppb.New(tokens.RefreshTokenExpiresAt),
+	}
+}
+
+func toProtoRoles(roles []domain.Role) []string {
+	protoRoles := make([]string, 0, len(roles))
+	for _, role := range roles {
+		protoRoles = append(protoRoles, string(role))
+	}
+	return protoRoles
+}
+
+func toProtoUser(user *service.UserResponse) *userv1.User {
+	pbUser := &userv1.User{
+		Id:        user.ID.String(),
+		Email:     string(user.Email),
+		Name:      string(user.Name),
+		Roles:     toProtoRoles(user.Roles),
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+	}
+	if user.EmailVerifiedAt != nil {
+		pbUser.EmailVerifiedAt = timestamppb.New(*user.EmailVerifiedAt)
+	}
+	return pbUser
+}
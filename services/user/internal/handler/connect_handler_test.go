@@ -0,0 +1,492 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
+	userv1 "github.com/lot-koichi/sre-skill-up-project/services/user/gen/user/v1"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/gen/user/v1/userv1connect"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/auth"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newConnectTestClient starts a Connect server and returns a client authenticated as principal (nil: anonymous)
+func newConnectTestClient(t *testing.T, svc service.UserService, principal *auth.Principal) userv1connect.UserServiceClient {
+	t.Helper()
+	issuer := service.NewJWTTokenIssuer(service.DefaultTokenConfig([]byte("test-secret")))
+
+	mux := http.NewServeMux()
+	mux.Handle(userv1connect.NewUserServiceHandler(NewUserConnectHandler(svc, logger.NewNop()),
+		connect.WithInterceptors(NewTracingInterceptor(), NewLogContextInterceptor(), NewAuthInterceptor(issuer, logger.NewNop())),
+	))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	var opts []connect.ClientOption
+	if principal != nil {
+		token, _, err := issuer.IssueAccessToken(principal.UserID, principal.Roles)
+		require.NoError(t, err)
+		opts = append(opts, connect.WithInterceptors(connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+			return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+				req.Header().Set("Authorization", "Bearer "+token)
+				return next(ctx, req)
+			}
+		})))
+	}
+	return userv1connect.NewUserServiceClient(server.Client(), server.URL, opts...)
+}
+
+func TestUserConnectHandler_CreateUser(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		request   *userv1.CreateUserRequest
+		mockSetup func(*MockUserService)
+		wantCode  connect.Code
+	}{
+		{
+			name:    "正常系：ユーザー作成成功",
+			request: &userv1.CreateUserRequest{Email: "test@example.com", Name: "Test User", Password: "password123"},
+			mockSetup: func(m *MockUserService) {
+				m.On("CreateUser", mock.Anything, service.CreateUserRequest{
+					Email:    "test@example.com",
+					Name:     "Test User",
+					Password: "password123",
+				}).Return(&service.UserResponse{
+					ID:        userID,
+					Email:     "test@example.com",
+					Name:      "Test User",
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+			},
+		},
+		{
+			name:    "異常系：ユーザーが既に存在",
+			request: &userv1.CreateUserRequest{Email: "test@example.com", Name: "Test User", Password: "password123"},
+			mockSetup: func(m *MockUserService) {
+				m.On("CreateUser", mock.Anything, mock.Anything).Return(nil, domain.ErrUserAlreadyExists)
+			},
+			wantCode: connect.CodeAlreadyExists,
+		},
+		{
+			name:    "異常系：不正なメールアドレス",
+			request: &userv1.CreateUserRequest{Email: "invalid", Name: "Test User", Password: "password123"},
+			mockSetup: func(m *MockUserService) {
+				m.On("CreateUser", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidEmail)
+			},
+			wantCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockUserService)
+			tt.mockSetup(mockService)
+			client := newConnectTestClient(t, mockService, nil)
+
+			resp, err := client.CreateUser(context.Background(), connect.NewRequest(tt.request))
+
+			if tt.wantCode != 0 {
+				assert.Equal(t, tt.wantCode, connect.CodeOf(err))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, userID.String(), resp.Msg.GetUser().GetId())
+				assert.Equal(t, "test@example.com", resp.Msg.GetUser().GetEmail())
+				assert.Equal(t, now, resp.Msg.GetUser().GetCreatedAt().AsTime())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserConnectHandler_GetUserByID(t *testing.T) {
+	userID := uuid.New()
+	self := &auth.Principal{UserID: userID}
+
+	tests := []struct {
+		name      string
+		id        string
+		principal *auth.Principal
+		mockSetup func(*MockUserService)
+		wantCode  connect.Code
+	}{
+		{
+			name:      "正常系：ユーザー取得成功",
+			id:        userID.String(),
+			principal: self,
+			mockSetup: func(m *MockUserService) {
+				m.On("GetUserByID", mock.Anything, userID).Return(&service.UserResponse{ID: userID, Email: "test@example.com"}, nil)
+			},
+		},
+		{
+			name:      "正常系：管理者は他ユーザーを取得可能",
+			id:        userID.String(),
+			principal: &auth.Principal{UserID: uuid.New(), Roles: []domain.Role{domain.RoleAdmin}},
+			mockSetup: func(m *MockUserService) {
+				m.On("GetUserByID", mock.Anything, userID).Return(&service.UserResponse{ID: userID, Email: "test@example.com"}, nil)
+			},
+		},
+		{
+			name:      "異常系：未認証",
+			id:        userID.String(),
+			mockSetup: func(m *MockUserService) {},
+			wantCode:  connect.CodeUnauthenticated,
+		},
+		{
+			name:      "異常系：他ユーザーは取得不可",
+			id:        userID.String(),
+			principal: &auth.Principal{UserID: uuid.New()},
+			mockSetup: func(m *MockUserService) {},
+			wantCode:  connect.CodePermissionDenied,
+		},
+		{
+			name:      "異常系：不正なID形式",
+			id:        "invalid-uuid",
+			principal: self,
+			mockSetup: func(m *MockUserService) {},
+			wantCode:  connect.CodeInvalidArgument,
+		},
+		{
+			name:      "異常系：ユーザーが存在しない",
+			id:        userID.String(),
+			principal: self,
+			mockSetup: func(m *MockUserService) {
+				m.On("GetUserByID", mock.Anything, userID).Return(nil, domain.ErrUserNotFound)
+			},
+			wantCode: connect.CodeNotFound,
+		},
+		{
+			name:      "異常系：内部エラー",
+			id:        userID.String(),
+			principal: self,
+			mockSetup: func(m *MockUserService) {
+				m.On("GetUserByID", mock.Anything, userID).Return(nil, errors.New("database error"))
+			},
+			wantCode: connect.CodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockUserService)
+			tt.mockSetup(mockService)
+			client := newConnectTestClient(t, mockService, tt.principal)
+
+			resp, err := client.GetUserByID(context.Background(), connect.NewRequest(&userv1.GetUserByIDRequest{Id: tt.id}))
+
+			if tt.wantCode != 0 {
+				assert.Equal(t, tt.wantCode, connect.CodeOf(err))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, userID.String(), resp.Msg.GetUser().GetId())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserConnectHandler_GetUserByEmail(t *testing.T) {
+	userID := uuid.New()
+	support := &auth.Principal{UserID: uuid.New(), Roles: []domain.Role{domain.RoleSupport}}
+
+	tests := []struct {
+		name      string
+		email     string
+		principal *auth.Principal
+		mockSetup func(*MockUserService)
+		wantCode  connect.Code
+	}{
+		{
+			name:      "正常系：参照権限があれば取得可能",
+			email:     " Alice@Example.com ",
+			principal: support,
+			mockSetup: func(m *MockUserService) {
+				m.On("GetUserByEmail", mock.Anything, domain.Email("Alice@example.com")).Return(&service.UserResponse{ID: userID}, nil)
+			},
+		},
+		{
+			name:      "異常系：権限がなければ存在有無に関わらず拒否",
+			email:     "alice@example.com",
+			principal: &auth.Principal{UserID: userID},
+			mockSetup: func(m *MockUserService) {},
+			wantCode:  connect.CodePermissionDenied,
+		},
+		{
+			name:      "異常系：不正なメールアドレス",
+			email:     "not-an-email",
+			principal: support,
+			mockSetup: func(m *MockUserService) {},
+			wantCode:  connect.CodeInvalidArgument,
+		},
+		{
+			name:      "異常系：ユーザーが存在しない",
+			email:     "nobody@example.com",
+			principal: support,
+			mockSetup: func(m *MockUserService) {
+				m.On("GetUserByEmail", mock.Anything, domain.Email("nobody@example.com")).Return(nil, domain.ErrUserNotFound)
+			},
+			wantCode: connect.CodeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockUserService)
+			tt.mockSetup(mockService)
+			client := newConnectTestClient(t, mockService, tt.principal)
+
+			resp, err := client.GetUserByEmail(context.Background(), connect.NewRequest(&userv1.GetUserByEmailRequest{Email: tt.email}))
+
+			if tt.wantCode != 0 {
+				assert.Equal(t, tt.wantCode, connect.CodeOf(err))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, userID.String(), resp.Msg.GetUser().GetId())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserConnectHandler_ListUsers(t *testing.T) {
+	admin := &auth.Principal{UserID: uuid.New(), Roles: []domain.Role{domain.RoleAdmin}}
+
+	t.Run("正常系：デフォルトのページングを適用", func(t *testing.T) {
+		mockService := new(MockUserService)
+		mockService.On("ListUsers", mock.Anything, service.ListUsersRequest{Limit: 10, Offset: 0}).
+			Return(&service.ListUsersResponse{Users: []*service.UserResponse{{ID: uuid.New()}, {ID: uuid.New()}}}, nil)
+		client := newConnectTestClient(t, mockService, admin)
+
+		resp, err := client.ListUsers(context.Background(), connect.NewRequest(&userv1.ListUsersRequest{}))
+
+		require.NoError(t, err)
+		assert.Len(t, resp.Msg.GetUsers(), 2)
+		assert.Equal(t, int32(10), resp.Msg.GetLimit())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("正常系：カーソルを受け渡す", func(t *testing.T) {
+		mockService := new(MockUserService)
+		mockService.On("ListUsers", mock.Anything, service.ListUsersRequest{Limit: 10, Cursor: "cursor"}).
+			Return(&service.ListUsersResponse{Users: []*service.UserResponse{{ID: uuid.New()}}, NextCursor: "next", PrevCursor: "prev"}, nil)
+		client := newConnectTestClient(t, mockService, admin)
+
+		resp, err := client.ListUsers(context.Background(), connect.NewRequest(&userv1.ListUsersRequest{Cursor: "cursor"}))
+
+		require.NoError(t, err)
+		assert.Equal(t, "next", resp.Msg.GetNextCursor())
+		assert.Equal(t, "prev", resp.Msg.GetPrevCursor())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("正常系：フィルタと並び順を受け渡し総件数を返す", func(t *testing.T) {
+		createdFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockService := new(MockUserService)
+		mockService.On("ListUsers", mock.Anything, service.ListUsersRequest{
+			Limit:       10,
+			SortBy:      "email",
+			SortOrder:   "asc",
+			EmailPrefix: "al",
+			CreatedFrom: &createdFrom,
+		}).Return(&service.ListUsersResponse{Users: []*service.UserResponse{{ID: uuid.New()}}, TotalCount: 31}, nil)
+		client := newConnectTestClient(t, mockService, admin)
+
+		resp, err := client.ListUsers(context.Background(), connect.NewRequest(&userv1.ListUsersRequest{
+			SortBy:      "email",
+			SortOrder:   "asc",
+			EmailPrefix: "al",
+			CreatedFrom: timestamppb.New(createdFrom),
+		}))
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(31), resp.Msg.GetTotalCount())
+		assert.False(t, resp.Msg.GetTotalCountEstimated())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("異常系：許可されていない並び順", func(t *testing.T) {
+		mockService := new(MockUserService)
+		mockService.On("ListUsers", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidSort)
+		client := newConnectTestClient(t, mockService, admin)
+
+		_, err := client.ListUsers(context.Background(), connect.NewRequest(&userv1.ListUsersRequest{SortBy: "password"}))
+
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("異常系：不正なカーソル", func(t *testing.T) {
+		mockService := new(MockUserService)
+		mockService.On("ListUsers", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidCursor)
+		client := newConnectTestClient(t, mockService, admin)
+
+		_, err := client.ListUsers(context.Background(), connect.NewRequest(&userv1.ListUsersRequest{Cursor: "tampered"}))
+
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("異常系：管理者以外は一覧取得不可", func(t *testing.T) {
+		mockService := new(MockUserService)
+		client := newConnectTestClient(t, mockService, &auth.Principal{UserID: uuid.New()})
+
+		_, err := client.ListUsers(context.Background(), connect.NewRequest(&userv1.ListUsersRequest{}))
+
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+		mockService.AssertNotCalled(t, "ListUsers", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserConnectHandler_SearchUsers(t *testing.T) {
+	support := &auth.Principal{UserID: uuid.New(), Roles: []domain.Role{domain.RoleSupport}}
+
+	t.Run("正常系：スコア付きの検索結果を返す", func(t *testing.T) {
+		userID := uuid.New()
+		mockService := new(MockUserService)
+		mockService.On("SearchUsers", mock.Anything, service.SearchUsersRequest{Query: "alic", Limit: 10}).
+			Return(&service.SearchUsersResponse{
+				Hits:       []*service.UserSearchHitResponse{{User: &service.UserResponse{ID: userID}, Score: 0.75}},
+				TotalCount: 1,
+			}, nil)
+		client := newConnectTestClient(t, mockService, support)
+
+		resp, err := client.SearchUsers(context.Background(), connect.NewRequest(&userv1.SearchUsersRequest{Query: "alic"}))
+
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.GetHits(), 1)
+		assert.Equal(t, userID.String(), resp.Msg.GetHits()[0].GetUser().GetId())
+		assert.Equal(t, 0.75, resp.Msg.GetHits()[0].GetScore())
+		assert.Equal(t, int64(1), resp.Msg.GetTotalCount())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("異常系：不正な検索語", func(t *testing.T) {
+		mockService := new(MockUserService)
+		mockService.On("SearchUsers", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidSearchQuery)
+		client := newConnectTestClient(t, mockService, support)
+
+		_, err := client.SearchUsers(context.Background(), connect.NewRequest(&userv1.SearchUsersRequest{}))
+
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("異常系：権限のないユーザーは検索不可", func(t *testing.T) {
+		mockService := new(MockUserService)
+		client := newConnectTestClient(t, mockService, &auth.Principal{UserID: uuid.New()})
+
+		_, err := client.SearchUsers(context.Background(), connect.NewRequest(&userv1.SearchUsersRequest{Query: "alice"}))
+
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+		mockService.AssertNotCalled(t, "SearchUsers", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserConnectHandler_DeleteUser(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("正常系：ユーザー削除成功", func(t *testing.T) {
+		mockService := new(MockUserService)
+		mockService.On("DeleteUser", mock.Anything, service.DeleteUserRequest{ID: userID}).Return(nil)
+		client := newConnectTestClient(t, mockService, &auth.Principal{UserID: userID})
+
+		_, err := client.DeleteUser(context.Background(), connect.NewRequest(&userv1.DeleteUserRequest{Id: userID.String()}))
+
+		assert.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestUserConnectHandler_GrantRole(t *testing.T) {
+	userID := uuid.New()
+	admin := &auth.Principal{UserID: uuid.New(), Roles: []domain.Role{domain.RoleAdmin}}
+
+	t.Run("正常系：管理者によるロール付与", func(t *testing.T) {
+		mockService := new(MockUserService)
+		mockService.On("GrantRole", mock.Anything, service.GrantRoleRequest{UserID: userID, Role: domain.RoleSupport}).Return(nil)
+		client := newConnectTestClient(t, mockService, admin)
+
+		_, err := client.GrantRole(context.Background(), connect.NewRequest(&userv1.GrantRoleRequest{UserId: userID.String(), Role: "support"}))
+
+		assert.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("異常系：未知のロール", func(t *testing.T) {
+		mockService := new(MockUserService)
+		mockService.On("GrantRole", mock.Anything, service.GrantRoleRequest{UserID: userID, Role: "owner"}).Return(domain.ErrInvalidRole)
+		client := newConnectTestClient(t, mockService, admin)
+
+		_, err := client.GrantRole(context.Background(), connect.NewRequest(&userv1.GrantRoleRequest{UserId: userID.String(), Role: "owner"}))
+
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("異常系：サポートはロール付与不可", func(t *testing.T) {
+		mockService := new(MockUserService)
+		client := newConnectTestClient(t, mockService, &auth.Principal{UserID: uuid.New(), Roles: []domain.Role{domain.RoleSupport}})
+
+		_, err := client.GrantRole(context.Background(), connect.NewRequest(&userv1.GrantRoleRequest{UserId: userID.String(), Role: "admin"}))
+
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+		mockService.AssertNotCalled(t, "GrantRole", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserConnectHandler_VerifyEmail(t *testing.T) {
+	t.Run("正常系：未認証でもメールアドレスを確認できる", func(t *testing.T) {
+		mockService := new(MockUserService)
+		mockService.On("VerifyEmail", mock.Anything, service.VerifyEmailRequest{Token: "verification-token"}).Return(nil)
+		client := newConnectTestClient(t, mockService, nil)
+
+		_, err := client.VerifyEmail(context.Background(), connect.NewRequest(&userv1.VerifyEmailRequest{Token: "verification-token"}))
+
+		assert.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("異常系：無効なトークン", func(t *testing.T) {
+		mockService := new(MockUserService)
+		mockService.On("VerifyEmail", mock.Anything, mock.Anything).Return(domain.ErrInvalidToken)
+		client := newConnectTestClient(t, mockService, nil)
+
+		_, err := client.VerifyEmail(context.Background(), connect.NewRequest(&userv1.VerifyEmailRequest{Token: "expired-token"}))
+
+		assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+
+	t.Run("異常系：トークンなし", func(t *testing.T) {
+		mockService := new(MockUserService)
+		client := newConnectTestClient(t, mockService, nil)
+
+		_, err := client.VerifyEmail(context.Background(), connect.NewRequest(&userv1.VerifyEmailRequest{}))
+
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+		mockService.AssertNotCalled(t, "VerifyEmail", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserConnectHandler_ResendEmailVerification(t *testing.T) {
+	t.Run("正常系：未認証でも再送を受け付ける", func(t *testing.T) {
+		mockService := new(MockUserService)
+		mockService.On("ResendEmailVerification", mock.Anything, service.ResendEmailVerificationRequest{Email: "test@example.com"}).Return(nil)
+		client := newConnectTestClient(t, mockService, nil)
+
+		_, err := client.ResendEmailVerification(context.Background(), connect.NewRequest(&userv1.ResendEmailVerificationRequest{Email: "test@example.com"}))
+
+		assert.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+}
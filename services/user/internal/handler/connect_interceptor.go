@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/gen/user/v1/userv1connect"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/auth"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/service"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// publicProcedures can be called without an access token
+var publicProcedures = map[string]bool{
+	userv1connect.UserServiceCreateUserProcedure:              true,
+	userv1connect.UserServiceAuthenticateUserProcedure:        true,
+	userv1connect.UserServiceRefreshTokenProcedure:            true,
+	userv1connect.UserServiceLogoutProcedure:                  true,
+	userv1connect.UserServiceRequestPasswordResetProcedure:    true,
+	userv1connect.UserServiceConfirmPasswordResetProcedure:    true,
+	userv1connect.UserServiceVerifyEmailProcedure:             true,
+	userv1connect.UserServiceResendEmailVerificationProcedure: true,
+}
+
+// NewAuthInterceptor authenticates bearer tokens on Connect requests, mirroring AuthMiddleware
+func NewAuthInterceptor(issuer service.TokenIssuer, logger logger.Logger) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if publicProcedures[req.Spec().Procedure] {
+				return next(ctx, req)
+			}
+
+			token, ok := bearerToken(req.Header().Get("Authorization"))
+			if !ok {
+				return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("missing bearer token"))
+			}
+
+			claims, err := issuer.ParseAccessToken(token)
+			if err != nil {
+				logger.Info(ctx, "Invalid access token", zap.Error(err))
+				return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid or expired token"))
+			}
+
+			ctx = withPrincipal(ctx, &auth.Principal{
+				UserID: claims.UserID,
+				Roles:  claims.Roles,
+			})
+			return next(ctx, req)
+		}
+	}
+}
+
+// NewLogContextInterceptor adds the procedure to the log entries of each Connect call;
+// the route of the request only tells that it is a Connect call, as all procedures share one route
+func NewLogContextInterceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			ctx = logger.WithContextFields(ctx, zap.String("procedure", req.Spec().Procedure))
+			return next(ctx, req)
+		}
+	}
+}
+
+// NewTracingInterceptor runs each Connect call in a span named after its procedure, e.g. "user.v1.UserService/GetUser".
+// All procedures share one route in the router's server span, so this span is what tells the calls apart.
+func NewTracingInterceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			procedure := strings.TrimPrefix(req.Spec().Procedure, "/")
+			rpcService, rpcMethod, _ := strings.Cut(procedure, "/")
+			ctx, span := tracing.Start(ctx, procedure,
+				trace.WithAttributes(
+					semconv.RPCSystemConnectRPC,
+					semconv.RPCService(rpcService),
+					semconv.RPCMethod(rpcMethod),
+				),
+			)
+			resp, err := next(ctx, req)
+			tracing.End(span, err)
+			return resp, err
+		}
+	}
+}
@@ -25,19 +25,114 @@ type AuthenticateUserRequest struct {
 	Password domain.Password `json:"password" validate:"required"`
 }
 
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword domain.Password `json:"current_password" validate:"required"`
+	NewPassword     domain.Password `json:"new_password" validate:"required,min=8"`
+}
+
+type RequestPasswordResetRequest struct {
+	Email domain.Email `json:"email" validate:"required,email"`
+}
+
+type ConfirmPasswordResetRequest struct {
+	Token       string          `json:"token" validate:"required"`
+	NewPassword domain.Password `json:"new_password" validate:"required,min=8"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ResendEmailVerificationRequest struct {
+	Email domain.Email `json:"email" validate:"required,email"`
+}
+
+type GrantRoleRequest struct {
+	Role domain.Role `json:"role" validate:"required"`
+}
+
+type AuthTokensResponse struct {
+	AccessToken           string `json:"access_token"`
+	TokenType             string `json:"token_type"`
+	ExpiresIn             int64  `json:"expires_in"`
+	RefreshToken          string `json:"refresh_token"`
+	RefreshTokenExpiresAt string `json:"refresh_token_expires_at"`
+}
+
 type UserResponse struct {
 	ID        uuid.UUID `json:"id"`
 	Email     string    `json:"email"`
 	Name      string    `json:"name"`
+	Roles     []string  `json:"roles"`
 	CreatedAt string    `json:"created_at"`
 	UpdatedAt string    `json:"updated_at"`
+	// EmailVerifiedAt is omitted until the current email address is verified
+	EmailVerifiedAt *string `json:"email_verified_at,omitempty"`
 }
 
 type ListUsersResponse struct {
-	Users      []*UserResponse `json:"users"`
-	TotalCount int             `json:"total_count"`
-	Limit      int             `json:"limit"`
-	Offset     int             `json:"offset"`
+	Users               []*UserResponse `json:"users"`
+	TotalCount          int64           `json:"total_count"`
+	TotalCountEstimated bool            `json:"total_count_estimated,omitempty"`
+	Limit               int             `json:"limit"`
+	Offset              int             `json:"offset"`
+	NextCursor          string          `json:"next_cursor,omitempty"`
+	PrevCursor          string          `json:"prev_cursor,omitempty"`
+}
+
+type UserSearchHitResponse struct {
+	User  *UserResponse `json:"user"`
+	Score float64       `json:"score"`
+}
+
+type SearchUsersResponse struct {
+	Hits       []*UserSearchHitResponse `json:"hits"`
+	TotalCount int64                    `json:"total_count"`
+	Limit      int                      `json:"limit"`
+	Offset     int                      `json:"offset"`
+}
+
+type AuditEventResponse struct {
+	ID         uuid.UUID            `json:"id"`
+	Action     string               `json:"action"`
+	Outcome    string               `json:"outcome"`
+	ActorID    *uuid.UUID           `json:"actor_id"`
+	UserID     *uuid.UUID           `json:"user_id"`
+	RequestID  string               `json:"request_id"`
+	ClientIP   string               `json:"client_ip"`
+	Changes    []domain.AuditChange `json:"changes"`
+	Error      string               `json:"error,omitempty"`
+	OccurredAt string               `json:"occurred_at"`
+}
+
+type ListAuditEventsResponse struct {
+	Events     []*AuditEventResponse `json:"events"`
+	TotalCount int64                 `json:"total_count"`
+	Limit      int                   `json:"limit"`
+	Offset     int                   `json:"offset"`
+}
+
+type BatchCreateUserResult struct {
+	Index  int           `json:"index"`
+	Status string        `json:"status"`
+	Email  string        `json:"email"`
+	User   *UserResponse `json:"user,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+type BatchCreateUsersResponse struct {
+	Results  []*BatchCreateUserResult `json:"results"`
+	Created  int                      `json:"created"`
+	Rejected int                      `json:"rejected"`
+	Skipped  int                      `json:"skipped"`
 }
 
 type ErrorResponse struct {
@@ -10,22 +10,54 @@ import (
 )
 
 func (h *UserHandler) handleServiceError(w http.ResponseWriter, r *http.Request, err error) {
-	h.logger.Error("Service error", zap.Error(err))
+	h.logger.Error(r.Context(), "Service error", zap.Error(err))
 
+	status, message := serviceErrorStatus(err)
+	h.renderError(w, r, status, message)
+}
+
+// serviceErrorStatus maps a service error to its HTTP status and client-facing message
+func serviceErrorStatus(err error) (int, string) {
 	switch {
 	case errors.Is(err, domain.ErrUserNotFound):
-		h.renderError(w, r, http.StatusNotFound, "User not found")
+		return http.StatusNotFound, "User not found"
 	case errors.Is(err, domain.ErrUserAlreadyExists):
-		h.renderError(w, r, http.StatusConflict, "User already exists")
+		return http.StatusConflict, "User already exists"
 	case errors.Is(err, domain.ErrInvalidEmail):
-		h.renderError(w, r, http.StatusBadRequest, "Invalid email address")
+		return http.StatusBadRequest, "Invalid email address"
 	case errors.Is(err, domain.ErrInvalidName):
-		h.renderError(w, r, http.StatusBadRequest, "Invalid name")
+		return http.StatusBadRequest, "Invalid name"
 	case errors.Is(err, domain.ErrInvalidPassword):
-		h.renderError(w, r, http.StatusBadRequest, "Invalid password")
+		return http.StatusBadRequest, "Invalid password"
+	case errors.Is(err, domain.ErrInvalidRole):
+		return http.StatusBadRequest, "Invalid role"
+	case errors.Is(err, domain.ErrInvalidCursor):
+		return http.StatusBadRequest, "Invalid cursor"
+	case errors.Is(err, domain.ErrInvalidSort):
+		return http.StatusBadRequest, "Invalid sort"
+	case errors.Is(err, domain.ErrInvalidFilter):
+		return http.StatusBadRequest, "Invalid filter"
+	case errors.Is(err, domain.ErrInvalidSearchQuery):
+		return http.StatusBadRequest, "Invalid search query"
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		return http.StatusUnauthorized, "Invalid credentials"
+	case errors.Is(err, domain.ErrAccountLocked):
+		return http.StatusLocked, "Account is temporarily locked"
+	case errors.Is(err, domain.ErrTooManyAttempts):
+		return http.StatusTooManyRequests, "Too many login attempts"
+	case errors.Is(err, domain.ErrEmailNotVerified):
+		return http.StatusForbidden, "Email address is not verified"
+	case errors.Is(err, domain.ErrInvalidToken):
+		return http.StatusUnauthorized, "Invalid or expired token"
+	case errors.Is(err, domain.ErrInvalidInput):
+		return http.StatusBadRequest, "Invalid input"
+	case errors.Is(err, domain.ErrBatchTooLarge):
+		return http.StatusRequestEntityTooLarge, "Too many rows in batch"
+	case errors.Is(err, domain.ErrConcurrentModification):
+		return http.StatusPreconditionFailed, "User was modified by another request"
 	default:
 		// 詳細なエラーメッセージを表示
-		h.renderError(w, r, http.StatusInternalServerError, err.Error())
+		return http.StatusInternalServerError, err.Error()
 	}
 }
 
@@ -44,7 +76,7 @@ func (h *UserHandler) renderErrorWithDetails(w http.ResponseWriter, r *http.Requ
 	}
 
 	if err := json.NewEncoder(w).Encode(errorResp); err != nil {
-		h.logger.Error("Failed to encode error response", zap.Error(err))
+		h.logger.Error(r.Context(), "Failed to encode error response", zap.Error(err))
 	}
 }
 
@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/auth"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	anonymousIdempotencyScope = "anonymous"
+)
+
+// replayedHeaders are the response headers stored with the response and sent again on replay
+var replayedHeaders = []string{"Content-Type", "Location", "ETag"}
+
+// IdempotencyConfig configures the Idempotency-Key middleware
+type IdempotencyConfig struct {
+	// TTL is how long a stored response is replayed; afterwards the key can be reused for a new request
+	TTL time.Duration
+	// MaxBodyBytes limits the request body that is read to compute the request hash
+	MaxBodyBytes int64
+	// HashKey signs the request hash so that request bodies (which may contain passwords) cannot be guessed from the table
+	HashKey []byte
+}
+
+// DefaultIdempotencyConfig returns the default Idempotency-Key middleware configuration
+func DefaultIdempotencyConfig(hashKey []byte) IdempotencyConfig {
+	return IdempotencyConfig{
+		TTL:          24 * time.Hour,
+		MaxBodyBytes: 1 << 20,
+		HashKey:      hashKey,
+	}
+}
+
+// IdempotencyMiddleware replays the stored response when a request is retried with the same Idempotency-Key header
+type IdempotencyMiddleware struct {
+	repo   repository.IdempotencyKeyRepository
+	cfg    IdempotencyConfig
+	logger logger.Logger
+	now    func() time.Time
+}
+
+func NewIdempotencyMiddleware(repo repository.IdempotencyKeyRepository, cfg IdempotencyConfig, logger logger.Logger) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{
+		repo:   repo,
+		cfg:    cfg,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Handle processes the first request with a key and stores its response; a retry with the same key and body gets the
+// stored response, a different body is 422 and a retry while the first request is still running is 409.
+// Requests without the header are passed through unchanged.
+func (m *IdempotencyMiddleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(idempotencyKeyHeader)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !validIdempotencyKey(key) {
+			m.renderError(w, r, http.StatusBadRequest, "Invalid Idempotency-Key header")
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, m.cfg.MaxBodyBytes))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				m.renderError(w, r, http.StatusRequestEntityTooLarge, "Request body too large")
+				return
+			}
+			m.renderError(w, r, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := r.Context()
+		record := domain.NewIdempotencyKey(idempotencyScope(ctx), key, m.requestHash(r, body), m.now().Add(m.cfg.TTL))
+		existing, reserved, err := m.repo.Reserve(ctx, record)
+		if err != nil {
+			m.logger.Error(ctx, "Failed to reserve idempotency key", zap.Error(err))
+			m.renderError(w, r, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		if !reserved {
+			m.handleExisting(w, r, existing, record.RequestHash)
+			return
+		}
+
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		completed := false
+		defer func() {
+			// パニックやエラーで応答を保存できなかった場合は、同じキーで再試行できるよう予約を解除する
+			if !completed {
+				m.release(ctx, record)
+			}
+		}()
+
+		next.ServeHTTP(rec, r)
+
+		if retryableStatus(rec.status) {
+			return
+		}
+		record.StatusCode = rec.status
+		record.ResponseHeaders = storedHeaders(rec.Header())
+		record.ResponseBody = rec.body.Bytes()
+		// クライアントが切断していても応答は保存する
+		if err := m.repo.Complete(context.WithoutCancel(ctx), record); err != nil {
+			m.logger.Error(ctx, "Failed to store idempotent response", zap.Error(err))
+			return
+		}
+		completed = true
+	})
+}
+
+func (m *IdempotencyMiddleware) handleExisting(w http.ResponseWriter, r *http.Request, existing *domain.IdempotencyKey, requestHash string) {
+	switch {
+	case !existing.Matches(requestHash):
+		m.renderError(w, r, http.StatusUnprocessableEntity, "Idempotency-Key was already used with a different request")
+	case !existing.IsCompleted():
+		w.Header().Set("Retry-After", "1")
+		m.renderError(w, r, http.StatusConflict, "A request with the same Idempotency-Key is still being processed")
+	default:
+		for name, value := range existing.ResponseHeaders {
+			w.Header().Set(name, value)
+		}
+		w.Header().Set(idempotentReplayedHeader, "true")
+		w.WriteHeader(existing.StatusCode)
+		if _, err := w.Write(existing.ResponseBody); err != nil {
+			m.logger.Error(r.Context(), "Failed to write idempotent response", zap.Error(err))
+		}
+	}
+}
+
+func (m *IdempotencyMiddleware) release(ctx context.Context, record *domain.IdempotencyKey) {
+	if err := m.repo.Release(context.WithoutCancel(ctx), record.Scope, record.Key); err != nil {
+		m.logger.Error(ctx, "Failed to release idempotency key", zap.Error(err))
+	}
+}
+
+// requestHash is the HMAC-SHA256 of the method, the request URI and the body
+func (m *IdempotencyMiddleware) requestHash(r *http.Request, body []byte) string {
+	mac := hmac.New(sha256.New, m.cfg.HashKey)
+	mac.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (m *IdempotencyMiddleware) renderError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	errorResp := ErrorResponse{
+		Error: message,
+		Code:  http.StatusText(status),
+	}
+
+	if err := json.NewEncoder(w).Encode(errorResp); err != nil {
+		m.logger.Error(r.Context(), "Failed to encode error response", zap.Error(err))
+	}
+}
+
+// idempotencyScope separates the keys of authenticated callers; unauthenticated requests share one scope
+func idempotencyScope(ctx context.Context) string {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		return principal.UserID.String()
+	}
+	return anonymousIdempotencyScope
+}
+
+// validIdempotencyKey accepts 1 to 255 printable ASCII characters
+func validIdempotencyKey(key string) bool {
+	if len(key) > maxIdempotencyKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x21 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// retryableStatus reports whether the response is not stored so that the same key can be retried (server errors and rate limits)
+func retryableStatus(status int) bool {
+	return status >= http.StatusInternalServerError || status == http.StatusTooManyRequests
+}
+
+func storedHeaders(header http.Header) map[string]string {
+	stored := make(map[string]string, len(replayedHeaders))
+	for _, name := range replayedHeaders {
+		if value := header.Get(name); value != "" {
+			stored[name] = value
+		}
+	}
+	return stored
+}
+
+// responseRecorder writes the response through and keeps a copy of its status and body
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	if !rec.wroteHeader {
+		rec.status = status
+		rec.wroteHeader = true
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	if !rec.wroteHeader {
+		rec.WriteHeader(http.StatusOK)
+	}
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (rec *responseRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
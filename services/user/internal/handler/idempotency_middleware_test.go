@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIdempotencyMiddleware_Handle(t *testing.T) {
+	cfg := DefaultIdempotencyConfig([]byte("test-secret"))
+	body := `{"email":"alice@example.com"}`
+
+	// 最初のリクエストと同じハッシュを持つ既存キーを作る
+	hashOf := func(body string) string {
+		m := NewIdempotencyMiddleware(nil, cfg, logger.NewNop())
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(body))
+		return m.requestHash(req, []byte(body))
+	}
+	completed := &domain.IdempotencyKey{
+		Scope:           anonymousIdempotencyScope,
+		Key:             "key-1",
+		RequestHash:     hashOf(body),
+		StatusCode:      http.StatusCreated,
+		ResponseHeaders: map[string]string{"Content-Type": "application/json"},
+		ResponseBody:    []byte(`{"id":"stored"}`),
+		ExpiresAt:       time.Now().Add(time.Hour),
+	}
+	inProgress := &domain.IdempotencyKey{
+		Scope:       anonymousIdempotencyScope,
+		Key:         "key-1",
+		RequestHash: hashOf(body),
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+
+	tests := []struct {
+		name           string
+		key            string
+		body           string
+		nextStatus     int
+		mockSetup      func(*repository.MockIdempotencyKeyRepository)
+		expectedStatus int
+		expectedBody   string
+		expectReplay   bool
+		expectCalled   bool
+	}{
+		{
+			name:           "成功: ヘッダーなしはそのまま処理",
+			body:           body,
+			nextStatus:     http.StatusCreated,
+			mockSetup:      func(m *repository.MockIdempotencyKeyRepository) {},
+			expectedStatus: http.StatusCreated,
+			expectCalled:   true,
+		},
+		{
+			name:           "失敗: 不正なキー",
+			key:            "invalid key",
+			body:           body,
+			mockSetup:      func(m *repository.MockIdempotencyKeyRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "成功: 最初のリクエストは応答を保存",
+			key:        "key-1",
+			body:       body,
+			nextStatus: http.StatusCreated,
+			mockSetup: func(m *repository.MockIdempotencyKeyRepository) {
+				m.On("Reserve", mock.Anything, mock.Anything).Return(nil, true, nil)
+				m.On("Complete", mock.Anything, mock.MatchedBy(func(k *domain.IdempotencyKey) bool {
+					return k.StatusCode == http.StatusCreated && string(k.ResponseBody) == `{"id":"new"}` &&
+						k.ResponseHeaders["Content-Type"] == "application/json"
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"new"}`,
+			expectCalled:   true,
+		},
+		{
+			name:       "成功: 同じ本文の再試行は保存した応答を返す",
+			key:        "key-1",
+			body:       body,
+			nextStatus: http.StatusCreated,
+			mockSetup: func(m *repository.MockIdempotencyKeyRepository) {
+				m.On("Reserve", mock.Anything, mock.Anything).Return(completed, false, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"stored"}`,
+			expectReplay:   true,
+		},
+		{
+			name: "失敗: 異なる本文での再利用は422",
+			key:  "key-1",
+			body: `{"email":"bob@example.com"}`,
+			mockSetup: func(m *repository.MockIdempotencyKeyRepository) {
+				m.On("Reserve", mock.Anything, mock.Anything).Return(completed, false, nil)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "失敗: 処理中のキーは409",
+			key:  "key-1",
+			body: body,
+			mockSetup: func(m *repository.MockIdempotencyKeyRepository) {
+				m.On("Reserve", mock.Anything, mock.Anything).Return(inProgress, false, nil)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:       "成功: サーバーエラーは保存せず予約を解除",
+			key:        "key-1",
+			body:       body,
+			nextStatus: http.StatusInternalServerError,
+			mockSetup: func(m *repository.MockIdempotencyKeyRepository) {
+				m.On("Reserve", mock.Anything, mock.Anything).Return(nil, true, nil)
+				m.On("Release", mock.Anything, anonymousIdempotencyScope, "key-1").Return(nil)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectCalled:   true,
+		},
+		{
+			name: "失敗: 予約エラー",
+			key:  "key-1",
+			body: body,
+			mockSetup: func(m *repository.MockIdempotencyKeyRepository) {
+				m.On("Reserve", mock.Anything, mock.Anything).Return(nil, false, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockIdempotencyKeyRepository)
+			tt.mockSetup(mockRepo)
+
+			called := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.nextStatus)
+				_, _ = w.Write([]byte(`{"id":"new"}`))
+			})
+			h := NewIdempotencyMiddleware(mockRepo, cfg, logger.NewNop()).Handle(next)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(tt.body))
+			if tt.key != "" {
+				req.Header.Set(idempotencyKeyHeader, tt.key)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, rec.Body.String())
+			}
+			assert.Equal(t, tt.expectReplay, rec.Header().Get(idempotentReplayedHeader) == "true")
+			assert.Equal(t, tt.expectCalled, called)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
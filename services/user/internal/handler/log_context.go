@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/auth"
+	"go.uber.org/zap"
+)
+
+// logContext attaches the request ID, method and route to the request context, so that every entry
+// logged while serving the request carries them; it must run after middleware.RequestID
+func logContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := logger.WithContextFields(r.Context(),
+			zap.String("request_id", middleware.GetReqID(r.Context())),
+			zap.String("method", r.Method),
+			zap.String("route", findRoute(r)),
+		)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// findRoute resolves the route pattern the request will be routed to.
+// chi only fills in the pattern while routing, after the top-level middlewares have run, so the route is looked up ahead.
+func findRoute(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil || rctx.Routes == nil {
+		return unmatchedRoute
+	}
+	if pattern := rctx.Routes.Find(chi.NewRouteContext(), r.Method, r.URL.Path); pattern != "" {
+		return pattern
+	}
+	return unmatchedRoute
+}
+
+// withPrincipal stores the authenticated caller in ctx and adds its user ID to the log entries of the request
+func withPrincipal(ctx context.Context, principal *auth.Principal) context.Context {
+	ctx = auth.WithPrincipal(ctx, principal)
+	return logger.WithContextFields(ctx, zap.String("user_id", principal.UserID.String()))
+}
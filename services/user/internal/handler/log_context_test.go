@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/metrics"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogContext(t *testing.T) {
+	issuer := service.NewJWTTokenIssuer(service.DefaultTokenConfig([]byte("test-secret")))
+	userID := uuid.New()
+	token, _, err := issuer.IssueAccessToken(userID, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		authorization  string
+		setupMock      func(*MockUserService)
+		expectedMsg    string
+		expectedFields map[string]any
+	}{
+		{
+			name:          "成功: 認証後のログにユーザー ID とリクエストの情報を付与",
+			authorization: "Bearer " + token,
+			setupMock: func(m *MockUserService) {
+				m.On("GetUserByID", mock.Anything, userID).Return(nil, errors.New("database error"))
+			},
+			expectedMsg: "Service error",
+			expectedFields: map[string]any{
+				"method":  http.MethodGet,
+				"route":   "/api/v1/users/{userID}",
+				"user_id": userID.String(),
+			},
+		},
+		{
+			name:          "成功: 認証前のログにはユーザー ID を付与しない",
+			authorization: "Bearer invalid",
+			setupMock:     func(m *MockUserService) {},
+			expectedMsg:   "Invalid access token",
+			expectedFields: map[string]any{
+				"method": http.MethodGet,
+				"route":  "/api/v1/users/{userID}",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.InfoLevel)
+			log := logger.New(zap.New(core), logger.DefaultRedactionConfig())
+			mockSvc := new(MockUserService)
+			tt.setupMock(mockSvc)
+			idemMW := NewIdempotencyMiddleware(new(repository.MockIdempotencyKeyRepository), DefaultIdempotencyConfig([]byte("test-secret")), log)
+			router := NewRouter(NewUserHandler(mockSvc, log), NewAuthMiddleware(issuer, log), idemMW, NewMetricsMiddleware(metrics.New(nil)), DefaultRouterConfig())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/users/"+userID.String(), nil)
+			req.Header.Set("Authorization", tt.authorization)
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			entries := logs.FilterMessage(tt.expectedMsg).All()
+			require.Len(t, entries, 1)
+			fields := entries[0].ContextMap()
+			for key, value := range tt.expectedFields {
+				assert.Equal(t, value, fields[key], key)
+			}
+			assert.NotEmpty(t, fields["request_id"])
+			if _, ok := tt.expectedFields["user_id"]; !ok {
+				assert.NotContains(t, fields, "user_id")
+			}
+		})
+	}
+}
+
+func TestUserHandler_CreateUser_RedactsPersonalData(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	mockSvc := new(MockUserService)
+	mockSvc.On("CreateUser", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+	h := NewUserHandler(mockSvc, logger.New(zap.New(core), logger.DefaultRedactionConfig()))
+
+	body := `{"email":"alice@example.com","name":"Alice Smith","password":"Password123"}`
+	h.CreateUser(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(body)))
+
+	require.NotEmpty(t, logs.All())
+	for _, entry := range logs.All() {
+		for key, value := range entry.ContextMap() {
+			for _, raw := range []string{"alice@example.com", "Alice Smith", "Password123"} {
+				assert.NotContains(t, fmt.Sprint(value), raw, "%s: %s", entry.Message, key)
+			}
+		}
+	}
+	entries := logs.FilterMessage("CreateUser request").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "a***@example.com", entries[0].ContextMap()["email"])
+}
+
+func TestRouter_DoesNotLogQueryStrings(t *testing.T) {
+	issuer := service.NewJWTTokenIssuer(service.DefaultTokenConfig([]byte("test-secret")))
+	token, _, err := issuer.IssueAccessToken(uuid.New(), []domain.Role{domain.RoleAdmin})
+	require.NoError(t, err)
+
+	// chi の middleware.Logger など、標準ライブラリのロガーに書かれたものも確認する
+	var stdlog bytes.Buffer
+	log.SetOutput(&stdlog)
+	defaultLogger := middleware.DefaultLogger
+	middleware.DefaultLogger = middleware.RequestLogger(&middleware.DefaultLogFormatter{Logger: log.New(&stdlog, "", 0), NoColor: true})
+	t.Cleanup(func() {
+		log.SetOutput(os.Stderr)
+		middleware.DefaultLogger = defaultLogger
+	})
+
+	var out bytes.Buffer
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&out), zapcore.DebugLevel)
+	l := logger.New(zap.New(core), logger.DefaultRedactionConfig())
+	mockSvc := new(MockUserService)
+	mockSvc.On("GetUserByEmail", mock.Anything, mock.Anything).Return(nil, domain.ErrUserNotFound)
+	mockSvc.On("SearchUsers", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+	idemMW := NewIdempotencyMiddleware(new(repository.MockIdempotencyKeyRepository), DefaultIdempotencyConfig([]byte("test-secret")), l)
+	router := NewRouter(NewUserHandler(mockSvc, l), NewAuthMiddleware(issuer, l), idemMW, NewMetricsMiddleware(metrics.New(nil)), DefaultRouterConfig())
+
+	for _, target := range []string{
+		"/api/v1/users/lookup?email=alice%40example.com",
+		"/api/v1/users/search?q=alice@example.com",
+	} {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	// アクセスログは出力されている
+	assert.Equal(t, 2, strings.Count(out.String(), "Request served"))
+	assert.Contains(t, out.String(), `"route":"/api/v1/users/lookup"`)
+	for _, output := range []string{out.String(), stdlog.String()} {
+		assert.NotContains(t, output, "alice@example.com")
+		assert.NotContains(t, output, "alice%40example.com")
+	}
+}
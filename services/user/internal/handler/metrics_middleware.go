@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// unmatchedRoute labels requests that matched no route, so that arbitrary paths do not create new series
+const unmatchedRoute = "unmatched"
+
+// RequestObserver records the outcome of served requests
+type RequestObserver interface {
+	ObserveRequest(route, method string, status int, elapsed time.Duration)
+}
+
+// MetricsMiddleware reports the rate, errors and duration of requests per route
+type MetricsMiddleware struct {
+	observer RequestObserver
+}
+
+func NewMetricsMiddleware(observer RequestObserver) *MetricsMiddleware {
+	return &MetricsMiddleware{
+		observer: observer,
+	}
+}
+
+// Handle observes every request with the chi route pattern it matched (e.g. /api/v1/users/{userID}/), never the raw path.
+// It must run outside middleware.Recoverer so that panics are counted as 500.
+func (m *MetricsMiddleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r)
+
+		m.observer.ObserveRequest(routePattern(r), r.Method, responseStatus(ww), time.Since(start))
+	})
+}
+
+// routePattern returns the chi route pattern the request matched; it is only known once the request has been routed
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+		return rctx.RoutePattern()
+	}
+	return unmatchedRoute
+}
+
+// responseStatus returns the status code written to the response
+func responseStatus(ww middleware.WrapResponseWriter) int {
+	// 本文を書かずに返したハンドラーは 200 を返している
+	if status := ww.Status(); status != 0 {
+		return status
+	}
+	return http.StatusOK
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRequestObserver is a mock implementation of RequestObserver
+type MockRequestObserver struct {
+	mock.Mock
+}
+
+func (m *MockRequestObserver) ObserveRequest(route, method string, status int, elapsed time.Duration) {
+	m.Called(route, method, status, elapsed)
+}
+
+func TestMetricsMiddleware_Handle(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		expectedRoute string
+		expectedCode  int
+	}{
+		{
+			name:          "成功: パスではなくルートのパターンで記録",
+			method:        http.MethodGet,
+			path:          "/api/v1/users/123e4567-e89b-12d3-a456-426614174000",
+			expectedRoute: "/api/v1/users/{userID}",
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:          "成功: ステータスコードを記録",
+			method:        http.MethodPost,
+			path:          "/api/v1/users",
+			expectedRoute: "/api/v1/users",
+			expectedCode:  http.StatusCreated,
+		},
+		{
+			name:          "成功: パニックは 500 として記録",
+			method:        http.MethodDelete,
+			path:          "/api/v1/users/123e4567-e89b-12d3-a456-426614174000",
+			expectedRoute: "/api/v1/users/{userID}",
+			expectedCode:  http.StatusInternalServerError,
+		},
+		{
+			name:          "成功: 一致しないパスはまとめて記録",
+			method:        http.MethodGet,
+			path:          "/no/such/path",
+			expectedRoute: unmatchedRoute,
+			expectedCode:  http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observer := new(MockRequestObserver)
+			observer.On("ObserveRequest", tt.expectedRoute, tt.method, tt.expectedCode, mock.AnythingOfType("time.Duration")).Once()
+
+			r := chi.NewRouter()
+			r.Use(NewMetricsMiddleware(observer).Handle)
+			r.Use(middleware.Recoverer)
+			r.Get("/api/v1/users/{userID}", func(w http.ResponseWriter, r *http.Request) {})
+			r.Post("/api/v1/users", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusCreated) })
+			r.Delete("/api/v1/users/{userID}", func(w http.ResponseWriter, r *http.Request) { panic("boom") })
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			observer.AssertExpectations(t)
+		})
+	}
+}
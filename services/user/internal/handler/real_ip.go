@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+)
+
+// realIP rewrites RemoteAddr to the client IP set by a trusted reverse proxy in X-Real-IP or X-Forwarded-For.
+// Unlike middleware.RealIP, the headers of requests from any other peer are ignored, as a client could otherwise
+// pick the address its login failures are counted against.
+func realIP(trusted []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ip, ok := forwardedClientIP(r, trusted); ok {
+				r.RemoteAddr = ip.String()
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// forwardedClientIP returns the client IP forwarded by a trusted proxy, if the peer is one
+func forwardedClientIP(r *http.Request, trusted []netip.Prefix) (netip.Addr, bool) {
+	peer, err := netip.ParseAddr(remoteHost(r.RemoteAddr))
+	if err != nil || !isTrustedProxy(peer, trusted) {
+		return netip.Addr{}, false
+	}
+
+	if v := r.Header.Get("X-Real-IP"); v != "" {
+		ip, err := netip.ParseAddr(strings.TrimSpace(v))
+		return ip.Unmap(), err == nil
+	}
+
+	// 右端から信頼するプロキシを読み飛ばし、最初の信頼しないアドレスを接続元とする（左側はクライアントが詐称できる）
+	var client netip.Addr
+	for _, value := range slices.Backward(r.Header.Values("X-Forwarded-For")) {
+		hops := strings.Split(value, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			ip, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+			if err != nil {
+				return client, client.IsValid()
+			}
+			client = ip.Unmap()
+			if !isTrustedProxy(client, trusted) {
+				return client, true
+			}
+		}
+	}
+	return client, client.IsValid()
+}
+
+func isTrustedProxy(ip netip.Addr, trusted []netip.Prefix) bool {
+	ip = ip.Unmap()
+	for _, prefix := range trusted {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/metrics"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRealIP(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string][]string
+		expectedIP string
+	}{
+		{
+			name:       "成功: 信頼しない接続元のヘッダーは無視する",
+			remoteAddr: "192.0.2.1:1234",
+			headers: map[string][]string{
+				"X-Forwarded-For": {"198.51.100.7"},
+				"X-Real-IP":       {"198.51.100.8"},
+			},
+			expectedIP: "192.0.2.1",
+		},
+		{
+			name:       "成功: 信頼するプロキシの X-Real-IP",
+			remoteAddr: "10.0.0.2:1234",
+			headers:    map[string][]string{"X-Real-IP": {"203.0.113.5"}},
+			expectedIP: "203.0.113.5",
+		},
+		{
+			name:       "成功: X-Forwarded-For の詐称された左側は使わない",
+			remoteAddr: "10.0.0.2:1234",
+			headers:    map[string][]string{"X-Forwarded-For": {"198.51.100.7, 203.0.113.5"}},
+			expectedIP: "203.0.113.5",
+		},
+		{
+			name:       "成功: 多段の信頼するプロキシを読み飛ばす",
+			remoteAddr: "10.0.0.2:1234",
+			headers:    map[string][]string{"X-Forwarded-For": {"198.51.100.7", "203.0.113.5, 10.0.0.3"}},
+			expectedIP: "203.0.113.5",
+		},
+		{
+			name:       "成功: 不正な値はプロキシのアドレスのまま",
+			remoteAddr: "10.0.0.2:1234",
+			headers:    map[string][]string{"X-Forwarded-For": {"not-an-ip"}},
+			expectedIP: "10.0.0.2",
+		},
+		{
+			name:       "成功: ヘッダーがなければプロキシのアドレスのまま",
+			remoteAddr: "10.0.0.2:1234",
+			expectedIP: "10.0.0.2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var clientIP string
+			h := realIP(trusted)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				clientIP = remoteHost(r.RemoteAddr)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/users/authenticate", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for key, values := range tt.headers {
+				for _, v := range values {
+					req.Header.Add(key, v)
+				}
+			}
+			h.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.expectedIP, clientIP)
+		})
+	}
+}
+
+func TestNewRouter_IgnoresSpoofedForwardedFor(t *testing.T) {
+	// 既定ではどのプロキシも信頼せず、ヘッダーを変えてもログイン失敗は同じ IP に記録される
+	l := createTestLogger()
+	mockSvc := new(MockUserService)
+	mockSvc.On("AuthenticateUser", mock.Anything, mock.MatchedBy(func(req service.AuthenticateUserRequest) bool {
+		return req.ClientIP == "192.0.2.1"
+	})).Return(nil, domain.ErrInvalidCredentials).Twice()
+	idemMW := NewIdempotencyMiddleware(new(repository.MockIdempotencyKeyRepository), DefaultIdempotencyConfig([]byte("test-secret")), l)
+	router := NewRouter(NewUserHandler(mockSvc, l), NewAuthMiddleware(nil, l), idemMW, NewMetricsMiddleware(metrics.New(nil)), DefaultRouterConfig())
+
+	for _, spoofed := range []string{"198.51.100.7", "198.51.100.8"} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/users/authenticate", strings.NewReader(`{"email":"test@example.com","password":"WrongPassword"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Forwarded-For", spoofed)
+		req.Header.Set("X-Real-IP", spoofed)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	}
+	mockSvc.AssertExpectations(t)
+}
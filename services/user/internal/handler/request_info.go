@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/auth"
+)
+
+// requestInfo stores the request ID and the client IP in the request context; it must run after middleware.RequestID and realIP
+func requestInfo(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := auth.WithRequestInfo(r.Context(), auth.RequestInfo{
+			RequestID: middleware.GetReqID(r.Context()),
+			ClientIP:  remoteHost(r.RemoteAddr),
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -1,33 +1,113 @@
 package handler
 
 import (
+	"net/netip"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
 )
 
-func NewRouter(h *UserHandler) *chi.Mux {
+// RouterConfig configures the request timeouts and the trusted proxies of the router
+type RouterConfig struct {
+	// RequestTimeout bounds every request but bulk imports
+	RequestTimeout time.Duration
+	// BatchCreateTimeout bounds POST /users:batchCreate; the row limit of the service must fit within it
+	BatchCreateTimeout time.Duration
+	// TrustedProxies are the addresses of the reverse proxies whose X-Real-IP and X-Forwarded-For headers are trusted.
+	// The client IP keys the login throttle, so the headers of any other peer are ignored.
+	TrustedProxies []netip.Prefix
+}
+
+// DefaultRouterConfig returns the default router configuration, which trusts no proxy
+func DefaultRouterConfig() RouterConfig {
+	return RouterConfig{
+		RequestTimeout:     60 * time.Second,
+		BatchCreateTimeout: 5 * time.Minute,
+	}
+}
+
+func NewRouter(h *UserHandler, authMW *AuthMiddleware, idemMW *IdempotencyMiddleware, metricsMW *MetricsMiddleware, cfg RouterConfig) *chi.Mux {
 	r := chi.NewRouter()
 
 	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
+	// 信頼するプロキシからのリクエストに限り、ヘッダーから接続元 IP を取り出す
+	r.Use(realIP(cfg.TrustedProxies))
+	// 呼び出し元のトレースを traceparent ヘッダーから引き継ぐ
+	r.Use(traceRequests)
+	// 監査ログに記録するため、REST と Connect の両方でリクエスト ID と接続元をサービス層へ渡す
+	r.Use(requestInfo)
+	// 以降のログにリクエスト ID・メソッド・ルート（認証後はユーザー ID も）を付与する
+	r.Use(logContext)
+	r.Use(metricsMW.Handle)
+	// アクセスログはクエリ文字列の個人情報を出力しないよう、URL ではなくルートで記録する
+	r.Use(accessLog(h.logger))
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(60 * time.Second))
+	// 一括登録は行ごとにパスワードをハッシュ化するため、他より長いタイムアウトを使う
+	r.Use(requestTimeout(cfg.RequestTimeout, map[string]time.Duration{batchCreateRoute: cfg.BatchCreateTimeout}))
 
 	r.Get("/healthz", h.HealthCheck)
 	r.Get("/readyz", h.ReadinessCheck)
 
 	r.Route("/api/v1", func(r chi.Router) {
+		// 一括登録・エクスポート（/users 配下のユーザー ID と衝突しないようカスタムメソッド形式にする）
+		r.Group(func(r chi.Router) {
+			r.Use(authMW.Authenticate)
+
+			// 再送しても作成済みの行は重複として報告されるため、Idempotency-Key は適用しない（本文の上限も異なる）
+			r.With(authMW.RequirePermission(domain.PermissionImportUsers)).Post("/users:batchCreate", h.BatchCreateUsers)
+			r.With(authMW.RequirePermission(domain.PermissionExportUsers)).Get("/users:export", h.ExportUsers)
+		})
 		r.Route("/users", func(r chi.Router) {
-			r.Get("/", h.ListUsers)
-			r.Post("/", h.CreateUser)
-			r.Get("/{userID}", h.GetUserByID)
-			// r.Get("/{email}", h.GetUserByEmail)
-			r.Put("/{userID}", h.UpdateUser)
-			r.Delete("/{userID}", h.DeleteUser)
+			// 認証不要（ユーザー登録・ログイン・メールアドレスの確認）
+			// Idempotency-Key は応答に認証情報を含まない POST にだけ適用する
+			r.With(idemMW.Handle).Post("/", h.CreateUser)
 			r.Post("/authenticate", h.AuthenticateUser)
+			r.Post("/verify-email", h.VerifyEmail)
+			r.With(idemMW.Handle).Post("/verify-email/resend", h.ResendEmailVerification)
+
+			r.Group(func(r chi.Router) {
+				r.Use(authMW.Authenticate)
+
+				r.With(authMW.RequirePermission(domain.PermissionListUsers)).Get("/", h.ListUsers)
+				r.With(authMW.RequirePermission(domain.PermissionListUsers)).Get("/search", h.SearchUsers)
+				// 他人のアカウントの有無を確認できないよう、検索前に参照権限を確認する
+				r.With(authMW.RequirePermission(domain.PermissionReadAnyUser)).Get("/lookup", h.GetUserByEmail)
+
+				r.Route("/{userID}", func(r chi.Router) {
+					r.With(authMW.RequireSelfOrPermission("userID", domain.PermissionReadAnyUser)).Get("/", h.GetUserByID)
+					// 本人であっても自分の監査ログは参照できない
+					r.With(authMW.RequirePermission(domain.PermissionReadAuditLog)).Get("/audit", h.ListUserAuditEvents)
+
+					r.Group(func(r chi.Router) {
+						r.Use(authMW.RequireSelfOrPermission("userID", domain.PermissionWriteAnyUser))
+
+						r.Put("/", h.UpdateUser)
+						r.Patch("/", h.PatchUser)
+						r.Put("/password", h.ChangePassword)
+						r.Delete("/", h.DeleteUser)
+					})
+
+					// ロールの付与・剥奪は本人であっても権限が必要
+					r.Group(func(r chi.Router) {
+						r.Use(authMW.RequirePermission(domain.PermissionManageRoles))
+
+						r.With(idemMW.Handle).Post("/roles", h.GrantRole)
+						r.Delete("/roles/{role}", h.RevokeRole)
+					})
+
+					r.With(authMW.RequirePermission(domain.PermissionUnlockUsers), idemMW.Handle).Post("/unlock", h.UnlockUser)
+					// 論理削除されたユーザー本人はログインできないため、復元は権限を持つユーザーのみ
+					r.With(authMW.RequirePermission(domain.PermissionWriteAnyUser), idemMW.Handle).Post("/restore", h.RestoreUser)
+				})
+			})
+		})
+		r.Route("/auth", func(r chi.Router) {
+			r.Post("/refresh", h.RefreshToken)
+			r.Post("/logout", h.Logout)
+			r.With(idemMW.Handle).Post("/password-reset", h.RequestPasswordReset)
+			r.Post("/password-reset/confirm", h.ConfirmPasswordReset)
 		})
 	})
 
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// batchCreateRoute is the route of bulk imports, which hash a password per row and outlast other requests
+const batchCreateRoute = "/api/v1/users:batchCreate"
+
+// requestTimeout is middleware.Timeout with the timeout chosen by route.
+// A route-level middleware cannot lengthen the deadline of a top-level one, so the route is looked up ahead like logContext.
+func requestTimeout(timeout time.Duration, routes map[string]time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		handlers := make(map[string]http.Handler, len(routes))
+		for route, d := range routes {
+			handlers[route] = middleware.Timeout(d)(next)
+		}
+		fallback := middleware.Timeout(timeout)(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if h, ok := handlers[findRoute(r)]; ok {
+				h.ServeHTTP(w, r)
+				return
+			}
+			fallback.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/metrics"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	tests := []struct {
+		name            string
+		path            string
+		expectedTimeout time.Duration
+	}{
+		{
+			name:            "成功: 一括登録は専用のタイムアウト",
+			path:            batchCreateRoute,
+			expectedTimeout: 5 * time.Minute,
+		},
+		{
+			name:            "成功: その他のルートは既定のタイムアウト",
+			path:            "/api/v1/users/",
+			expectedTimeout: time.Minute,
+		},
+		{
+			name:            "成功: 存在しないルートも既定のタイムアウト",
+			path:            "/no/such/path",
+			expectedTimeout: time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deadline time.Time
+			record := func(w http.ResponseWriter, r *http.Request) {
+				var ok bool
+				deadline, ok = r.Context().Deadline()
+				require.True(t, ok)
+			}
+
+			r := chi.NewRouter()
+			r.Use(requestTimeout(time.Minute, map[string]time.Duration{batchCreateRoute: 5 * time.Minute}))
+			r.Post(batchCreateRoute, record)
+			r.Post("/api/v1/users/", record)
+			r.NotFound(record)
+
+			start := time.Now()
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, tt.path, nil))
+
+			assert.WithinDuration(t, start.Add(tt.expectedTimeout), deadline, 5*time.Second)
+		})
+	}
+}
+
+func TestNewRouter_BatchCreateRoute(t *testing.T) {
+	// 専用のタイムアウトはルートのパターンで選ぶため、ルーターのパターンと一致している必要がある
+	l := logger.NewNop()
+	idemMW := NewIdempotencyMiddleware(new(repository.MockIdempotencyKeyRepository), DefaultIdempotencyConfig([]byte("test-secret")), l)
+	router := NewRouter(NewUserHandler(new(MockUserService), l), NewAuthMiddleware(nil, l), idemMW, NewMetricsMiddleware(metrics.New(nil)), DefaultRouterConfig())
+
+	assert.Equal(t, batchCreateRoute, router.Find(chi.NewRouteContext(), http.MethodPost, "/api/v1/users:batchCreate"))
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	userv1 "github.com/lot-koichi/sre-skill-up-project/services/user/gen/user/v1"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/auth"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/service"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newTestSpanRecorder installs a tracer provider that keeps finished spans in memory until the test ends
+func newTestSpanRecorder(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	previous := otel.GetTracerProvider()
+	tracing.Install(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return exporter
+}
+
+// spanAttribute returns the value of the attribute of the span, or an invalid value if it is not set
+func spanAttribute(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestTraceRequests(t *testing.T) {
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		traceparent   string
+		expectedName  string
+		expectedCode  int
+		expectedError bool
+	}{
+		{
+			name:         "成功: 呼び出し元のトレースを引き継ぎ、ルートのパターンで命名",
+			method:       http.MethodGet,
+			path:         "/api/v1/users/123e4567-e89b-12d3-a456-426614174000",
+			traceparent:  traceparent,
+			expectedName: "GET /api/v1/users/{userID}",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:          "成功: パニックはエラーのスパン",
+			method:        http.MethodDelete,
+			path:          "/api/v1/users/123e4567-e89b-12d3-a456-426614174000",
+			expectedName:  "DELETE /api/v1/users/{userID}",
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: true,
+		},
+		{
+			name:         "成功: 4xx はエラーにしない",
+			method:       http.MethodGet,
+			path:         "/no/such/path",
+			expectedName: "GET " + unmatchedRoute,
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := newTestSpanRecorder(t)
+
+			r := chi.NewRouter()
+			r.Use(traceRequests)
+			r.Use(middleware.Recoverer)
+			r.Get("/api/v1/users/{userID}", func(w http.ResponseWriter, r *http.Request) {
+				// ハンドラーにはサーバースパンが渡る
+				assert.True(t, trace.SpanContextFromContext(r.Context()).IsValid())
+			})
+			r.Delete("/api/v1/users/{userID}", func(w http.ResponseWriter, r *http.Request) { panic("boom") })
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.traceparent != "" {
+				req.Header.Set("traceparent", tt.traceparent)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			span := spans[0]
+			assert.Equal(t, tt.expectedName, span.Name)
+			assert.Equal(t, trace.SpanKindServer, span.SpanKind)
+			assert.Equal(t, int64(tt.expectedCode), spanAttribute(span, "http.response.status_code").AsInt64())
+			if tt.traceparent != "" {
+				assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext.TraceID().String())
+				assert.Equal(t, "00f067aa0ba902b7", span.Parent.SpanID().String())
+			}
+			if tt.expectedError {
+				assert.Equal(t, codes.Error, span.Status.Code)
+			} else {
+				assert.Equal(t, codes.Unset, span.Status.Code)
+			}
+		})
+	}
+}
+
+func TestTracingInterceptor(t *testing.T) {
+	exporter := newTestSpanRecorder(t)
+	userID := uuid.New()
+	mockService := new(MockUserService)
+	mockService.On("DeleteUser", mock.Anything, service.DeleteUserRequest{ID: userID}).Return(domain.ErrUserNotFound)
+	client := newConnectTestClient(t, mockService, &auth.Principal{UserID: userID})
+
+	_, err := client.DeleteUser(context.Background(), connect.NewRequest(&userv1.DeleteUserRequest{Id: userID.String()}))
+
+	require.Error(t, err)
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "user.v1.UserService/DeleteUser", spans[0].Name)
+	assert.Equal(t, "DeleteUser", spanAttribute(spans[0], "rpc.method").AsString())
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+}
@@ -0,0 +1,367 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/render"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/service"
+	"go.uber.org/zap"
+)
+
+const (
+	ndjsonContentType = "application/x-ndjson"
+	csvContentType    = "text/csv"
+	// maxBatchBodyBytes bounds the body of a bulk import; the number of rows is limited by the service
+	maxBatchBodyBytes = 64 << 20
+	// maxNDJSONLineBytes bounds a single NDJSON row
+	maxNDJSONLineBytes = 64 << 10
+	// exportFlushEvery is the number of exported users sent to the client at a time
+	exportFlushEvery = 100
+)
+
+// csvUserColumns are the columns of an imported CSV file; the header row may list them in any order
+var csvUserColumns = []string{"email", "name", "password"}
+
+// csvExportColumns are the columns of an exported CSV file
+var csvExportColumns = []string{"id", "email", "name", "roles", "created_at", "updated_at"}
+
+// BatchCreateUsers handles POST /users:batchCreate. The body is a JSON array, NDJSON or CSV depending on Content-Type;
+// chunk_size commits every chunk_size rows separately instead of creating all rows in a single transaction.
+func (h *UserHandler) BatchCreateUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	chunkSize := 0
+	if v := r.URL.Query().Get("chunk_size"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			h.renderError(w, r, http.StatusBadRequest, "Invalid chunk_size")
+			return
+		}
+		chunkSize = n
+	}
+
+	var decode func(io.Reader) ([]service.CreateUserRequest, error)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/json":
+		decode = decodeJSONUsers
+	case ndjsonContentType, "application/ndjson":
+		decode = decodeNDJSONUsers
+	case csvContentType:
+		decode = decodeCSVUsers
+	default:
+		h.renderError(w, r, http.StatusUnsupportedMediaType, "Content-Type must be application/json, "+ndjsonContentType+" or "+csvContentType)
+		return
+	}
+
+	users, err := decode(http.MaxBytesReader(w, r.Body, maxBatchBodyBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.renderError(w, r, http.StatusRequestEntityTooLarge, "Request body too large")
+			return
+		}
+		h.renderErrorWithDetails(w, r, http.StatusBadRequest, "Invalid request body", map[string]string{"body": err.Error()})
+		return
+	}
+
+	result, err := h.svc.BatchCreateUsers(ctx, service.BatchCreateUsersRequest{
+		Users:     users,
+		ChunkSize: chunkSize,
+	})
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	// 全件作成できた場合は 201、1 件も作成できなかった場合は 422、一部のみの場合は 200
+	switch {
+	case result.Created == len(result.Results):
+		render.Status(r, http.StatusCreated)
+	case result.Created == 0:
+		render.Status(r, http.StatusUnprocessableEntity)
+	default:
+		render.Status(r, http.StatusOK)
+	}
+	render.JSON(w, r, h.toBatchCreateUsersResponse(result))
+}
+
+// decodeJSONUsers reads a JSON array of users one element at a time
+func decodeJSONUsers(body io.Reader) ([]service.CreateUserRequest, error) {
+	dec := json.NewDecoder(body)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New("body must be a JSON array")
+	}
+
+	var users []service.CreateUserRequest
+	for dec.More() {
+		var req CreateUserRequest
+		if err := dec.Decode(&req); err != nil {
+			return nil, fmt.Errorf("row %d: %w", len(users), err)
+		}
+		users = append(users, toServiceCreateUserRequest(req))
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// decodeNDJSONUsers reads one JSON user per line; blank lines are ignored
+func decodeNDJSONUsers(body io.Reader) ([]service.CreateUserRequest, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 4096), maxNDJSONLineBytes)
+
+	var users []service.CreateUserRequest
+	for line := 1; scanner.Scan(); line++ {
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		var req CreateUserRequest
+		if err := json.Unmarshal(raw, &req); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		users = append(users, toServiceCreateUserRequest(req))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// decodeCSVUsers reads a CSV file whose header row names the email, name and password columns
+func decodeCSVUsers(body io.Reader) ([]service.CreateUserRequest, error) {
+	reader := csv.NewReader(body)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("CSV header row is missing")
+		}
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// 表計算ソフトが先頭に付ける BOM を除く
+		name = strings.TrimPrefix(name, "\ufeff")
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range csvUserColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("CSV header has no %q column", name)
+		}
+	}
+
+	var users []service.CreateUserRequest
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return users, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, service.CreateUserRequest{
+			Email:    domain.Email(record[columns["email"]]),
+			Name:     domain.Name(record[columns["name"]]),
+			Password: domain.Password(record[columns["password"]]),
+		})
+	}
+}
+
+func toServiceCreateUserRequest(req CreateUserRequest) service.CreateUserRequest {
+	return service.CreateUserRequest{
+		Email:    req.Email,
+		Name:     req.Name,
+		Password: req.Password,
+	}
+}
+
+func (h *UserHandler) toBatchCreateUsersResponse(result *service.BatchCreateUsersResponse) *BatchCreateUsersResponse {
+	resp := &BatchCreateUsersResponse{
+		Results:  make([]*BatchCreateUserResult, 0, len(result.Results)),
+		Created:  result.Created,
+		Rejected: result.Rejected,
+		Skipped:  result.Skipped,
+	}
+	for _, row := range result.Results {
+		item := &BatchCreateUserResult{
+			Index:  row.Index,
+			Status: string(row.Status),
+			Email:  string(row.Email),
+		}
+		if row.User != nil {
+			item.User = h.toUserResponse(row.User)
+		}
+		if row.Err != nil {
+			_, item.Error = serviceErrorStatus(row.Err)
+		}
+		resp.Results = append(resp.Results, item)
+	}
+	return resp
+}
+
+// ExportUsers handles GET /users:export?format=ndjson|csv, streaming every user that matches the ListUsers filters
+func (h *UserHandler) ExportUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	var export userExportWriter
+	switch format := query.Get("format"); format {
+	case "", "ndjson":
+		export = newNDJSONUserExportWriter(w)
+	case "csv":
+		export = newCSVUserExportWriter(w)
+	default:
+		h.renderError(w, r, http.StatusBadRequest, "Invalid format")
+		return
+	}
+
+	req := service.ExportUsersRequest{
+		EmailPrefix: query.Get("email_prefix"),
+		NamePrefix:  query.Get("name_prefix"),
+	}
+	var err error
+	if req.CreatedFrom, err = parseTimeQuery(query.Get("created_from")); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	if req.CreatedTo, err = parseTimeQuery(query.Get("created_to")); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	// 最初のユーザーを読めるまではエラーを通常の応答で返せるよう、ヘッダーの送信を遅らせる
+	rc := http.NewResponseController(w)
+	started := false
+	start := func() error {
+		if started {
+			return nil
+		}
+		started = true
+		w.Header().Set("Content-Type", export.ContentType())
+		w.Header().Set("Content-Disposition", `attachment; filename="users.`+export.Extension()+`"`)
+		w.WriteHeader(http.StatusOK)
+		return export.Begin()
+	}
+
+	exported := 0
+	err = h.svc.ExportUsers(ctx, req, func(user *service.UserResponse) error {
+		if err := start(); err != nil {
+			return err
+		}
+		if err := export.Write(h.toUserResponse(user)); err != nil {
+			return err
+		}
+		exported++
+		if exported%exportFlushEvery == 0 {
+			return flushExport(export, rc)
+		}
+		return nil
+	})
+	if err == nil {
+		if err = start(); err == nil {
+			err = flushExport(export, rc)
+		}
+	}
+	if err != nil {
+		if !started {
+			h.handleServiceError(w, r, err)
+			return
+		}
+		h.logger.Error(ctx, "User export aborted", zap.Int("exported", exported), zap.Error(err))
+		// 途中までの出力を完全なファイルと誤解されないよう、正常に終了させずに接続を切る
+		panic(http.ErrAbortHandler)
+	}
+}
+
+func flushExport(export userExportWriter, rc *http.ResponseController) error {
+	if err := export.Flush(); err != nil {
+		return err
+	}
+	if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// userExportWriter encodes exported users in one file format
+type userExportWriter interface {
+	ContentType() string
+	Extension() string
+	// Begin writes what comes before the first user, such as a header row
+	Begin() error
+	Write(user *UserResponse) error
+	// Flush sends the buffered users to the underlying writer
+	Flush() error
+}
+
+type ndjsonUserExportWriter struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+func newNDJSONUserExportWriter(w io.Writer) *ndjsonUserExportWriter {
+	buf := bufio.NewWriter(w)
+	return &ndjsonUserExportWriter{buf: buf, enc: json.NewEncoder(buf)}
+}
+
+func (e *ndjsonUserExportWriter) ContentType() string { return ndjsonContentType }
+func (e *ndjsonUserExportWriter) Extension() string   { return "ndjson" }
+func (e *ndjsonUserExportWriter) Begin() error        { return nil }
+
+func (e *ndjsonUserExportWriter) Write(user *UserResponse) error {
+	// Encode は 1 件ごとに改行を付ける
+	return e.enc.Encode(user)
+}
+
+func (e *ndjsonUserExportWriter) Flush() error {
+	return e.buf.Flush()
+}
+
+type csvUserExportWriter struct {
+	csv *csv.Writer
+}
+
+func newCSVUserExportWriter(w io.Writer) *csvUserExportWriter {
+	return &csvUserExportWriter{csv: csv.NewWriter(w)}
+}
+
+func (e *csvUserExportWriter) ContentType() string { return csvContentType }
+func (e *csvUserExportWriter) Extension() string   { return "csv" }
+
+func (e *csvUserExportWriter) Begin() error {
+	return e.csv.Write(csvExportColumns)
+}
+
+func (e *csvUserExportWriter) Write(user *UserResponse) error {
+	return e.csv.Write([]string{
+		user.ID.String(),
+		user.Email,
+		user.Name,
+		strings.Join(user.Roles, ";"),
+		user.CreatedAt,
+		user.UpdatedAt,
+	})
+}
+
+func (e *csvUserExportWriter) Flush() error {
+	e.csv.Flush()
+	return e.csv.Error()
+}
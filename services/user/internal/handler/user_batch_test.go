@@ -0,0 +1,261 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUserHandler_BatchCreateUsers(t *testing.T) {
+	// 2 行のリクエストに対する結果を作る
+	resultOf := func(statuses ...service.BatchRowStatus) *service.BatchCreateUsersResponse {
+		resp := &service.BatchCreateUsersResponse{}
+		for i, status := range statuses {
+			row := &service.BatchCreateUserResult{Index: i, Status: status}
+			switch status {
+			case service.BatchRowCreated:
+				row.User = &service.UserResponse{ID: uuid.New()}
+				resp.Created++
+			case service.BatchRowRejected:
+				row.Err = domain.ErrUserAlreadyExists
+				resp.Rejected++
+			}
+			resp.Results = append(resp.Results, row)
+		}
+		return resp
+	}
+	twoUsers := mock.MatchedBy(func(req service.BatchCreateUsersRequest) bool {
+		return len(req.Users) == 2 &&
+			req.Users[0].Email == "a@example.com" && req.Users[0].Name == "Alice" && req.Users[0].Password == "Password123" &&
+			req.Users[1].Email == "b@example.com"
+	})
+
+	tests := []struct {
+		name           string
+		contentType    string
+		query          string
+		body           string
+		mockSetup      func(*MockUserService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "成功: JSON配列で全件作成",
+			contentType: "application/json",
+			body:        `[{"email":"a@example.com","name":"Alice","password":"Password123"},{"email":"b@example.com","name":"Bob","password":"Password123"}]`,
+			mockSetup: func(m *MockUserService) {
+				m.On("BatchCreateUsers", mock.Anything, twoUsers).Return(resultOf(service.BatchRowCreated, service.BatchRowCreated), nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:        "成功: NDJSONでチャンク指定",
+			contentType: "application/x-ndjson",
+			query:       "?chunk_size=1",
+			body:        "{\"email\":\"a@example.com\",\"name\":\"Alice\",\"password\":\"Password123\"}\n\n{\"email\":\"b@example.com\",\"name\":\"Bob\",\"password\":\"Password123\"}\n",
+			mockSetup: func(m *MockUserService) {
+				m.On("BatchCreateUsers", mock.Anything, mock.MatchedBy(func(req service.BatchCreateUsersRequest) bool {
+					return len(req.Users) == 2 && req.ChunkSize == 1
+				})).Return(resultOf(service.BatchRowCreated, service.BatchRowRejected), nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"error":"User already exists"`,
+		},
+		{
+			name:        "成功: CSVは列の順序を問わない",
+			contentType: "text/csv; charset=utf-8",
+			body:        "\ufeffPassword,email,name\nPassword123,a@example.com,Alice\nPassword123,b@example.com,Bob\n",
+			mockSetup: func(m *MockUserService) {
+				m.On("BatchCreateUsers", mock.Anything, twoUsers).Return(resultOf(service.BatchRowCreated, service.BatchRowCreated), nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:        "失敗: 1件も作成できなければ422",
+			contentType: "application/json",
+			body:        `[{"email":"a@example.com","name":"Alice","password":"Password123"},{"email":"b@example.com","name":"Bob","password":"Password123"}]`,
+			mockSetup: func(m *MockUserService) {
+				m.On("BatchCreateUsers", mock.Anything, mock.Anything).Return(resultOf(service.BatchRowRejected, service.BatchRowSkipped), nil)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "失敗: CSVの必須列がない",
+			contentType:    "text/csv",
+			body:           "email,name\na@example.com,Alice\n",
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "失敗: JSON配列でない",
+			contentType:    "application/json",
+			body:           `{"email":"a@example.com"}`,
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "失敗: 未対応のContent-Type",
+			contentType:    "application/xml",
+			body:           `<users/>`,
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:           "失敗: 不正なchunk_size",
+			contentType:    "application/json",
+			query:          "?chunk_size=-1",
+			body:           `[]`,
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "失敗: 行数が上限を超える",
+			contentType: "application/json",
+			body:        `[{"email":"a@example.com","name":"Alice","password":"Password123"}]`,
+			mockSetup: func(m *MockUserService) {
+				m.On("BatchCreateUsers", mock.Anything, mock.Anything).Return(nil, domain.ErrBatchTooLarge)
+			},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(MockUserService)
+			tt.mockSetup(mockSvc)
+			handler := NewUserHandler(mockSvc, logger.NewNop())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/users:batchCreate"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+
+			handler.BatchCreateUsers(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.Contains(t, rec.Body.String(), tt.expectedBody)
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_ExportUsers(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	users := []*service.UserResponse{
+		{
+			ID:        uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+			Email:     "a@example.com",
+			Name:      "Alice",
+			Roles:     []domain.Role{domain.RoleAdmin, domain.RoleSupport},
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		},
+	}
+
+	tests := []struct {
+		name                string
+		query               string
+		mockSetup           func(*MockUserService)
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:  "成功: NDJSON",
+			query: "?email_prefix=a",
+			mockSetup: func(m *MockUserService) {
+				m.On("ExportUsers", mock.Anything, service.ExportUsersRequest{EmailPrefix: "a"}).Return(users, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/x-ndjson",
+			expectedBody:        `"email":"a@example.com"`,
+		},
+		{
+			name:  "成功: CSV",
+			query: "?format=csv",
+			mockSetup: func(m *MockUserService) {
+				m.On("ExportUsers", mock.Anything, service.ExportUsersRequest{}).Return(users, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv",
+			expectedBody: "id,email,name,roles,created_at,updated_at\n" +
+				"123e4567-e89b-12d3-a456-426614174000,a@example.com,Alice,admin;support,2024-01-01T00:00:00Z,2024-01-01T00:00:00Z\n",
+		},
+		{
+			name:  "成功: 該当なしでもCSVのヘッダーを返す",
+			query: "?format=csv",
+			mockSetup: func(m *MockUserService) {
+				m.On("ExportUsers", mock.Anything, mock.Anything).Return([]*service.UserResponse{}, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv",
+			expectedBody:        "id,email,name,roles,created_at,updated_at\n",
+		},
+		{
+			name:           "失敗: 未対応の形式",
+			query:          "?format=xml",
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "失敗: 出力前のエラーは通常の応答",
+			query: "",
+			mockSetup: func(m *MockUserService) {
+				m.On("ExportUsers", mock.Anything, mock.Anything).Return([]*service.UserResponse{}, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(MockUserService)
+			tt.mockSetup(mockSvc)
+			handler := NewUserHandler(mockSvc, logger.NewNop())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/users:export"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			handler.ExportUsers(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedContentType != "" {
+				assert.Equal(t, tt.expectedContentType, rec.Header().Get("Content-Type"))
+			}
+			if tt.expectedBody != "" {
+				if tt.expectedContentType == "text/csv" {
+					assert.Equal(t, tt.expectedBody, rec.Body.String())
+				} else {
+					assert.Contains(t, rec.Body.String(), tt.expectedBody)
+				}
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_ExportUsers_AbortAfterOutput(t *testing.T) {
+	mockSvc := new(MockUserService)
+	mockSvc.On("ExportUsers", mock.Anything, mock.Anything).
+		Return([]*service.UserResponse{{ID: uuid.New(), Email: "a@example.com"}}, errors.New("database error"))
+	handler := NewUserHandler(mockSvc, logger.NewNop())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users:export", nil)
+	rec := httptest.NewRecorder()
+
+	// 出力開始後のエラーは接続を切るため、http.Server が処理する panic になる
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ExportUsers(rec, req)
+	})
+}
@@ -3,12 +3,18 @@ package handler
 import (
 	"encoding/json"
 	"fmt"
+	"mime"
+	"net"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
 	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
 	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/service"
 	"go.uber.org/zap"
@@ -16,10 +22,10 @@ import (
 
 type UserHandler struct {
 	svc    service.UserService
-	logger *zap.Logger
+	logger logger.Logger
 }
 
-func NewUserHandler(svc service.UserService, logger *zap.Logger) *UserHandler {
+func NewUserHandler(svc service.UserService, logger logger.Logger) *UserHandler {
 	return &UserHandler{
 		svc:    svc,
 		logger: logger,
@@ -31,6 +37,7 @@ func toUserResponse(user *domain.User) *UserResponse {
 		ID:        user.ID,
 		Email:     string(user.Email),
 		Name:      string(user.Name),
+		Roles:     toRoleNames(user.Roles),
 		CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
@@ -45,10 +52,10 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// デバッグ用ログ
-	h.logger.Info("CreateUser request",
-		zap.String("email", string(req.Email)),
-		zap.String("name", string(req.Name)),
+	// デバッグ用ログ（個人情報はロガーの設定に従ってマスクされる）
+	h.logger.Debug(ctx, "CreateUser request",
+		logger.Email("email", string(req.Email)),
+		logger.Name("name", string(req.Name)),
 		zap.String("password_length", fmt.Sprintf("%d", len(req.Password))))
 
 	svcReq := service.CreateUserRequest{
@@ -83,21 +90,27 @@ func (h *UserHandler) GetUserByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// PUT の If-Match に指定するバージョン
+	w.Header().Set("ETag", userETag(user.Version))
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, user)
 }
 
+// GetUserByEmail handles GET /users/lookup?email= (the email is matched ignoring case)
 func (h *UserHandler) GetUserByEmail(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	email := chi.URLParam(r, "email")
-	err := domain.ValidateEmail(domain.Email(email))
+	rawEmail, err := emailQueryParam(r.URL.RawQuery)
+	email := domain.NormalizeEmail(domain.Email(rawEmail))
+	if err == nil {
+		err = domain.ValidateEmail(email)
+	}
 	if err != nil {
 		h.renderError(w, r, http.StatusBadRequest, "Invalid email format")
 		return
 	}
 
-	user, err := h.svc.GetUserByEmail(ctx, domain.Email(email))
+	user, err := h.svc.GetUserByEmail(ctx, email)
 	if err != nil {
 		h.handleServiceError(w, r, err)
 		return
@@ -117,6 +130,12 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	version, err := ifMatchVersion(r.Header.Get("If-Match"))
+	if err != nil {
+		h.renderError(w, r, http.StatusPreconditionFailed, "If-Match does not match the current version")
+		return
+	}
+
 	var req UpdateUserRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.renderError(w, r, http.StatusBadRequest, "Invalid request body")
@@ -124,7 +143,8 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	svcReq := service.UpdateUserRequest{
-		ID: userID,
+		ID:      userID,
+		Version: version,
 	}
 
 	if req.Email != nil {
@@ -144,6 +164,92 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, map[string]string{"message": "User updated successfully"})
 }
 
+// mergePatchContentType is the media type of a JSON Merge Patch (RFC 7396) document
+const mergePatchContentType = "application/merge-patch+json"
+
+// PatchUser handles PATCH /users/{id}; only the members present in the merge patch are changed
+func (h *UserHandler) PatchUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userIDStr := chi.URLParam(r, "userID")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != mergePatchContentType {
+		h.renderError(w, r, http.StatusUnsupportedMediaType, "Content-Type must be "+mergePatchContentType)
+		return
+	}
+
+	version, err := ifMatchVersion(r.Header.Get("If-Match"))
+	if err != nil {
+		h.renderError(w, r, http.StatusPreconditionFailed, "If-Match does not match the current version")
+		return
+	}
+
+	// メンバーの有無と null を区別するため、値は後から個別に読む
+	var doc map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&doc); err != nil || doc == nil {
+		h.renderError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	patch, validationErrors := parseUserMergePatch(doc)
+	if len(validationErrors) > 0 {
+		h.renderValidationError(w, r, validationErrors)
+		return
+	}
+
+	user, err := h.svc.PatchUser(ctx, service.PatchUserRequest{
+		ID:      userID,
+		Patch:   patch,
+		Version: version,
+	})
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("ETag", userETag(user.Version))
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, user)
+}
+
+// parseUserMergePatch reads the members of a merge patch document. A null member removes the field in
+// merge patch semantics, which email and name do not allow; unknown and read-only members are rejected too.
+func parseUserMergePatch(doc map[string]json.RawMessage) (domain.UserPatch, map[string]string) {
+	var patch domain.UserPatch
+	validationErrors := make(map[string]string)
+	for field, raw := range doc {
+		if field != "email" && field != "name" {
+			validationErrors[field] = "Field cannot be patched"
+			continue
+		}
+		if string(raw) == "null" {
+			validationErrors[field] = "Field is required and cannot be removed"
+			continue
+		}
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			validationErrors[field] = "Field must be a string"
+			continue
+		}
+
+		switch field {
+		case "email":
+			email := domain.Email(value)
+			patch.Email = &email
+		case "name":
+			name := domain.Name(value)
+			patch.Name = &name
+		}
+	}
+	return patch, validationErrors
+}
+
 func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -164,6 +270,27 @@ func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// RestoreUser restores a soft-deleted user
+func (h *UserHandler) RestoreUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userIDStr := chi.URLParam(r, "userID")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+
+	user, err := h.svc.RestoreUser(ctx, service.RestoreUserRequest{ID: userID})
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, user)
+}
+
 func toUserResponses(users []*service.UserResponse) []*UserResponse {
 	return make([]*UserResponse, 0, len(users))
 }
@@ -172,42 +299,186 @@ func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// Parse query parameters
-	limitStr := r.URL.Query().Get("limit")
-	offsetStr := r.URL.Query().Get("offset")
+	limit, offset := parsePaging(r)
 
-	limit := 10 // default
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
-			limit = l
-		}
+	query := r.URL.Query()
+	req := service.ListUsersRequest{
+		Limit:       int32(limit),
+		Offset:      int32(offset),
+		Cursor:      query.Get("cursor"),
+		SortBy:      query.Get("sort_by"),
+		SortOrder:   query.Get("sort_order"),
+		EmailPrefix: query.Get("email_prefix"),
+		NamePrefix:  query.Get("name_prefix"),
 	}
 
-	offset := 0 // default
-	if offsetStr != "" {
-		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
-			offset = o
-		}
+	// 期間の指定が不正な場合は無視せずエラーにする（意図しない全件取得を避ける）
+	var err error
+	if req.CreatedFrom, err = parseTimeQuery(query.Get("created_from")); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+	if req.CreatedTo, err = parseTimeQuery(query.Get("created_to")); err != nil {
+		h.handleServiceError(w, r, err)
+		return
 	}
 
-	req := service.ListUsersRequest{
+	page, err := h.svc.ListUsers(ctx, req)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	resp := ListUsersResponse{
+		Users:               h.toUserResponses(page.Users),
+		TotalCount:          page.TotalCount,
+		TotalCountEstimated: page.TotalCountEstimated,
+		Limit:               limit,
+		Offset:              offset,
+		NextCursor:          page.NextCursor,
+		PrevCursor:          page.PrevCursor,
+	}
+
+	render.JSON(w, r, resp)
+}
+
+// SearchUsers handles user search by partial or fuzzy name/email
+func (h *UserHandler) SearchUsers(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parsePaging(r)
+
+	result, err := h.svc.SearchUsers(r.Context(), service.SearchUsersRequest{
+		Query:  r.URL.Query().Get("q"),
 		Limit:  int32(limit),
 		Offset: int32(offset),
+	})
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
 	}
 
-	users, err := h.svc.ListUsers(ctx, req)
+	hits := make([]*UserSearchHitResponse, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		hits = append(hits, &UserSearchHitResponse{User: h.toUserResponse(hit.User), Score: hit.Score})
+	}
+
+	render.JSON(w, r, SearchUsersResponse{
+		Hits:       hits,
+		TotalCount: result.TotalCount,
+		Limit:      limit,
+		Offset:     offset,
+	})
+}
+
+// ListUserAuditEvents handles GET /users/{id}/audit?limit=&offset=, newest first
+func (h *UserHandler) ListUserAuditEvents(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+	limit, offset := parsePaging(r)
+
+	result, err := h.svc.ListUserAuditEvents(r.Context(), service.ListUserAuditEventsRequest{
+		UserID: userID,
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
 	if err != nil {
 		h.handleServiceError(w, r, err)
 		return
 	}
 
-	resp := ListUsersResponse{
-		Users:      h.toUserResponses(users),
-		TotalCount: len(users),
+	events := make([]*AuditEventResponse, 0, len(result.Events))
+	for _, event := range result.Events {
+		events = append(events, &AuditEventResponse{
+			ID:         event.ID,
+			Action:     string(event.Action),
+			Outcome:    string(event.Outcome),
+			ActorID:    event.ActorID,
+			UserID:     event.UserID,
+			RequestID:  event.RequestID,
+			ClientIP:   event.ClientIP,
+			Changes:    event.Changes,
+			Error:      event.Error,
+			OccurredAt: event.OccurredAt.Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+
+	render.JSON(w, r, ListAuditEventsResponse{
+		Events:     events,
+		TotalCount: result.TotalCount,
 		Limit:      limit,
 		Offset:     offset,
+	})
+}
+
+// userETag formats the user version as a strong entity tag
+func userETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// ifMatchVersion reads the version from an If-Match header; an absent header or "*" returns 0 (no check).
+// Weak, listed or malformed tags can never match a single version and are returned as an error.
+func ifMatchVersion(header string) (int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+	tag, err := strconv.Unquote(header)
+	if err != nil || !strings.HasPrefix(header, `"`) {
+		return 0, fmt.Errorf("invalid If-Match header: %q", header)
+	}
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("invalid If-Match header: %q", header)
 	}
+	return version, nil
+}
 
-	render.JSON(w, r, resp)
+// emailQueryParam reads the email query parameter; unlike url.ParseQuery it keeps a literal "+" (as in alice+tag@example.com)
+func emailQueryParam(rawQuery string) (string, error) {
+	for pair := range strings.SplitSeq(rawQuery, "&") {
+		key, value, _ := strings.Cut(pair, "=")
+		if key != "email" {
+			continue
+		}
+		email, err := url.PathUnescape(value)
+		if err != nil {
+			return "", err
+		}
+		return email, nil
+	}
+	return "", nil
+}
+
+// parsePaging reads limit (default 10, at most 100) and offset (default 0); invalid values fall back to the defaults
+func parsePaging(r *http.Request) (limit, offset int) {
+	limit = 10 // default
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	offset = 0 // default
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+	return limit, offset
+}
+
+// parseTimeQuery parses an RFC 3339 query parameter; an empty value is nil
+func parseTimeQuery(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time %q: %w", value, domain.ErrInvalidFilter)
+	}
+	return &t, nil
 }
 
 // AuthenticateUser handles user authentication
@@ -228,14 +499,266 @@ func (h *UserHandler) AuthenticateUser(w http.ResponseWriter, r *http.Request) {
 	authReq := service.AuthenticateUserRequest{
 		Email:    req.Email,
 		Password: req.Password,
+		ClientIP: remoteHost(r.RemoteAddr),
 	}
 
-	if err := h.svc.AuthenticateUser(ctx, authReq); err != nil {
+	tokens, err := h.svc.AuthenticateUser(ctx, authReq)
+	if err != nil {
 		h.handleServiceError(w, r, err)
 		return
 	}
 
-	render.JSON(w, r, map[string]string{"message": "Authentication successful"})
+	render.JSON(w, r, toAuthTokensResponse(tokens))
+}
+
+// RefreshToken exchanges a refresh token for a new token pair
+func (h *UserHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.RefreshToken == "" {
+		h.renderError(w, r, http.StatusBadRequest, "Refresh token is required")
+		return
+	}
+
+	tokens, err := h.svc.RefreshToken(ctx, service.RefreshTokenRequest{RefreshToken: req.RefreshToken})
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, toAuthTokensResponse(tokens))
+}
+
+// Logout revokes a refresh token
+func (h *UserHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req LogoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.RefreshToken == "" {
+		h.renderError(w, r, http.StatusBadRequest, "Refresh token is required")
+		return
+	}
+
+	if err := h.svc.Logout(ctx, service.LogoutRequest{RefreshToken: req.RefreshToken}); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ChangePassword changes the password of a user and revokes their sessions
+func (h *UserHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userIDStr := chi.URLParam(r, "userID")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.CurrentPassword == "" || req.NewPassword == "" {
+		h.renderError(w, r, http.StatusBadRequest, "Current and new password are required")
+		return
+	}
+
+	svcReq := service.ChangePasswordRequest{
+		ID:              userID,
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+	}
+
+	if err := h.svc.ChangePassword(ctx, svcReq); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RequestPasswordReset sends a password reset token; it responds 202 whether or not the email is registered
+func (h *UserHandler) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req RequestPasswordResetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.Email == "" {
+		h.renderError(w, r, http.StatusBadRequest, "Email is required")
+		return
+	}
+
+	if err := h.svc.RequestPasswordReset(ctx, service.RequestPasswordResetRequest{Email: req.Email}); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// ConfirmPasswordReset sets a new password using a password reset token
+func (h *UserHandler) ConfirmPasswordReset(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req ConfirmPasswordResetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.Token == "" || req.NewPassword == "" {
+		h.renderError(w, r, http.StatusBadRequest, "Token and new password are required")
+		return
+	}
+
+	svcReq := service.ConfirmPasswordResetRequest{
+		Token:       req.Token,
+		NewPassword: req.NewPassword,
+	}
+
+	if err := h.svc.ConfirmPasswordReset(ctx, svcReq); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// VerifyEmail marks the email address as verified using an email verification token
+func (h *UserHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req VerifyEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.Token == "" {
+		h.renderError(w, r, http.StatusBadRequest, "Token is required")
+		return
+	}
+
+	if err := h.svc.VerifyEmail(ctx, service.VerifyEmailRequest{Token: req.Token}); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ResendEmailVerification sends a new verification token; it responds 202 whether or not a mail was sent
+func (h *UserHandler) ResendEmailVerification(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req ResendEmailVerificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.Email == "" {
+		h.renderError(w, r, http.StatusBadRequest, "Email is required")
+		return
+	}
+
+	if err := h.svc.ResendEmailVerification(ctx, service.ResendEmailVerificationRequest{Email: req.Email}); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// GrantRole grants a role to a user
+func (h *UserHandler) GrantRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userIDStr := chi.URLParam(r, "userID")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+
+	var req GrantRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.Role == "" {
+		h.renderError(w, r, http.StatusBadRequest, "Role is required")
+		return
+	}
+
+	if err := h.svc.GrantRole(ctx, service.GrantRoleRequest{UserID: userID, Role: req.Role}); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeRole revokes a role from a user
+func (h *UserHandler) RevokeRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userIDStr := chi.URLParam(r, "userID")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+
+	role := domain.Role(chi.URLParam(r, "role"))
+
+	if err := h.svc.RevokeRole(ctx, service.RevokeRoleRequest{UserID: userID, Role: role}); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UnlockUser clears the login lockout of a user
+func (h *UserHandler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userIDStr := chi.URLParam(r, "userID")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+
+	if err := h.svc.UnlockUser(ctx, service.UnlockUserRequest{ID: userID}); err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
 }
 
 // HealthCheck handles health check
@@ -254,13 +777,37 @@ func (h *UserHandler) ReadinessCheck(w http.ResponseWriter, r *http.Request) {
 // Helper methods
 
 func (h *UserHandler) toUserResponse(user *service.UserResponse) *UserResponse {
-	return &UserResponse{
+	resp := &UserResponse{
 		ID:        user.ID,
 		Email:     string(user.Email),
 		Name:      string(user.Name),
+		Roles:     toRoleNames(user.Roles),
 		CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
+	if user.EmailVerifiedAt != nil {
+		verifiedAt := user.EmailVerifiedAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.EmailVerifiedAt = &verifiedAt
+	}
+	return resp
+}
+
+func toRoleNames(roles []domain.Role) []string {
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		names = append(names, string(role))
+	}
+	return names
+}
+
+func toAuthTokensResponse(tokens *service.AuthTokens) *AuthTokensResponse {
+	return &AuthTokensResponse{
+		AccessToken:           tokens.AccessToken,
+		TokenType:             "Bearer",
+		ExpiresIn:             int64(time.Until(tokens.AccessTokenExpiresAt).Seconds()),
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
 }
 
 func (h *UserHandler) toUserResponses(users []*service.UserResponse) []*UserResponse {
@@ -270,3 +817,12 @@ func (h *UserHandler) toUserResponses(users []*service.UserResponse) []*UserResp
 	}
 	return responses
 }
+
+// remoteHost returns the host part of a remote address (RemoteAddr is already rewritten by realIP)
+func remoteHost(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
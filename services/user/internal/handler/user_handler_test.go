@@ -12,13 +12,21 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
 	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
 	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
+// createTestLogger creates a test logger
+func createTestLogger() logger.Logger {
+	zapLogger, _ := zap.NewDevelopment()
+	return logger.New(zapLogger, logger.DefaultRedactionConfig())
+}
+
 // MockUserService is a mock implementation of UserService
 type MockUserService struct {
 	mock.Mock
@@ -32,6 +40,27 @@ func (m *MockUserService) CreateUser(ctx context.Context, req service.CreateUser
 	return args.Get(0).(*service.UserResponse), args.Error(1)
 }
 
+func (m *MockUserService) BatchCreateUsers(ctx context.Context, req service.BatchCreateUsersRequest) (*service.BatchCreateUsersResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.BatchCreateUsersResponse), args.Error(1)
+}
+
+// ExportUsers passes the mocked users to fn, then returns the mocked error
+func (m *MockUserService) ExportUsers(ctx context.Context, req service.ExportUsersRequest, fn func(*service.UserResponse) error) error {
+	args := m.Called(ctx, req)
+	if users, ok := args.Get(0).([]*service.UserResponse); ok {
+		for _, user := range users {
+			if err := fn(user); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
 func (m *MockUserService) GetUserByID(ctx context.Context, id uuid.UUID) (*service.UserResponse, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -53,26 +82,114 @@ func (m *MockUserService) UpdateUser(ctx context.Context, req service.UpdateUser
 	return args.Error(0)
 }
 
+func (m *MockUserService) PatchUser(ctx context.Context, req service.PatchUserRequest) (*service.UserResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.UserResponse), args.Error(1)
+}
+
 func (m *MockUserService) DeleteUser(ctx context.Context, req service.DeleteUserRequest) error {
 	args := m.Called(ctx, req)
 	return args.Error(0)
 }
 
-func (m *MockUserService) ListUsers(ctx context.Context, req service.ListUsersRequest) ([]*service.UserResponse, error) {
+func (m *MockUserService) ListUsers(ctx context.Context, req service.ListUsersRequest) (*service.ListUsersResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ListUsersResponse), args.Error(1)
+}
+
+func (m *MockUserService) SearchUsers(ctx context.Context, req service.SearchUsersRequest) (*service.SearchUsersResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.SearchUsersResponse), args.Error(1)
+}
+
+func (m *MockUserService) AuthenticateUser(ctx context.Context, req service.AuthenticateUserRequest) (*service.AuthTokens, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.AuthTokens), args.Error(1)
+}
+
+func (m *MockUserService) RefreshToken(ctx context.Context, req service.RefreshTokenRequest) (*service.AuthTokens, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.AuthTokens), args.Error(1)
+}
+
+func (m *MockUserService) Logout(ctx context.Context, req service.LogoutRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockUserService) ChangePassword(ctx context.Context, req service.ChangePasswordRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockUserService) RequestPasswordReset(ctx context.Context, req service.RequestPasswordResetRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockUserService) ConfirmPasswordReset(ctx context.Context, req service.ConfirmPasswordResetRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockUserService) VerifyEmail(ctx context.Context, req service.VerifyEmailRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockUserService) ResendEmailVerification(ctx context.Context, req service.ResendEmailVerificationRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockUserService) GrantRole(ctx context.Context, req service.GrantRoleRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockUserService) RevokeRole(ctx context.Context, req service.RevokeRoleRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockUserService) RestoreUser(ctx context.Context, req service.RestoreUserRequest) (*service.UserResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.UserResponse), args.Error(1)
+}
+
+func (m *MockUserService) ListUserAuditEvents(ctx context.Context, req service.ListUserAuditEventsRequest) (*service.ListUserAuditEventsResponse, error) {
 	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*service.UserResponse), args.Error(1)
+	return args.Get(0).(*service.ListUserAuditEventsResponse), args.Error(1)
 }
 
-func (m *MockUserService) AuthenticateUser(ctx context.Context, req service.AuthenticateUserRequest) error {
+func (m *MockUserService) UnlockUser(ctx context.Context, req service.UnlockUserRequest) error {
 	args := m.Called(ctx, req)
 	return args.Error(0)
 }
 
 func TestUserHandler_CreateUser(t *testing.T) {
-	logger, _ := zap.NewDevelopment()
+	logger := createTestLogger()
 
 	tests := []struct {
 		name           string
@@ -182,7 +299,7 @@ func TestUserHandler_CreateUser(t *testing.T) {
 }
 
 func TestUserHandler_GetUserByID(t *testing.T) {
-	logger, _ := zap.NewDevelopment()
+	logger := createTestLogger()
 	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
 
 	tests := []struct {
@@ -190,6 +307,7 @@ func TestUserHandler_GetUserByID(t *testing.T) {
 		userID         string
 		mockSetup      func(*MockUserService)
 		expectedStatus int
+		expectedETag   string
 	}{
 		{
 			name:   "成功: ユーザー取得",
@@ -202,9 +320,11 @@ func TestUserHandler_GetUserByID(t *testing.T) {
 						Name:      "Test User",
 						CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 						UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+						Version:   4,
 					}, nil)
 			},
 			expectedStatus: http.StatusOK,
+			expectedETag:   `"4"`,
 		},
 		{
 			name:           "失敗: 無効なUUID",
@@ -244,6 +364,131 @@ func TestUserHandler_GetUserByID(t *testing.T) {
 			handler.GetUserByID(rec, req)
 
 			// Assert
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedETag, rec.Header().Get("ETag"))
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_GetUserByID_EmailVerifiedAt(t *testing.T) {
+	logger := createTestLogger()
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	verifiedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name               string
+		emailVerifiedAt    *time.Time
+		expectedVerifiedAt any
+	}{
+		{
+			name:               "成功: 確認済みなら確認日時を返す",
+			emailVerifiedAt:    &verifiedAt,
+			expectedVerifiedAt: "2024-01-02T03:04:05Z",
+		},
+		{
+			name:               "成功: 未確認なら確認日時を省略する",
+			emailVerifiedAt:    nil,
+			expectedVerifiedAt: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockSvc := new(MockUserService)
+			mockSvc.On("GetUserByID", mock.Anything, userID).
+				Return(&service.UserResponse{
+					ID:              userID,
+					Email:           "test@example.com",
+					Name:            "Test User",
+					CreatedAt:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					UpdatedAt:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					EmailVerifiedAt: tt.emailVerifiedAt,
+				}, nil)
+
+			handler := NewUserHandler(mockSvc, logger)
+
+			req := httptest.NewRequest("GET", "/api/v1/users/"+userID.String(), nil)
+			rec := httptest.NewRecorder()
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("userID", userID.String())
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			// Execute
+			handler.GetUserByID(rec, req)
+
+			// Assert
+			require.Equal(t, http.StatusOK, rec.Code)
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.expectedVerifiedAt, body["email_verified_at"])
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_GetUserByEmail(t *testing.T) {
+	logger := createTestLogger()
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+
+	tests := []struct {
+		name           string
+		rawQuery       string
+		mockSetup      func(*MockUserService)
+		expectedStatus int
+	}{
+		{
+			name:     "成功: URLエンコードされたメールアドレス",
+			rawQuery: "email=Alice%40Example.com",
+			mockSetup: func(m *MockUserService) {
+				m.On("GetUserByEmail", mock.Anything, domain.Email("Alice@example.com")).Return(&service.UserResponse{ID: userID}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:     "成功: エンコードされていない + を保持する",
+			rawQuery: "limit=1&email=alice+tag@example.com",
+			mockSetup: func(m *MockUserService) {
+				m.On("GetUserByEmail", mock.Anything, domain.Email("alice+tag@example.com")).Return(&service.UserResponse{ID: userID}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "失敗: メールアドレスの指定なし",
+			rawQuery:       "",
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "失敗: 不正なエスケープ",
+			rawQuery:       "email=alice%zz@example.com",
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:     "失敗: ユーザーが存在しない",
+			rawQuery: "email=nobody%40example.com",
+			mockSetup: func(m *MockUserService) {
+				m.On("GetUserByEmail", mock.Anything, domain.Email("nobody@example.com")).Return(nil, domain.ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(MockUserService)
+			tt.mockSetup(mockSvc)
+
+			handler := NewUserHandler(mockSvc, logger)
+
+			req := httptest.NewRequest("GET", "/api/v1/users/lookup?"+tt.rawQuery, nil)
+			rec := httptest.NewRecorder()
+
+			handler.GetUserByEmail(rec, req)
+
 			assert.Equal(t, tt.expectedStatus, rec.Code)
 			mockSvc.AssertExpectations(t)
 		})
@@ -251,13 +496,14 @@ func TestUserHandler_GetUserByID(t *testing.T) {
 }
 
 func TestUserHandler_UpdateUser(t *testing.T) {
-	logger, _ := zap.NewDevelopment()
+	logger := createTestLogger()
 	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
 
 	tests := []struct {
 		name           string
 		userID         string
 		requestBody    interface{}
+		ifMatch        string
 		mockSetup      func(*MockUserService)
 		expectedStatus int
 	}{
@@ -307,6 +553,48 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 			},
 			expectedStatus: http.StatusNotFound,
 		},
+		{
+			name:        "成功: If-Match のバージョンを渡す",
+			userID:      userID.String(),
+			requestBody: map[string]interface{}{"name": "Updated Name"},
+			ifMatch:     `"3"`,
+			mockSetup: func(m *MockUserService) {
+				m.On("UpdateUser", mock.Anything, mock.MatchedBy(func(req service.UpdateUserRequest) bool {
+					return req.Version == 3
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "成功: If-Match: * はバージョンを確認しない",
+			userID:      userID.String(),
+			requestBody: map[string]interface{}{"name": "Updated Name"},
+			ifMatch:     "*",
+			mockSetup: func(m *MockUserService) {
+				m.On("UpdateUser", mock.Anything, mock.MatchedBy(func(req service.UpdateUserRequest) bool {
+					return req.Version == 0
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "失敗: バージョン不一致",
+			userID:      userID.String(),
+			requestBody: map[string]interface{}{"name": "Updated Name"},
+			ifMatch:     `"2"`,
+			mockSetup: func(m *MockUserService) {
+				m.On("UpdateUser", mock.Anything, mock.Anything).Return(domain.ErrConcurrentModification)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "失敗: 弱いETag",
+			userID:         userID.String(),
+			requestBody:    map[string]interface{}{"name": "Updated Name"},
+			ifMatch:        `W/"3"`,
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
 	}
 
 	for _, tt := range tests {
@@ -327,6 +615,9 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 
 			req := httptest.NewRequest("PUT", "/api/v1/users/"+tt.userID, bytes.NewReader(body))
 			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
 			rec := httptest.NewRecorder()
 
 			// Set up chi context
@@ -344,8 +635,113 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 	}
 }
 
+func TestUserHandler_PatchUser(t *testing.T) {
+	logger := createTestLogger()
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+
+	tests := []struct {
+		name           string
+		body           string
+		contentType    string
+		ifMatch        string
+		mockSetup      func(*MockUserService)
+		expectedStatus int
+		expectedETag   string
+	}{
+		{
+			name:        "成功: 名前のみ変更",
+			body:        `{"name":"Patched Name"}`,
+			contentType: "application/merge-patch+json",
+			mockSetup: func(m *MockUserService) {
+				m.On("PatchUser", mock.Anything, mock.MatchedBy(func(req service.PatchUserRequest) bool {
+					return req.ID == userID && req.Patch.Email == nil && req.Patch.Name != nil && *req.Patch.Name == "Patched Name"
+				})).Return(&service.UserResponse{ID: userID, Name: "Patched Name", Version: 5}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"5"`,
+		},
+		{
+			name:        "成功: If-Match のバージョンを渡す",
+			body:        `{"email":"patched@example.com"}`,
+			contentType: "application/merge-patch+json; charset=utf-8",
+			ifMatch:     `"4"`,
+			mockSetup: func(m *MockUserService) {
+				m.On("PatchUser", mock.Anything, mock.MatchedBy(func(req service.PatchUserRequest) bool {
+					return req.Version == 4 && req.Patch.Email != nil && *req.Patch.Email == "patched@example.com"
+				})).Return(&service.UserResponse{ID: userID, Version: 5}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"5"`,
+		},
+		{
+			name:           "失敗: merge-patch 以外の Content-Type",
+			body:           `{"name":"Patched Name"}`,
+			contentType:    "application/json",
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:           "失敗: 必須フィールドを null で削除",
+			body:           `{"name":null}`,
+			contentType:    "application/merge-patch+json",
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "失敗: 変更できないフィールド",
+			body:           `{"id":"00000000-0000-0000-0000-000000000000"}`,
+			contentType:    "application/merge-patch+json",
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "失敗: オブジェクト以外のパッチ",
+			body:           `["name"]`,
+			contentType:    "application/merge-patch+json",
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "失敗: バージョン不一致",
+			body:        `{"name":"Patched Name"}`,
+			contentType: "application/merge-patch+json",
+			ifMatch:     `"3"`,
+			mockSetup: func(m *MockUserService) {
+				m.On("PatchUser", mock.Anything, mock.Anything).Return(nil, domain.ErrConcurrentModification)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(MockUserService)
+			tt.mockSetup(mockSvc)
+
+			handler := NewUserHandler(mockSvc, logger)
+
+			req := httptest.NewRequest("PATCH", "/api/v1/users/"+userID.String(), bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("userID", userID.String())
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			handler.PatchUser(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedETag, rec.Header().Get("ETag"))
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
 func TestUserHandler_DeleteUser(t *testing.T) {
-	logger, _ := zap.NewDevelopment()
+	logger := createTestLogger()
 	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
 
 	tests := []struct {
@@ -407,7 +803,7 @@ func TestUserHandler_DeleteUser(t *testing.T) {
 }
 
 func TestUserHandler_ListUsers(t *testing.T) {
-	logger, _ := zap.NewDevelopment()
+	logger := createTestLogger()
 
 	tests := []struct {
 		name           string
@@ -423,7 +819,7 @@ func TestUserHandler_ListUsers(t *testing.T) {
 				m.On("ListUsers", mock.Anything, service.ListUsersRequest{
 					Limit:  10,
 					Offset: 0,
-				}).Return([]*service.UserResponse{
+				}).Return(&service.ListUsersResponse{Users: []*service.UserResponse{
 					{
 						ID:        uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
 						Email:     "user1@example.com",
@@ -438,46 +834,111 @@ func TestUserHandler_ListUsers(t *testing.T) {
 						CreatedAt: time.Now(),
 						UpdatedAt: time.Now(),
 					},
-				}, nil)
+				}, NextCursor: "next-cursor", TotalCount: 42}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			validateBody: func(t *testing.T, rec *httptest.ResponseRecorder) {
 				var resp ListUsersResponse
 				err := json.NewDecoder(rec.Body).Decode(&resp)
 				assert.NoError(t, err)
-				assert.Equal(t, 2, resp.TotalCount)
+				assert.Equal(t, int64(42), resp.TotalCount)
+				assert.False(t, resp.TotalCountEstimated)
 				assert.Equal(t, 10, resp.Limit)
 				assert.Equal(t, 0, resp.Offset)
+				assert.Equal(t, "next-cursor", resp.NextCursor)
+				assert.Empty(t, resp.PrevCursor)
 			},
 		},
 		{
-			name:        "成功: カスタムパラメータ",
-			queryParams: "?limit=20&offset=10",
+			name:        "成功: カーソル指定",
+			queryParams: "?limit=20&cursor=abc.def",
 			mockSetup: func(m *MockUserService) {
 				m.On("ListUsers", mock.Anything, service.ListUsersRequest{
 					Limit:  20,
-					Offset: 10,
-				}).Return([]*service.UserResponse{}, nil)
+					Cursor: "abc.def",
+				}).Return(&service.ListUsersResponse{Users: []*service.UserResponse{}, PrevCursor: "prev-cursor"}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			validateBody: func(t *testing.T, rec *httptest.ResponseRecorder) {
 				var resp ListUsersResponse
 				err := json.NewDecoder(rec.Body).Decode(&resp)
 				assert.NoError(t, err)
-				assert.Equal(t, 0, resp.TotalCount)
-				assert.Equal(t, 20, resp.Limit)
-				assert.Equal(t, 10, resp.Offset)
+				assert.Equal(t, "prev-cursor", resp.PrevCursor)
+				assert.Empty(t, resp.NextCursor)
 			},
 		},
 		{
-			name:        "失敗: 無効なlimit",
-			queryParams: "?limit=invalid",
+			name:        "失敗: 不正なカーソル",
+			queryParams: "?cursor=tampered",
 			mockSetup: func(m *MockUserService) {
-				// ListUsers is still called with default values because we handle invalid params
-				m.On("ListUsers", mock.Anything, service.ListUsersRequest{
-					Limit:  10,
-					Offset: 0,
-				}).Return([]*service.UserResponse{}, nil)
+				m.On("ListUsers", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidCursor)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "成功: カスタムパラメータ",
+			queryParams: "?limit=20&offset=10",
+			mockSetup: func(m *MockUserService) {
+				m.On("ListUsers", mock.Anything, service.ListUsersRequest{
+					Limit:  20,
+					Offset: 10,
+				}).Return(&service.ListUsersResponse{Users: []*service.UserResponse{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var resp ListUsersResponse
+				err := json.NewDecoder(rec.Body).Decode(&resp)
+				assert.NoError(t, err)
+				assert.Equal(t, int64(0), resp.TotalCount)
+				assert.Equal(t, 20, resp.Limit)
+				assert.Equal(t, 10, resp.Offset)
+			},
+		},
+		{
+			name:        "成功: フィルタと並び順",
+			queryParams: "?email_prefix=al&name_prefix=Al&created_from=2025-01-01T00:00:00Z&created_to=2025-02-01T00:00:00%2B09:00&sort_by=name&sort_order=asc",
+			mockSetup: func(m *MockUserService) {
+				createdFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+				createdTo := time.Date(2025, 2, 1, 0, 0, 0, 0, time.FixedZone("", 9*60*60))
+				m.On("ListUsers", mock.Anything, mock.MatchedBy(func(req service.ListUsersRequest) bool {
+					return req.EmailPrefix == "al" && req.NamePrefix == "Al" &&
+						req.SortBy == "name" && req.SortOrder == "asc" &&
+						req.CreatedFrom != nil && req.CreatedFrom.Equal(createdFrom) &&
+						req.CreatedTo != nil && req.CreatedTo.Equal(createdTo)
+				})).Return(&service.ListUsersResponse{Users: []*service.UserResponse{}, TotalCount: 150000, TotalCountEstimated: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var resp ListUsersResponse
+				err := json.NewDecoder(rec.Body).Decode(&resp)
+				assert.NoError(t, err)
+				assert.Equal(t, int64(150000), resp.TotalCount)
+				assert.True(t, resp.TotalCountEstimated)
+			},
+		},
+		{
+			name:           "失敗: 不正な作成日時",
+			queryParams:    "?created_from=yesterday",
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "失敗: 許可されていない並び順",
+			queryParams: "?sort_by=password",
+			mockSetup: func(m *MockUserService) {
+				m.On("ListUsers", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidSort)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "失敗: 無効なlimit",
+			queryParams: "?limit=invalid",
+			mockSetup: func(m *MockUserService) {
+				// ListUsers is still called with default values because we handle invalid params
+				m.On("ListUsers", mock.Anything, service.ListUsersRequest{
+					Limit:  10,
+					Offset: 0,
+				}).Return(&service.ListUsersResponse{Users: []*service.UserResponse{}}, nil)
 			},
 			expectedStatus: http.StatusOK, // Handler uses default values for invalid params
 		},
@@ -517,8 +978,179 @@ func TestUserHandler_ListUsers(t *testing.T) {
 	}
 }
 
+func TestUserHandler_SearchUsers(t *testing.T) {
+	logger := createTestLogger()
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+
+	tests := []struct {
+		name           string
+		queryParams    string
+		mockSetup      func(*MockUserService)
+		expectedStatus int
+		validateBody   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "成功: スコア付きの検索結果",
+			queryParams: "?q=alic&limit=5&offset=5",
+			mockSetup: func(m *MockUserService) {
+				m.On("SearchUsers", mock.Anything, service.SearchUsersRequest{Query: "alic", Limit: 5, Offset: 5}).
+					Return(&service.SearchUsersResponse{
+						Hits: []*service.UserSearchHitResponse{
+							{User: &service.UserResponse{ID: userID, Email: "alice@example.com", Name: "Alice"}, Score: 0.5},
+						},
+						TotalCount: 6,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var resp SearchUsersResponse
+				err := json.NewDecoder(rec.Body).Decode(&resp)
+				assert.NoError(t, err)
+				require.Len(t, resp.Hits, 1)
+				assert.Equal(t, userID, resp.Hits[0].User.ID)
+				assert.Equal(t, 0.5, resp.Hits[0].Score)
+				assert.Equal(t, int64(6), resp.TotalCount)
+				assert.Equal(t, 5, resp.Limit)
+				assert.Equal(t, 5, resp.Offset)
+			},
+		},
+		{
+			name:        "失敗: 検索語なし",
+			queryParams: "",
+			mockSetup: func(m *MockUserService) {
+				m.On("SearchUsers", mock.Anything, service.SearchUsersRequest{Limit: 10}).Return(nil, domain.ErrInvalidSearchQuery)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "失敗: サービスエラー",
+			queryParams: "?q=alice",
+			mockSetup: func(m *MockUserService) {
+				m.On("SearchUsers", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(MockUserService)
+			tt.mockSetup(mockSvc)
+
+			handler := NewUserHandler(mockSvc, logger)
+
+			req := httptest.NewRequest("GET", "/api/v1/users/search"+tt.queryParams, nil)
+			rec := httptest.NewRecorder()
+
+			handler.SearchUsers(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.validateBody != nil {
+				tt.validateBody(t, rec)
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_ListUserAuditEvents(t *testing.T) {
+	logger := createTestLogger()
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	actorID := uuid.MustParse("223e4567-e89b-12d3-a456-426614174000")
+
+	tests := []struct {
+		name           string
+		userID         string
+		queryParams    string
+		mockSetup      func(*MockUserService)
+		expectedStatus int
+		validateBody   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "成功: 監査ログのページ",
+			userID:      userID.String(),
+			queryParams: "?limit=5&offset=5",
+			mockSetup: func(m *MockUserService) {
+				m.On("ListUserAuditEvents", mock.Anything, service.ListUserAuditEventsRequest{UserID: userID, Limit: 5, Offset: 5}).
+					Return(&service.ListUserAuditEventsResponse{
+						Events: []*service.AuditEventResponse{
+							{
+								ID:         uuid.New(),
+								Action:     domain.AuditActionUserUpdate,
+								Outcome:    domain.AuditOutcomeSuccess,
+								ActorID:    &actorID,
+								UserID:     &userID,
+								RequestID:  "host/abc-000001",
+								ClientIP:   "192.0.2.1",
+								Changes:    []domain.AuditChange{{Field: "name", Before: "Alice", After: "Alice Smith"}},
+								OccurredAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+							},
+						},
+						TotalCount: 6,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var resp ListAuditEventsResponse
+				err := json.NewDecoder(rec.Body).Decode(&resp)
+				assert.NoError(t, err)
+				require.Len(t, resp.Events, 1)
+				event := resp.Events[0]
+				assert.Equal(t, "user.update", event.Action)
+				assert.Equal(t, "success", event.Outcome)
+				assert.Equal(t, &actorID, event.ActorID)
+				assert.Equal(t, "host/abc-000001", event.RequestID)
+				assert.Equal(t, "192.0.2.1", event.ClientIP)
+				assert.Equal(t, []domain.AuditChange{{Field: "name", Before: "Alice", After: "Alice Smith"}}, event.Changes)
+				assert.Equal(t, "2024-01-01T00:00:00Z", event.OccurredAt)
+				assert.Equal(t, int64(6), resp.TotalCount)
+				assert.Equal(t, 5, resp.Limit)
+				assert.Equal(t, 5, resp.Offset)
+			},
+		},
+		{
+			name:           "失敗: 不正なユーザーID",
+			userID:         "invalid-uuid",
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "失敗: サービスエラー",
+			userID: userID.String(),
+			mockSetup: func(m *MockUserService) {
+				m.On("ListUserAuditEvents", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(MockUserService)
+			tt.mockSetup(mockSvc)
+
+			handler := NewUserHandler(mockSvc, logger)
+
+			req := httptest.NewRequest("GET", "/api/v1/users/"+tt.userID+"/audit"+tt.queryParams, nil)
+			rec := httptest.NewRecorder()
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("userID", tt.userID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			handler.ListUserAuditEvents(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.validateBody != nil {
+				tt.validateBody(t, rec)
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
 func TestUserHandler_AuthenticateUser(t *testing.T) {
-	logger, _ := zap.NewDevelopment()
+	logger := createTestLogger()
 
 	tests := []struct {
 		name           string
@@ -533,10 +1165,17 @@ func TestUserHandler_AuthenticateUser(t *testing.T) {
 				"password": "Password123",
 			},
 			mockSetup: func(m *MockUserService) {
+				// httptest.NewRequest の RemoteAddr は 192.0.2.1:1234
 				m.On("AuthenticateUser", mock.Anything, service.AuthenticateUserRequest{
 					Email:    "test@example.com",
 					Password: "Password123",
-				}).Return(nil)
+					ClientIP: "192.0.2.1",
+				}).Return(&service.AuthTokens{
+					AccessToken:           "access-token",
+					AccessTokenExpiresAt:  time.Now().Add(15 * time.Minute),
+					RefreshToken:          "refresh-token",
+					RefreshTokenExpiresAt: time.Now().Add(24 * time.Hour),
+				}, nil)
 			},
 			expectedStatus: http.StatusOK,
 		},
@@ -548,7 +1187,55 @@ func TestUserHandler_AuthenticateUser(t *testing.T) {
 			},
 			mockSetup: func(m *MockUserService) {
 				m.On("AuthenticateUser", mock.Anything, mock.Anything).
-					Return(errors.New("invalid credentials"))
+					Return(nil, domain.ErrInvalidCredentials)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "失敗: アカウントロック中",
+			requestBody: map[string]string{
+				"email":    "test@example.com",
+				"password": "Password123",
+			},
+			mockSetup: func(m *MockUserService) {
+				m.On("AuthenticateUser", mock.Anything, mock.Anything).
+					Return(nil, domain.ErrAccountLocked)
+			},
+			expectedStatus: http.StatusLocked,
+		},
+		{
+			name: "失敗: IPからの試行回数超過",
+			requestBody: map[string]string{
+				"email":    "test@example.com",
+				"password": "Password123",
+			},
+			mockSetup: func(m *MockUserService) {
+				m.On("AuthenticateUser", mock.Anything, mock.Anything).
+					Return(nil, domain.ErrTooManyAttempts)
+			},
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name: "失敗: メールアドレス未確認",
+			requestBody: map[string]string{
+				"email":    "test@example.com",
+				"password": "Password123",
+			},
+			mockSetup: func(m *MockUserService) {
+				m.On("AuthenticateUser", mock.Anything, mock.Anything).
+					Return(nil, domain.ErrEmailNotVerified)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "失敗: 内部エラー",
+			requestBody: map[string]string{
+				"email":    "test@example.com",
+				"password": "Password123",
+			},
+			mockSetup: func(m *MockUserService) {
+				m.On("AuthenticateUser", mock.Anything, mock.Anything).
+					Return(nil, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
@@ -606,8 +1293,325 @@ func TestUserHandler_AuthenticateUser(t *testing.T) {
 	}
 }
 
+func TestUserHandler_RefreshToken(t *testing.T) {
+	logger := createTestLogger()
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockSetup      func(*MockUserService)
+		expectedStatus int
+	}{
+		{
+			name:        "成功: トークン再発行",
+			requestBody: map[string]string{"refresh_token": "refresh-token"},
+			mockSetup: func(m *MockUserService) {
+				m.On("RefreshToken", mock.Anything, service.RefreshTokenRequest{RefreshToken: "refresh-token"}).
+					Return(&service.AuthTokens{AccessToken: "new-access-token", RefreshToken: "new-refresh-token"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "失敗: 無効なトークン",
+			requestBody: map[string]string{"refresh_token": "revoked-token"},
+			mockSetup: func(m *MockUserService) {
+				m.On("RefreshToken", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidToken)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "失敗: トークンがない",
+			requestBody:    map[string]string{},
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(MockUserService)
+			tt.mockSetup(mockSvc)
+
+			handler := NewUserHandler(mockSvc, logger)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest("POST", "/api/v1/auth/refresh", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			handler.RefreshToken(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp AuthTokensResponse
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, "new-access-token", resp.AccessToken)
+				assert.Equal(t, "Bearer", resp.TokenType)
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_Logout(t *testing.T) {
+	logger := createTestLogger()
+
+	mockSvc := new(MockUserService)
+	mockSvc.On("Logout", mock.Anything, service.LogoutRequest{RefreshToken: "refresh-token"}).Return(nil)
+	handler := NewUserHandler(mockSvc, logger)
+
+	body, _ := json.Marshal(map[string]string{"refresh_token": "refresh-token"})
+	req := httptest.NewRequest("POST", "/api/v1/auth/logout", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	handler.Logout(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestUserHandler_ChangePassword(t *testing.T) {
+	logger := createTestLogger()
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		userID         string
+		requestBody    interface{}
+		mockSetup      func(*MockUserService)
+		expectedStatus int
+	}{
+		{
+			name:        "成功: パスワード変更",
+			userID:      userID.String(),
+			requestBody: map[string]string{"current_password": "oldPassword", "new_password": "newPassword123"},
+			mockSetup: func(m *MockUserService) {
+				m.On("ChangePassword", mock.Anything, service.ChangePasswordRequest{
+					ID:              userID,
+					CurrentPassword: "oldPassword",
+					NewPassword:     "newPassword123",
+				}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:        "失敗: 現在のパスワードが誤り",
+			userID:      userID.String(),
+			requestBody: map[string]string{"current_password": "wrong", "new_password": "newPassword123"},
+			mockSetup: func(m *MockUserService) {
+				m.On("ChangePassword", mock.Anything, mock.Anything).Return(domain.ErrInvalidCredentials)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "失敗: 無効なUUID",
+			userID:         "invalid-uuid",
+			requestBody:    map[string]string{"current_password": "oldPassword", "new_password": "newPassword123"},
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(MockUserService)
+			tt.mockSetup(mockSvc)
+
+			handler := NewUserHandler(mockSvc, logger)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest("PUT", "/api/v1/users/"+tt.userID+"/password", bytes.NewReader(body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("userID", tt.userID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			rec := httptest.NewRecorder()
+
+			handler.ChangePassword(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_RequestPasswordReset(t *testing.T) {
+	logger := createTestLogger()
+
+	mockSvc := new(MockUserService)
+	mockSvc.On("RequestPasswordReset", mock.Anything, service.RequestPasswordResetRequest{Email: "test@example.com"}).Return(nil)
+	handler := NewUserHandler(mockSvc, logger)
+
+	body, _ := json.Marshal(map[string]string{"email": "test@example.com"})
+	req := httptest.NewRequest("POST", "/api/v1/auth/password-reset", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	handler.RequestPasswordReset(rec, req)
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestUserHandler_ConfirmPasswordReset(t *testing.T) {
+	logger := createTestLogger()
+
+	tests := []struct {
+		name           string
+		requestBody    map[string]string
+		mockSetup      func(*MockUserService)
+		expectedStatus int
+	}{
+		{
+			name:        "成功: パスワード再設定",
+			requestBody: map[string]string{"token": "reset-token", "new_password": "newPassword123"},
+			mockSetup: func(m *MockUserService) {
+				m.On("ConfirmPasswordReset", mock.Anything, service.ConfirmPasswordResetRequest{
+					Token:       "reset-token",
+					NewPassword: "newPassword123",
+				}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:        "失敗: 無効なトークン",
+			requestBody: map[string]string{"token": "used-token", "new_password": "newPassword123"},
+			mockSetup: func(m *MockUserService) {
+				m.On("ConfirmPasswordReset", mock.Anything, mock.Anything).Return(domain.ErrInvalidToken)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "失敗: トークンなし",
+			requestBody:    map[string]string{"new_password": "newPassword123"},
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(MockUserService)
+			tt.mockSetup(mockSvc)
+
+			handler := NewUserHandler(mockSvc, logger)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest("POST", "/api/v1/auth/password-reset/confirm", bytes.NewReader(body))
+			rec := httptest.NewRecorder()
+
+			handler.ConfirmPasswordReset(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_VerifyEmail(t *testing.T) {
+	logger := createTestLogger()
+
+	tests := []struct {
+		name           string
+		requestBody    map[string]string
+		mockSetup      func(*MockUserService)
+		expectedStatus int
+	}{
+		{
+			name:        "成功: メールアドレス確認",
+			requestBody: map[string]string{"token": "verification-token"},
+			mockSetup: func(m *MockUserService) {
+				m.On("VerifyEmail", mock.Anything, service.VerifyEmailRequest{Token: "verification-token"}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:        "失敗: 無効なトークン",
+			requestBody: map[string]string{"token": "expired-token"},
+			mockSetup: func(m *MockUserService) {
+				m.On("VerifyEmail", mock.Anything, mock.Anything).Return(domain.ErrInvalidToken)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "失敗: トークンなし",
+			requestBody:    map[string]string{},
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(MockUserService)
+			tt.mockSetup(mockSvc)
+
+			handler := NewUserHandler(mockSvc, logger)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest("POST", "/api/v1/users/verify-email", bytes.NewReader(body))
+			rec := httptest.NewRecorder()
+
+			handler.VerifyEmail(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_ResendEmailVerification(t *testing.T) {
+	logger := createTestLogger()
+
+	tests := []struct {
+		name           string
+		requestBody    map[string]string
+		mockSetup      func(*MockUserService)
+		expectedStatus int
+	}{
+		{
+			name:        "成功: 確認メール再送",
+			requestBody: map[string]string{"email": "test@example.com"},
+			mockSetup: func(m *MockUserService) {
+				m.On("ResendEmailVerification", mock.Anything, service.ResendEmailVerificationRequest{Email: "test@example.com"}).Return(nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:        "失敗: 不正なメールアドレス",
+			requestBody: map[string]string{"email": "invalid"},
+			mockSetup: func(m *MockUserService) {
+				m.On("ResendEmailVerification", mock.Anything, mock.Anything).Return(domain.ErrInvalidEmail)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "失敗: メールアドレスなし",
+			requestBody:    map[string]string{},
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(MockUserService)
+			tt.mockSetup(mockSvc)
+
+			handler := NewUserHandler(mockSvc, logger)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest("POST", "/api/v1/users/verify-email/resend", bytes.NewReader(body))
+			rec := httptest.NewRecorder()
+
+			handler.ResendEmailVerification(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
 func TestUserHandler_HealthCheck(t *testing.T) {
-	logger, _ := zap.NewDevelopment()
+	logger := createTestLogger()
 	mockSvc := new(MockUserService)
 	handler := NewUserHandler(mockSvc, logger)
 
@@ -621,7 +1625,7 @@ func TestUserHandler_HealthCheck(t *testing.T) {
 }
 
 func TestUserHandler_ReadinessCheck(t *testing.T) {
-	logger, _ := zap.NewDevelopment()
+	logger := createTestLogger()
 	mockSvc := new(MockUserService)
 	handler := NewUserHandler(mockSvc, logger)
 
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	db "github.com/lot-koichi/sre-skill-up-project/services/user/db/sqlc/generated"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+)
+
+type postgresAuditEventRepository struct {
+	db      *sql.DB
+	queries *db.Queries
+}
+
+// NewAuditEventRepository creates a new PostgreSQL audit event repository
+func NewAuditEventRepository(database *sql.DB) repository.AuditEventRepository {
+	return &postgresAuditEventRepository{
+		db:      database,
+		queries: newQueries(database),
+	}
+}
+
+func (r *postgresAuditEventRepository) Create(ctx context.Context, event *domain.AuditEvent) error {
+	params, err := toCreateAuditEventParams(event)
+	if err != nil {
+		return err
+	}
+	if err := r.queries.CreateAuditEvent(ctx, params); err != nil {
+		return handlePostgresError(err)
+	}
+	return nil
+}
+
+func (r *postgresAuditEventRepository) CreateBatch(ctx context.Context, events []*domain.AuditEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	rows := make([][]any, 0, len(events))
+	for _, event := range events {
+		row, err := toAuditEventCopyRow(event)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row)
+	}
+	return withSQLTx(ctx, r.db, func(tx *sql.Tx) error {
+		return copyIn(ctx, tx, "audit_events", auditEventCopyColumns, rows)
+	})
+}
+
+func (r *postgresAuditEventRepository) ListByUser(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]*domain.AuditEvent, error) {
+	events, err := r.queries.ListAuditEventsByUser(ctx, db.ListAuditEventsByUserParams{
+		UserID: uuid.NullUUID{UUID: userID, Valid: true},
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, handlePostgresError(err)
+	}
+	return toDomainAuditEvents(events)
+}
+
+func (r *postgresAuditEventRepository) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	count, err := r.queries.CountAuditEventsByUser(ctx, uuid.NullUUID{UUID: userID, Valid: true})
+	if err != nil {
+		return 0, handlePostgresError(err)
+	}
+	return count, nil
+}
@@ -1,20 +1,35 @@
 package postgres
 
 import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 	db "github.com/lot-koichi/sre-skill-up-project/services/user/db/sqlc/generated"
 	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
 )
 
 // toDomainUser converts SQLC generated User to domain User
 func toDomainUser(sqlcUser db.User) *domain.User {
-	return &domain.User{
+	user := &domain.User{
 		ID:        sqlcUser.ID,
 		Email:     domain.Email(sqlcUser.Email),
 		Password:  domain.Password(sqlcUser.Password),
 		Name:      domain.Name(sqlcUser.Name),
 		CreatedAt: sqlcUser.CreatedAt.Time,
 		UpdatedAt: sqlcUser.UpdatedAt.Time,
+		Version:   sqlcUser.Version,
+	}
+	if sqlcUser.DeletedAt.Valid {
+		user.DeletedAt = &sqlcUser.DeletedAt.Time
 	}
+	if sqlcUser.EmailVerifiedAt.Valid {
+		user.EmailVerifiedAt = &sqlcUser.EmailVerifiedAt.Time
+	}
+	return user
 }
 
 // toCreateUserParams converts domain User to SQLC CreateUserParams
@@ -29,20 +44,357 @@ func toCreateUserParams(user *domain.User) db.CreateUserParams {
 // toUpdateUserParams converts domain User to SQLC UpdateUserParams
 func toUpdateUserParams(user *domain.User) db.UpdateUserParams {
 	return db.UpdateUserParams{
-		Email: string(user.Email),
-		Name:  string(user.Name),
-		ID:    user.ID,
+		Email:   string(user.Email),
+		Name:    string(user.Name),
+		ID:      user.ID,
+		Version: user.Version,
+	}
+}
+
+// toPatchUserParams converts the changed fields to SQLC PatchUserParams; unchanged fields are NULL
+func toPatchUserParams(user *domain.User, changes domain.UserPatch) db.PatchUserParams {
+	params := db.PatchUserParams{
+		ID:      user.ID,
+		Version: user.Version,
+	}
+	if changes.Email != nil {
+		params.Email = sql.NullString{String: string(*changes.Email), Valid: true}
+	}
+	if changes.Name != nil {
+		params.Name = sql.NullString{String: string(*changes.Name), Valid: true}
 	}
+	return params
 }
 
 // toListUsersParams creates SQLC ListUsersParams
-func toListUsersParams(limit, offset int32) db.ListUsersParams {
+func toListUsersParams(filter domain.UserFilter, sort domain.UserSort, limit, offset int32) db.ListUsersParams {
 	return db.ListUsersParams{
-		Limit:  limit,
-		Offset: offset,
+		EmailPrefix: toNullPrefix(filter.EmailPrefix),
+		NamePrefix:  toNullPrefix(filter.NamePrefix),
+		CreatedFrom: toNullTime(filter.CreatedFrom),
+		CreatedTo:   toNullTime(filter.CreatedTo),
+		SortColumn:  string(sort.Field),
+		SortDesc:    sort.Order == domain.SortDesc,
+		RowLimit:    limit,
+		RowOffset:   offset,
+	}
+}
+
+// toListUsersAfterParams creates SQLC ListUsersAfterParams from a cursor
+func toListUsersAfterParams(filter domain.UserFilter, cursor domain.UserCursor, limit int32) db.ListUsersAfterParams {
+	return db.ListUsersAfterParams{
+		CreatedAt:   sql.NullTime{Time: cursor.CreatedAt, Valid: true},
+		ID:          cursor.ID,
+		EmailPrefix: toNullPrefix(filter.EmailPrefix),
+		NamePrefix:  toNullPrefix(filter.NamePrefix),
+		CreatedFrom: toNullTime(filter.CreatedFrom),
+		CreatedTo:   toNullTime(filter.CreatedTo),
+		RowLimit:    limit,
+	}
+}
+
+// toListUsersBeforeParams creates SQLC ListUsersBeforeParams from a cursor
+func toListUsersBeforeParams(filter domain.UserFilter, cursor domain.UserCursor, limit int32) db.ListUsersBeforeParams {
+	return db.ListUsersBeforeParams{
+		CreatedAt:   sql.NullTime{Time: cursor.CreatedAt, Valid: true},
+		ID:          cursor.ID,
+		EmailPrefix: toNullPrefix(filter.EmailPrefix),
+		NamePrefix:  toNullPrefix(filter.NamePrefix),
+		CreatedFrom: toNullTime(filter.CreatedFrom),
+		CreatedTo:   toNullTime(filter.CreatedTo),
+		RowLimit:    limit,
+	}
+}
+
+// toCountUsersParams creates SQLC CountUsersParams
+func toCountUsersParams(filter domain.UserFilter) db.CountUsersParams {
+	return db.CountUsersParams{
+		EmailPrefix: toNullPrefix(filter.EmailPrefix),
+		NamePrefix:  toNullPrefix(filter.NamePrefix),
+		CreatedFrom: toNullTime(filter.CreatedFrom),
+		CreatedTo:   toNullTime(filter.CreatedTo),
+	}
+}
+
+// toSearchUsersParams creates SQLC SearchUsersParams
+func toSearchUsersParams(query string, limit, offset int32) db.SearchUsersParams {
+	return db.SearchUsersParams{
+		Query:     query,
+		Pattern:   toContainsPattern(query),
+		RowLimit:  limit,
+		RowOffset: offset,
+	}
+}
+
+// toCountSearchUsersParams creates SQLC CountSearchUsersParams
+func toCountSearchUsersParams(query string) db.CountSearchUsersParams {
+	return db.CountSearchUsersParams{
+		Pattern: toContainsPattern(query),
+		Query:   query,
+	}
+}
+
+// toDomainUserSearchHits converts SQLC SearchUsersRows to domain UserSearchHits
+func toDomainUserSearchHits(rows []db.SearchUsersRow) []*domain.UserSearchHit {
+	hits := make([]*domain.UserSearchHit, 0, len(rows))
+	for _, row := range rows {
+		hits = append(hits, &domain.UserSearchHit{
+			User: toDomainUser(db.User{
+				ID:              row.ID,
+				Email:           row.Email,
+				Name:            row.Name,
+				CreatedAt:       row.CreatedAt,
+				UpdatedAt:       row.UpdatedAt,
+				Password:        row.Password,
+				DeletedAt:       row.DeletedAt,
+				EmailVerifiedAt: row.EmailVerifiedAt,
+				Version:         row.Version,
+			}),
+			Score: row.Score,
+		})
+	}
+	return hits
+}
+
+// toInsertOutboxEventParams converts domain Event to SQLC InsertOutboxEventParams
+func toInsertOutboxEventParams(event *domain.Event) db.InsertOutboxEventParams {
+	return db.InsertOutboxEventParams{
+		EventID:    event.ID,
+		EventType:  string(event.Type),
+		Payload:    event.Payload,
+		Version:    int32(event.Version),
+		OccurredAt: event.OccurredAt,
+	}
+}
+
+// userCopyColumns are the users columns written by toUserCopyRow
+var userCopyColumns = []string{"id", "email", "name", "password", "created_at", "updated_at"}
+
+// toUserCopyRow converts domain User to a COPY row of userCopyColumns
+func toUserCopyRow(user *domain.User) []any {
+	return []any{user.ID, string(user.Email), string(user.Name), string(user.Password), user.CreatedAt, user.UpdatedAt}
+}
+
+// outboxEventCopyColumns are the outbox_events columns written by toOutboxEventCopyRow
+var outboxEventCopyColumns = []string{"event_id", "event_type", "payload", "version", "occurred_at"}
+
+// toOutboxEventCopyRow converts domain Event to a COPY row of outboxEventCopyColumns
+func toOutboxEventCopyRow(event *domain.Event) []any {
+	// []byte は bytea として送られるため、jsonb の列には文字列で渡す
+	return []any{event.ID, string(event.Type), string(event.Payload), event.Version, event.OccurredAt}
+}
+
+// toDomainEmails converts email strings to domain Emails
+func toDomainEmails(emails []string) []domain.Email {
+	domainEmails := make([]domain.Email, 0, len(emails))
+	for _, email := range emails {
+		domainEmails = append(domainEmails, domain.Email(email))
+	}
+	return domainEmails
+}
+
+// toClaimOutboxEventsParams creates SQLC ClaimOutboxEventsParams
+func toClaimOutboxEventsParams(batchSize int32, lease time.Duration) db.ClaimOutboxEventsParams {
+	return db.ClaimOutboxEventsParams{
+		LeaseMs:   lease.Milliseconds(),
+		BatchSize: batchSize,
+	}
+}
+
+// toDomainOutboxEvent converts SQLC generated OutboxEvent to domain OutboxEvent
+func toDomainOutboxEvent(sqlcEvent db.OutboxEvent) *domain.OutboxEvent {
+	return &domain.OutboxEvent{
+		SeqID: sqlcEvent.SeqID,
+		Event: domain.Event{
+			ID:         sqlcEvent.EventID,
+			Type:       domain.EventType(sqlcEvent.EventType),
+			Version:    int(sqlcEvent.Version),
+			OccurredAt: sqlcEvent.OccurredAt,
+			Producer:   domain.EventProducer,
+			Payload:    sqlcEvent.Payload,
+		},
+		Status:      domain.OutboxStatus(sqlcEvent.Status),
+		Attempts:    int(sqlcEvent.Attempts),
+		ErrorReason: sqlcEvent.ErrorReason.String,
+	}
+}
+
+// toDomainOutboxEvents converts multiple SQLC OutboxEvents to domain OutboxEvents
+func toDomainOutboxEvents(sqlcEvents []db.OutboxEvent) []*domain.OutboxEvent {
+	domainEvents := make([]*domain.OutboxEvent, 0, len(sqlcEvents))
+	for _, sqlcEvent := range sqlcEvents {
+		domainEvents = append(domainEvents, toDomainOutboxEvent(sqlcEvent))
+	}
+	return domainEvents
+}
+
+// toNullString converts an empty string to NULL
+func toNullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// toNullPrefix converts a prefix to a LIKE pattern prefix with its wildcards escaped; an empty prefix becomes NULL
+func toNullPrefix(prefix string) sql.NullString {
+	return toNullString(likeEscaper.Replace(prefix))
+}
+
+// toContainsPattern converts a search query to a LIKE pattern matching it anywhere
+func toContainsPattern(query string) string {
+	return "%" + likeEscaper.Replace(query) + "%"
+}
+
+// likeEscaper escapes the LIKE wildcards with the default escape character
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// toNullTime converts a nil time to NULL
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+// toCreateRefreshTokenParams converts domain RefreshToken to SQLC CreateRefreshTokenParams
+func toCreateRefreshTokenParams(token *domain.RefreshToken) db.CreateRefreshTokenParams {
+	return db.CreateRefreshTokenParams{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
 	}
 }
 
+// toDomainRefreshToken converts SQLC generated RefreshToken to domain RefreshToken
+func toDomainRefreshToken(sqlcToken db.RefreshToken) *domain.RefreshToken {
+	token := &domain.RefreshToken{
+		ID:        sqlcToken.ID,
+		UserID:    sqlcToken.UserID,
+		TokenHash: sqlcToken.TokenHash,
+		ExpiresAt: sqlcToken.ExpiresAt,
+		CreatedAt: sqlcToken.CreatedAt,
+	}
+	if sqlcToken.RevokedAt.Valid {
+		token.RevokedAt = &sqlcToken.RevokedAt.Time
+	}
+	return token
+}
+
+// toCreatePasswordResetTokenParams converts domain PasswordResetToken to SQLC CreatePasswordResetTokenParams
+func toCreatePasswordResetTokenParams(token *domain.PasswordResetToken) db.CreatePasswordResetTokenParams {
+	return db.CreatePasswordResetTokenParams{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+	}
+}
+
+// toDomainPasswordResetToken converts SQLC generated PasswordResetToken to domain PasswordResetToken
+func toDomainPasswordResetToken(sqlcToken db.PasswordResetToken) *domain.PasswordResetToken {
+	token := &domain.PasswordResetToken{
+		ID:        sqlcToken.ID,
+		UserID:    sqlcToken.UserID,
+		TokenHash: sqlcToken.TokenHash,
+		ExpiresAt: sqlcToken.ExpiresAt,
+		CreatedAt: sqlcToken.CreatedAt,
+	}
+	if sqlcToken.UsedAt.Valid {
+		token.UsedAt = &sqlcToken.UsedAt.Time
+	}
+	return token
+}
+
+// toCreateEmailVerificationTokenParams converts domain EmailVerificationToken to SQLC CreateEmailVerificationTokenParams
+func toCreateEmailVerificationTokenParams(token *domain.EmailVerificationToken) db.CreateEmailVerificationTokenParams {
+	return db.CreateEmailVerificationTokenParams{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		Email:     string(token.Email),
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+	}
+}
+
+// toDomainEmailVerificationToken converts SQLC generated EmailVerificationToken to domain EmailVerificationToken
+func toDomainEmailVerificationToken(sqlcToken db.EmailVerificationToken) *domain.EmailVerificationToken {
+	token := &domain.EmailVerificationToken{
+		ID:        sqlcToken.ID,
+		UserID:    sqlcToken.UserID,
+		Email:     domain.Email(sqlcToken.Email),
+		TokenHash: sqlcToken.TokenHash,
+		ExpiresAt: sqlcToken.ExpiresAt,
+		CreatedAt: sqlcToken.CreatedAt,
+	}
+	if sqlcToken.UsedAt.Valid {
+		token.UsedAt = &sqlcToken.UsedAt.Time
+	}
+	return token
+}
+
+// toDomainLoginThrottle converts SQLC generated LoginThrottle to domain LoginThrottle
+func toDomainLoginThrottle(sqlcThrottle db.LoginThrottle) *domain.LoginThrottle {
+	throttle := &domain.LoginThrottle{
+		Scope:         domain.LoginThrottleScope(sqlcThrottle.Scope),
+		Subject:       sqlcThrottle.Subject,
+		Failures:      int(sqlcThrottle.Failures),
+		LastFailureAt: sqlcThrottle.LastFailureAt,
+	}
+	if sqlcThrottle.LockedUntil.Valid {
+		throttle.LockedUntil = &sqlcThrottle.LockedUntil.Time
+	}
+	return throttle
+}
+
+// toReserveIdempotencyKeyParams converts domain IdempotencyKey to SQLC ReserveIdempotencyKeyParams
+func toReserveIdempotencyKeyParams(key *domain.IdempotencyKey) db.ReserveIdempotencyKeyParams {
+	return db.ReserveIdempotencyKeyParams{
+		Scope:          key.Scope,
+		IdempotencyKey: key.Key,
+		RequestHash:    key.RequestHash,
+		ExpiresAt:      key.ExpiresAt,
+	}
+}
+
+// toCompleteIdempotencyKeyParams converts domain IdempotencyKey to SQLC CompleteIdempotencyKeyParams
+func toCompleteIdempotencyKeyParams(key *domain.IdempotencyKey) (db.CompleteIdempotencyKeyParams, error) {
+	headers := key.ResponseHeaders
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	rawHeaders, err := json.Marshal(headers)
+	if err != nil {
+		return db.CompleteIdempotencyKeyParams{}, fmt.Errorf("failed to marshal response headers: %w", err)
+	}
+	return db.CompleteIdempotencyKeyParams{
+		StatusCode:      sql.NullInt32{Int32: int32(key.StatusCode), Valid: key.StatusCode != 0},
+		ResponseHeaders: rawHeaders,
+		ResponseBody:    key.ResponseBody,
+		Scope:           key.Scope,
+		IdempotencyKey:  key.Key,
+	}, nil
+}
+
+// toDomainIdempotencyKey converts SQLC generated IdempotencyKey to domain IdempotencyKey
+func toDomainIdempotencyKey(sqlcKey db.IdempotencyKey) (*domain.IdempotencyKey, error) {
+	key := &domain.IdempotencyKey{
+		Scope:        sqlcKey.Scope,
+		Key:          sqlcKey.IdempotencyKey,
+		RequestHash:  sqlcKey.RequestHash,
+		ResponseBody: sqlcKey.ResponseBody,
+		CreatedAt:    sqlcKey.CreatedAt,
+		ExpiresAt:    sqlcKey.ExpiresAt,
+	}
+	if sqlcKey.StatusCode.Valid {
+		key.StatusCode = int(sqlcKey.StatusCode.Int32)
+	}
+	if len(sqlcKey.ResponseHeaders) > 0 {
+		if err := json.Unmarshal(sqlcKey.ResponseHeaders, &key.ResponseHeaders); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response headers: %w", err)
+		}
+	}
+	return key, nil
+}
+
 // toDomainUsers converts multiple SQLC Users to domain Users
 func toDomainUsers(sqlcUsers []db.User) []*domain.User {
 	domainUsers := make([]*domain.User, 0, len(sqlcUsers))
@@ -52,15 +404,120 @@ func toDomainUsers(sqlcUsers []db.User) []*domain.User {
 	return domainUsers
 }
 
+// toDomainRoles converts role names to domain Roles
+func toDomainRoles(roles []string) []domain.Role {
+	domainRoles := make([]domain.Role, 0, len(roles))
+	for _, role := range roles {
+		domainRoles = append(domainRoles, domain.Role(role))
+	}
+	return domainRoles
+}
+
+// assignRoles sets the roles of each user from ListUserRolesByUserIDs rows
+func assignRoles(users []*domain.User, rows []db.ListUserRolesByUserIDsRow) {
+	rolesByUser := make(map[uuid.UUID][]domain.Role, len(users))
+	for _, row := range rows {
+		rolesByUser[row.UserID] = append(rolesByUser[row.UserID], domain.Role(row.Role))
+	}
+	for _, user := range users {
+		user.Roles = rolesByUser[user.ID]
+		if user.Roles == nil {
+			user.Roles = []domain.Role{}
+		}
+	}
+}
+
 // updateTimestamps updates the domain user with timestamps from created/updated user
 func updateTimestamps(domainUser *domain.User, sqlcUser db.User) {
 	domainUser.ID = sqlcUser.ID
 	domainUser.CreatedAt = sqlcUser.CreatedAt.Time
 	domainUser.UpdatedAt = sqlcUser.UpdatedAt.Time
+	domainUser.Version = sqlcUser.Version
 }
 
-// updateUpdatedAt updates only the UpdatedAt timestamp
+// updateUpdatedAt updates the UpdatedAt timestamp and the version after an update
 func updateUpdatedAt(domainUser *domain.User, sqlcUser db.User) {
 	domainUser.UpdatedAt = sqlcUser.UpdatedAt.Time
+	domainUser.Version = sqlcUser.Version
+}
+
+// toCreateAuditEventParams converts domain AuditEvent to SQLC CreateAuditEventParams
+func toCreateAuditEventParams(event *domain.AuditEvent) (db.CreateAuditEventParams, error) {
+	changes, err := json.Marshal(event.Changes)
+	if err != nil {
+		return db.CreateAuditEventParams{}, fmt.Errorf("failed to marshal audit changes: %w", err)
+	}
+	return db.CreateAuditEventParams{
+		ID:         event.ID,
+		Action:     string(event.Action),
+		Outcome:    string(event.Outcome),
+		ActorID:    toNullUUID(event.ActorID),
+		UserID:     toNullUUID(event.UserID),
+		RequestID:  event.RequestID,
+		ClientIp:   event.ClientIP,
+		Changes:    changes,
+		Error:      event.Error,
+		OccurredAt: event.OccurredAt,
+	}, nil
+}
+
+// auditEventCopyColumns are the audit_events columns written by toAuditEventCopyRow
+var auditEventCopyColumns = []string{"id", "action", "outcome", "actor_id", "user_id", "request_id", "client_ip", "changes", "error", "occurred_at"}
+
+// toAuditEventCopyRow converts domain AuditEvent to a COPY row of auditEventCopyColumns
+func toAuditEventCopyRow(event *domain.AuditEvent) ([]any, error) {
+	params, err := toCreateAuditEventParams(event)
+	if err != nil {
+		return nil, err
+	}
+	// []byte は bytea として送られるため、jsonb の列には文字列で渡す
+	return []any{params.ID, params.Action, params.Outcome, params.ActorID, params.UserID, params.RequestID, params.ClientIp, string(params.Changes), params.Error, params.OccurredAt}, nil
+}
+
+// toDomainAuditEvent converts SQLC generated AuditEvent to domain AuditEvent
+func toDomainAuditEvent(sqlcEvent db.AuditEvent) (*domain.AuditEvent, error) {
+	event := &domain.AuditEvent{
+		ID:         sqlcEvent.ID,
+		Action:     domain.AuditAction(sqlcEvent.Action),
+		Outcome:    domain.AuditOutcome(sqlcEvent.Outcome),
+		ActorID:    fromNullUUID(sqlcEvent.ActorID),
+		UserID:     fromNullUUID(sqlcEvent.UserID),
+		RequestID:  sqlcEvent.RequestID,
+		ClientIP:   sqlcEvent.ClientIp,
+		Error:      sqlcEvent.Error,
+		OccurredAt: sqlcEvent.OccurredAt,
+	}
+	if err := json.Unmarshal(sqlcEvent.Changes, &event.Changes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audit changes: %w", err)
+	}
+	return event, nil
 }
 
+// toDomainAuditEvents converts multiple SQLC AuditEvents to domain AuditEvents
+func toDomainAuditEvents(sqlcEvents []db.AuditEvent) ([]*domain.AuditEvent, error) {
+	events := make([]*domain.AuditEvent, 0, len(sqlcEvents))
+	for _, sqlcEvent := range sqlcEvents {
+		event, err := toDomainAuditEvent(sqlcEvent)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// toNullUUID converts a nil ID to NULL
+func toNullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+// fromNullUUID converts NULL to a nil ID
+func fromNullUUID(id uuid.NullUUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	return &id.UUID
+}
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// copyIn bulk-loads rows into table with COPY FROM STDIN; each row holds one value per column
+func copyIn(ctx context.Context, tx *sql.Tx, table string, columns []string, rows [][]any) (err error) {
+	query := pq.CopyIn(table, columns...)
+	ctx, span := tracing.Start(ctx, "COPY "+table,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(queryAttributes("COPY", query)...),
+		trace.WithAttributes(attribute.Int("db.copy.rows", len(rows))),
+	)
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return handlePostgresError(err)
+	}
+
+	for _, row := range rows {
+		if _, err := stmt.ExecContext(ctx, row...); err != nil {
+			stmt.Close()
+			return handlePostgresError(err)
+		}
+	}
+	// 引数なしの Exec でバッファを送り切り、制約違反などのエラーを受け取る
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		stmt.Close()
+		return handlePostgresError(err)
+	}
+	if err := stmt.Close(); err != nil {
+		return fmt.Errorf("failed to close COPY statement: %w", err)
+	}
+	return nil
+}
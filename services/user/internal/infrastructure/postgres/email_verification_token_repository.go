@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/lot-koichi/sre-skill-up-project/services/user/db/sqlc/generated"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+)
+
+type postgresEmailVerificationTokenRepository struct {
+	queries *db.Queries
+}
+
+// NewEmailVerificationTokenRepository creates a new PostgreSQL email verification token repository
+func NewEmailVerificationTokenRepository(database *sql.DB) repository.EmailVerificationTokenRepository {
+	return &postgresEmailVerificationTokenRepository{
+		queries: newQueries(database),
+	}
+}
+
+func (r *postgresEmailVerificationTokenRepository) Create(ctx context.Context, token *domain.EmailVerificationToken) error {
+	created, err := r.queries.CreateEmailVerificationToken(ctx, toCreateEmailVerificationTokenParams(token))
+	if err != nil {
+		return handlePostgresError(err)
+	}
+	token.CreatedAt = created.CreatedAt
+	return nil
+}
+
+func (r *postgresEmailVerificationTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.EmailVerificationToken, error) {
+	token, err := r.queries.GetEmailVerificationTokenByHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, handlePostgresError(err)
+	}
+	return toDomainEmailVerificationToken(token), nil
+}
+
+func (r *postgresEmailVerificationTokenRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	rows, err := r.queries.UseEmailVerificationToken(ctx, id)
+	if err != nil {
+		return handlePostgresError(err)
+	}
+	// 既に使用済みの場合は 0 件になる（同一トークンの同時使用を防ぐ）
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *postgresEmailVerificationTokenRepository) InvalidateAllForUser(ctx context.Context, userID uuid.UUID) error {
+	if err := r.queries.InvalidateUserEmailVerificationTokens(ctx, userID); err != nil {
+		return handlePostgresError(err)
+	}
+	return nil
+}
+
+func (r *postgresEmailVerificationTokenRepository) CountIssuedSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	count, err := r.queries.CountEmailVerificationTokensSince(ctx, db.CountEmailVerificationTokensSinceParams{
+		UserID: userID,
+		Since:  since,
+	})
+	if err != nil {
+		return 0, handlePostgresError(err)
+	}
+	return int(count), nil
+}
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/lot-koichi/sre-skill-up-project/services/user/db/sqlc/generated"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+)
+
+// reserveAttempts bounds the retries when the conflicting key disappears between Reserve and Get
+const reserveAttempts = 2
+
+type postgresIdempotencyKeyRepository struct {
+	queries *db.Queries
+}
+
+// NewIdempotencyKeyRepository creates a new PostgreSQL idempotency key repository
+func NewIdempotencyKeyRepository(database *sql.DB) repository.IdempotencyKeyRepository {
+	return &postgresIdempotencyKeyRepository{
+		queries: newQueries(database),
+	}
+}
+
+func (r *postgresIdempotencyKeyRepository) Reserve(ctx context.Context, key *domain.IdempotencyKey) (*domain.IdempotencyKey, bool, error) {
+	for attempt := 1; ; attempt++ {
+		reserved, err := r.queries.ReserveIdempotencyKey(ctx, toReserveIdempotencyKeyParams(key))
+		if err == nil {
+			key.CreatedAt = reserved.CreatedAt
+			return nil, true, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, false, handlePostgresError(err)
+		}
+
+		// 有効なキーが既にある
+		existing, err := r.queries.GetIdempotencyKey(ctx, db.GetIdempotencyKeyParams{
+			Scope:          key.Scope,
+			IdempotencyKey: key.Key,
+		})
+		if err == nil {
+			domainKey, err := toDomainIdempotencyKey(existing)
+			if err != nil {
+				return nil, false, err
+			}
+			return domainKey, false, nil
+		}
+		// 取得までの間に解放・削除された場合は予約をやり直す
+		if !errors.Is(err, sql.ErrNoRows) || attempt >= reserveAttempts {
+			return nil, false, handlePostgresError(err)
+		}
+	}
+}
+
+func (r *postgresIdempotencyKeyRepository) Complete(ctx context.Context, key *domain.IdempotencyKey) error {
+	params, err := toCompleteIdempotencyKeyParams(key)
+	if err != nil {
+		return err
+	}
+	if err := r.queries.CompleteIdempotencyKey(ctx, params); err != nil {
+		return handlePostgresError(err)
+	}
+	return nil
+}
+
+func (r *postgresIdempotencyKeyRepository) Release(ctx context.Context, scope, key string) error {
+	err := r.queries.DeleteIdempotencyKey(ctx, db.DeleteIdempotencyKeyParams{
+		Scope:          scope,
+		IdempotencyKey: key,
+	})
+	if err != nil {
+		return handlePostgresError(err)
+	}
+	return nil
+}
+
+func (r *postgresIdempotencyKeyRepository) PurgeExpired(ctx context.Context) (int64, error) {
+	purged, err := r.queries.PurgeExpiredIdempotencyKeys(ctx)
+	if err != nil {
+		return 0, handlePostgresError(err)
+	}
+	return purged, nil
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/lot-koichi/sre-skill-up-project/services/user/db/sqlc/generated"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+)
+
+type postgresLoginThrottleRepository struct {
+	queries *db.Queries
+}
+
+// NewLoginThrottleRepository creates a new PostgreSQL login throttle repository
+func NewLoginThrottleRepository(database *sql.DB) repository.LoginThrottleRepository {
+	return &postgresLoginThrottleRepository{
+		queries: newQueries(database),
+	}
+}
+
+func (r *postgresLoginThrottleRepository) Get(ctx context.Context, scope domain.LoginThrottleScope, subject string) (*domain.LoginThrottle, error) {
+	throttle, err := r.queries.GetLoginThrottle(ctx, db.GetLoginThrottleParams{
+		Scope:   string(scope),
+		Subject: subject,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, handlePostgresError(err)
+	}
+	return toDomainLoginThrottle(throttle), nil
+}
+
+func (r *postgresLoginThrottleRepository) RecordFailure(ctx context.Context, scope domain.LoginThrottleScope, subject string, window time.Duration) (*domain.LoginThrottle, error) {
+	throttle, err := r.queries.RecordLoginFailure(ctx, db.RecordLoginFailureParams{
+		Scope:    string(scope),
+		Subject:  subject,
+		WindowMs: window.Milliseconds(),
+	})
+	if err != nil {
+		return nil, handlePostgresError(err)
+	}
+	return toDomainLoginThrottle(throttle), nil
+}
+
+func (r *postgresLoginThrottleRepository) Lock(ctx context.Context, scope domain.LoginThrottleScope, subject string, lockout time.Duration) error {
+	err := r.queries.LockLoginThrottle(ctx, db.LockLoginThrottleParams{
+		LockoutMs: lockout.Milliseconds(),
+		Scope:     string(scope),
+		Subject:   subject,
+	})
+	if err != nil {
+		return handlePostgresError(err)
+	}
+	return nil
+}
+
+func (r *postgresLoginThrottleRepository) Reset(ctx context.Context, scope domain.LoginThrottleScope, subject string) error {
+	err := r.queries.DeleteLoginThrottle(ctx, db.DeleteLoginThrottleParams{
+		Scope:   string(scope),
+		Subject: subject,
+	})
+	if err != nil {
+		return handlePostgresError(err)
+	}
+	return nil
+}
+
+func (r *postgresLoginThrottleRepository) PurgeExpired(ctx context.Context, window time.Duration) (int64, error) {
+	purged, err := r.queries.PurgeExpiredLoginThrottles(ctx, window.Milliseconds())
+	if err != nil {
+		return 0, handlePostgresError(err)
+	}
+	return purged, nil
+}
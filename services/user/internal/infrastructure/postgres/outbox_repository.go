@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	db "github.com/lot-koichi/sre-skill-up-project/services/user/db/sqlc/generated"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+)
+
+type postgresOutboxRepository struct {
+	db      *sql.DB
+	queries *db.Queries
+}
+
+// NewOutboxRepository creates a new PostgreSQL outbox repository
+func NewOutboxRepository(database *sql.DB) repository.OutboxRepository {
+	return &postgresOutboxRepository{
+		db:      database,
+		queries: newQueries(database),
+	}
+}
+
+func (r *postgresOutboxRepository) ClaimPending(ctx context.Context, batchSize int32, lease time.Duration) ([]*domain.OutboxEvent, error) {
+	events, err := r.queries.ClaimOutboxEvents(ctx, toClaimOutboxEventsParams(batchSize, lease))
+	if err != nil {
+		return nil, handlePostgresError(err)
+	}
+
+	// UPDATE ... RETURNING は順序を保証しないため seq_id 順に並べ直す
+	sort.Slice(events, func(i, j int) bool { return events[i].SeqID < events[j].SeqID })
+	return toDomainOutboxEvents(events), nil
+}
+
+func (r *postgresOutboxRepository) MarkPublished(ctx context.Context, seqID int64) error {
+	if err := r.queries.MarkOutboxEventPublished(ctx, seqID); err != nil {
+		return handlePostgresError(err)
+	}
+	return nil
+}
+
+func (r *postgresOutboxRepository) MarkFailed(ctx context.Context, seqID int64, reason string) error {
+	params := db.MarkOutboxEventFailedParams{
+		SeqID:       seqID,
+		ErrorReason: toNullString(reason),
+	}
+	if err := r.queries.MarkOutboxEventFailed(ctx, params); err != nil {
+		return handlePostgresError(err)
+	}
+	return nil
+}
+
+func (r *postgresOutboxRepository) ScheduleRetry(ctx context.Context, seqID int64, reason string, delay time.Duration) error {
+	params := db.ScheduleOutboxEventRetryParams{
+		DelayMs:     delay.Milliseconds(),
+		ErrorReason: toNullString(reason),
+		SeqID:       seqID,
+	}
+	if err := r.queries.ScheduleOutboxEventRetry(ctx, params); err != nil {
+		return handlePostgresError(err)
+	}
+	return nil
+}
+
+// insertUserEvent writes a user event to the outbox using the given (transactional) queries
+func insertUserEvent(ctx context.Context, q *db.Queries, eventType domain.EventType, user *domain.User) error {
+	event, err := domain.NewUserEvent(eventType, user)
+	if err != nil {
+		return err
+	}
+
+	if _, err := q.InsertOutboxEvent(ctx, toInsertOutboxEventParams(event)); err != nil {
+		return handlePostgresError(err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	db "github.com/lot-koichi/sre-skill-up-project/services/user/db/sqlc/generated"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+)
+
+type postgresPasswordResetTokenRepository struct {
+	queries *db.Queries
+}
+
+// NewPasswordResetTokenRepository creates a new PostgreSQL password reset token repository
+func NewPasswordResetTokenRepository(database *sql.DB) repository.PasswordResetTokenRepository {
+	return &postgresPasswordResetTokenRepository{
+		queries: newQueries(database),
+	}
+}
+
+func (r *postgresPasswordResetTokenRepository) Create(ctx context.Context, token *domain.PasswordResetToken) error {
+	created, err := r.queries.CreatePasswordResetToken(ctx, toCreatePasswordResetTokenParams(token))
+	if err != nil {
+		return handlePostgresError(err)
+	}
+	token.CreatedAt = created.CreatedAt
+	return nil
+}
+
+func (r *postgresPasswordResetTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	token, err := r.queries.GetPasswordResetTokenByHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, handlePostgresError(err)
+	}
+	return toDomainPasswordResetToken(token), nil
+}
+
+func (r *postgresPasswordResetTokenRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	rows, err := r.queries.UsePasswordResetToken(ctx, id)
+	if err != nil {
+		return handlePostgresError(err)
+	}
+	// 既に使用済みの場合は 0 件になる（同一トークンの同時使用を防ぐ）
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *postgresPasswordResetTokenRepository) InvalidateAllForUser(ctx context.Context, userID uuid.UUID) error {
+	if err := r.queries.InvalidateUserPasswordResetTokens(ctx, userID); err != nil {
+		return handlePostgresError(err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	db "github.com/lot-koichi/sre-skill-up-project/services/user/db/sqlc/generated"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+)
+
+type postgresRefreshTokenRepository struct {
+	queries *db.Queries
+}
+
+// NewRefreshTokenRepository creates a new PostgreSQL refresh token repository
+func NewRefreshTokenRepository(database *sql.DB) repository.RefreshTokenRepository {
+	return &postgresRefreshTokenRepository{
+		queries: newQueries(database),
+	}
+}
+
+func (r *postgresRefreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	created, err := r.queries.CreateRefreshToken(ctx, toCreateRefreshTokenParams(token))
+	if err != nil {
+		return handlePostgresError(err)
+	}
+	token.CreatedAt = created.CreatedAt
+	return nil
+}
+
+func (r *postgresRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	token, err := r.queries.GetRefreshTokenByHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, handlePostgresError(err)
+	}
+	return toDomainRefreshToken(token), nil
+}
+
+func (r *postgresRefreshTokenRepository) Revoke(ctx context.Context, id uuid.UUID) error {
+	rows, err := r.queries.RevokeRefreshToken(ctx, id)
+	if err != nil {
+		return handlePostgresError(err)
+	}
+	// 既に失効済みの場合は 0 件になる（同時リフレッシュの検出に使う）
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *postgresRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID uuid.UUID) error {
+	if err := r.queries.RevokeUserRefreshTokens(ctx, userID); err != nil {
+		return handlePostgresError(err)
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/lot-koichi/sre-skill-up-project/services/user/db/sqlc/generated"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newQueries creates sqlc queries whose statements each run in a client span
+func newQueries(dbtx db.DBTX) *db.Queries {
+	return db.New(tracedDB{next: dbtx})
+}
+
+// tracedDB starts a span for every statement sent through it.
+// Only the SQL text with placeholders is recorded, never the arguments, which may hold emails and password hashes.
+type tracedDB struct {
+	next db.DBTX
+}
+
+func (t tracedDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	result, err := t.next.ExecContext(ctx, query, args...)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (t tracedDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	stmt, err := t.next.PrepareContext(ctx, query)
+	tracing.End(span, err)
+	return stmt, err
+}
+
+// QueryContext's span ends when the query returns its first rows, not when they have all been read
+func (t tracedDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	rows, err := t.next.QueryContext(ctx, query, args...)
+	tracing.End(span, err)
+	return rows, err
+}
+
+func (t tracedDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, span := startQuerySpan(ctx, query)
+	row := t.next.QueryRowContext(ctx, query, args...)
+	// sql.ErrNoRows は Scan 時に返るため、ここで記録されるのはクエリ自体の失敗のみ
+	tracing.End(span, row.Err())
+	return row
+}
+
+// startQuerySpan names the span after the sqlc query ("-- name: GetUserByID :one"), or the SQL command for other statements
+func startQuerySpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	operation := queryName(query)
+	return tracing.Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(queryAttributes(operation, query)...),
+	)
+}
+
+func queryAttributes(operation, query string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		semconv.DBSystemNamePostgreSQL,
+		semconv.DBOperationName(operation),
+		semconv.DBQueryText(query),
+	}
+}
+
+// queryName returns the name sqlc puts in the first line of every generated query, or the first word of the statement
+func queryName(query string) string {
+	query = strings.TrimSpace(query)
+	if rest, ok := strings.CutPrefix(query, "-- name: "); ok {
+		if name, _, ok := strings.Cut(rest, " "); ok {
+			return name
+		}
+	}
+	command, _, _ := strings.Cut(query, " ")
+	return strings.ToUpper(command)
+}
+
+// tracedUserRepository starts a span around every UserRepository method; the statements it runs are child spans
+type tracedUserRepository struct {
+	next repository.UserRepository
+}
+
+func (r *tracedUserRepository) Create(ctx context.Context, user *domain.User) error {
+	ctx, span := tracing.Start(ctx, "UserRepository.Create")
+	err := r.next.Create(ctx, user)
+	tracing.End(span, err)
+	return err
+}
+
+func (r *tracedUserRepository) CreateBatch(ctx context.Context, users []*domain.User) error {
+	ctx, span := tracing.Start(ctx, "UserRepository.CreateBatch")
+	err := r.next.CreateBatch(ctx, users)
+	tracing.End(span, err)
+	return err
+}
+
+func (r *tracedUserRepository) ListTakenEmails(ctx context.Context, emails []domain.Email) ([]domain.Email, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.ListTakenEmails")
+	result, err := r.next.ListTakenEmails(ctx, emails)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (r *tracedUserRepository) ListUsers(ctx context.Context, filter domain.UserFilter, sort domain.UserSort, limit int32, offset int32) ([]*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.ListUsers")
+	result, err := r.next.ListUsers(ctx, filter, sort, limit, offset)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (r *tracedUserRepository) ListUsersByCursor(ctx context.Context, filter domain.UserFilter, cursor domain.UserCursor, limit int32) ([]*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.ListUsersByCursor")
+	result, err := r.next.ListUsersByCursor(ctx, filter, cursor, limit)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (r *tracedUserRepository) CountUsers(ctx context.Context, filter domain.UserFilter) (count int64, estimated bool, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.CountUsers")
+	count, estimated, err = r.next.CountUsers(ctx, filter)
+	tracing.End(span, err)
+	return count, estimated, err
+}
+
+func (r *tracedUserRepository) SearchUsers(ctx context.Context, query string, limit int32, offset int32) ([]*domain.UserSearchHit, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.SearchUsers")
+	result, err := r.next.SearchUsers(ctx, query, limit, offset)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (r *tracedUserRepository) CountSearchUsers(ctx context.Context, query string) (int64, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.CountSearchUsers")
+	result, err := r.next.CountSearchUsers(ctx, query)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (r *tracedUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.GetByID")
+	result, err := r.next.GetByID(ctx, id)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (r *tracedUserRepository) GetByEmail(ctx context.Context, email domain.Email) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.GetByEmail")
+	result, err := r.next.GetByEmail(ctx, email)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (r *tracedUserRepository) Update(ctx context.Context, user *domain.User) error {
+	ctx, span := tracing.Start(ctx, "UserRepository.Update")
+	err := r.next.Update(ctx, user)
+	tracing.End(span, err)
+	return err
+}
+
+func (r *tracedUserRepository) Patch(ctx context.Context, user *domain.User, changes domain.UserPatch) error {
+	ctx, span := tracing.Start(ctx, "UserRepository.Patch")
+	err := r.next.Patch(ctx, user, changes)
+	tracing.End(span, err)
+	return err
+}
+
+func (r *tracedUserRepository) UpdatePassword(ctx context.Context, id uuid.UUID, hashedPassword domain.Password) error {
+	ctx, span := tracing.Start(ctx, "UserRepository.UpdatePassword")
+	err := r.next.UpdatePassword(ctx, id, hashedPassword)
+	tracing.End(span, err)
+	return err
+}
+
+func (r *tracedUserRepository) MarkEmailVerified(ctx context.Context, id uuid.UUID, email domain.Email) error {
+	ctx, span := tracing.Start(ctx, "UserRepository.MarkEmailVerified")
+	err := r.next.MarkEmailVerified(ctx, id, email)
+	tracing.End(span, err)
+	return err
+}
+
+func (r *tracedUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "UserRepository.Delete")
+	err := r.next.Delete(ctx, id)
+	tracing.End(span, err)
+	return err
+}
+
+func (r *tracedUserRepository) Restore(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Restore")
+	result, err := r.next.Restore(ctx, id)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (r *tracedUserRepository) PurgeDeleted(ctx context.Context, retention time.Duration) (int64, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.PurgeDeleted")
+	result, err := r.next.PurgeDeleted(ctx, retention)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (r *tracedUserRepository) GrantRole(ctx context.Context, userID uuid.UUID, role domain.Role) error {
+	ctx, span := tracing.Start(ctx, "UserRepository.GrantRole")
+	err := r.next.GrantRole(ctx, userID, role)
+	tracing.End(span, err)
+	return err
+}
+
+func (r *tracedUserRepository) RevokeRole(ctx context.Context, userID uuid.UUID, role domain.Role) error {
+	ctx, span := tracing.Start(ctx, "UserRepository.RevokeRole")
+	err := r.next.RevokeRole(ctx, userID, role)
+	tracing.End(span, err)
+	return err
+}
@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	db "github.com/lot-koichi/sre-skill-up-project/services/user/db/sqlc/generated"
+)
+
+// withTx runs fn inside a single transaction and commits it only when fn succeeds
+func withTx(ctx context.Context, database *sql.DB, fn func(q *db.Queries) error) error {
+	return withSQLTx(ctx, database, func(tx *sql.Tx) error {
+		return fn(newQueries(tx))
+	})
+}
+
+// withSQLTx is withTx for statements that sqlc cannot generate, such as COPY
+func withSQLTx(ctx context.Context, database *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -3,24 +3,37 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
 	db "github.com/lot-koichi/sre-skill-up-project/services/user/db/sqlc/generated"
 	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
 	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+	"go.uber.org/zap"
 )
 
+// userCountEstimateThreshold is the row estimate above which unfiltered counts are not exact
+const userCountEstimateThreshold = 100_000
+
 type postgresUserRepository struct {
 	db      *sql.DB
 	queries *db.Queries
+	logger  logger.Logger
 }
 
-// NewUserRepository creates a new PostgreSQL user repository
-func NewUserRepository(database *sql.DB) repository.UserRepository {
-	queries := db.New(database)
-	return &postgresUserRepository{
-		db:      database,
-		queries: queries,
+// NewUserRepository creates a new PostgreSQL user repository; every method and statement runs in its own span
+func NewUserRepository(database *sql.DB, logger logger.Logger) repository.UserRepository {
+	return &tracedUserRepository{
+		next: &postgresUserRepository{
+			db:      database,
+			queries: newQueries(database),
+			logger:  logger,
+		},
 	}
 }
 
@@ -28,65 +41,347 @@ func (r *postgresUserRepository) Create(ctx context.Context, user *domain.User)
 	// Use converter function for parameters
 	params := toCreateUserParams(user)
 
-	createdUser, err := r.queries.CreateUser(ctx, params)
+	// ユーザー登録と user.created イベントの書き込みを同一トランザクションで行う
+	return withTx(ctx, r.db, func(q *db.Queries) error {
+		createdUser, err := q.CreateUser(ctx, params)
+		if err != nil {
+			return r.handleError(ctx, err)
+		}
+
+		// Update timestamps using converter function
+		updateTimestamps(user, createdUser)
+		return insertUserEvent(ctx, q, domain.EventTypeUserCreated, user)
+	})
+}
+
+func (r *postgresUserRepository) CreateBatch(ctx context.Context, users []*domain.User) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	userRows := make([][]any, 0, len(users))
+	eventRows := make([][]any, 0, len(users))
+	for _, user := range users {
+		event, err := domain.NewUserEvent(domain.EventTypeUserCreated, user)
+		if err != nil {
+			return err
+		}
+		userRows = append(userRows, toUserCopyRow(user))
+		eventRows = append(eventRows, toOutboxEventCopyRow(event))
+	}
+
+	// 1 行ずつの INSERT では遅いため、ユーザーと user.created イベントを COPY で同一トランザクションに書き込む
+	err := withSQLTx(ctx, r.db, func(tx *sql.Tx) error {
+		if err := copyIn(ctx, tx, "users", userCopyColumns, userRows); err != nil {
+			return err
+		}
+		return copyIn(ctx, tx, "outbox_events", outboxEventCopyColumns, eventRows)
+	})
 	if err != nil {
-		return handlePostgresError(err)
+		return err
 	}
 
-	// Update timestamps using converter function
-	updateTimestamps(user, createdUser)
+	// version は列の既定値で始まる
+	for _, user := range users {
+		user.Version = 1
+	}
 	return nil
 }
 
+func (r *postgresUserRepository) ListTakenEmails(ctx context.Context, emails []domain.Email) ([]domain.Email, error) {
+	lowered := make([]string, 0, len(emails))
+	for _, email := range emails {
+		lowered = append(lowered, strings.ToLower(string(email)))
+	}
+
+	taken, err := r.queries.ListLiveUserEmails(ctx, lowered)
+	if err != nil {
+		return nil, r.handleError(ctx, err)
+	}
+	return toDomainEmails(taken), nil
+}
+
 func (r *postgresUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	user, err := r.queries.GetUserByID(ctx, id)
 	if err != nil {
-		return nil, handlePostgresError(err)
+		// 論理削除済みのユーザーも存在しないものとして扱う
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, r.handleError(ctx, err)
 	}
 
 	// Use converter function
-	return toDomainUser(user), nil
+	return r.withRoles(ctx, toDomainUser(user))
 }
 
 func (r *postgresUserRepository) GetByEmail(ctx context.Context, email domain.Email) (*domain.User, error) {
-	user, err := r.queries.GetUserByEmail(ctx, string(email))
+	user, err := r.queries.GetUserByEmail(ctx, string(domain.NormalizeEmail(email)))
 	if err != nil {
-		return nil, handlePostgresError(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, r.handleError(ctx, err)
 	}
-	return toDomainUser(user), nil
+	return r.withRoles(ctx, toDomainUser(user))
 }
 
 func (r *postgresUserRepository) Update(ctx context.Context, user *domain.User) error {
 	// Use converter function for parameters
 	params := toUpdateUserParams(user)
 
-	updatedUser, err := r.queries.UpdateUser(ctx, params)
-	if err != nil {
-		return handlePostgresError(err)
+	return withTx(ctx, r.db, func(q *db.Queries) error {
+		updatedUser, err := q.UpdateUser(ctx, params)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return r.updateConflict(ctx, q, user.ID)
+			}
+			return r.handleError(ctx, err)
+		}
+
+		// Update only UpdatedAt using converter function
+		updateUpdatedAt(user, updatedUser)
+		return insertUserEvent(ctx, q, domain.EventTypeUserUpdated, user)
+	})
+}
+
+func (r *postgresUserRepository) Patch(ctx context.Context, user *domain.User, changes domain.UserPatch) error {
+	params := toPatchUserParams(user, changes)
+
+	return withTx(ctx, r.db, func(q *db.Queries) error {
+		patchedUser, err := q.PatchUser(ctx, params)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return r.updateConflict(ctx, q, user.ID)
+			}
+			return r.handleError(ctx, err)
+		}
+
+		updateUpdatedAt(user, patchedUser)
+		return insertUserEvent(ctx, q, domain.EventTypeUserUpdated, user)
+	})
+}
+
+// updateConflict tells why UpdateUser matched no row: the user is gone, or its version has moved on
+func (r *postgresUserRepository) updateConflict(ctx context.Context, q *db.Queries, id uuid.UUID) error {
+	if _, err := q.GetUserByID(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrUserNotFound
+		}
+		return r.handleError(ctx, err)
 	}
+	return domain.ErrConcurrentModification
+}
 
-	// Update only UpdatedAt using converter function
-	updateUpdatedAt(user, updatedUser)
-	return nil
+func (r *postgresUserRepository) UpdatePassword(ctx context.Context, id uuid.UUID, hashedPassword domain.Password) error {
+	return withTx(ctx, r.db, func(q *db.Queries) error {
+		updatedUser, err := q.UpdateUserPassword(ctx, db.UpdateUserPasswordParams{
+			ID:       id,
+			Password: string(hashedPassword),
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return domain.ErrUserNotFound
+			}
+			return r.handleError(ctx, err)
+		}
+		return insertUserEvent(ctx, q, domain.EventTypeUserUpdated, toDomainUser(updatedUser))
+	})
+}
+
+func (r *postgresUserRepository) MarkEmailVerified(ctx context.Context, id uuid.UUID, email domain.Email) error {
+	return withTx(ctx, r.db, func(q *db.Queries) error {
+		verifiedUser, err := q.MarkUserEmailVerified(ctx, db.MarkUserEmailVerifiedParams{
+			ID:    id,
+			Email: string(domain.NormalizeEmail(email)),
+		})
+		if err != nil {
+			// 削除済み、またはトークンの発行後にメールアドレスが変更されている
+			if errors.Is(err, sql.ErrNoRows) {
+				return domain.ErrUserNotFound
+			}
+			return r.handleError(ctx, err)
+		}
+		return insertUserEvent(ctx, q, domain.EventTypeUserUpdated, toDomainUser(verifiedUser))
+	})
 }
 
 func (r *postgresUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	err := r.queries.DeleteUser(ctx, id)
+	return withTx(ctx, r.db, func(q *db.Queries) error {
+		deletedUser, err := q.SoftDeleteUser(ctx, id)
+		if err != nil {
+			// 存在しない（または削除済みの）ユーザーの削除はエラーにせず、イベントも発行しない
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return r.handleError(ctx, err)
+		}
+		return insertUserEvent(ctx, q, domain.EventTypeUserDeleted, toDomainUser(deletedUser))
+	})
+}
+
+func (r *postgresUserRepository) Restore(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	var restored *domain.User
+	err := withTx(ctx, r.db, func(q *db.Queries) error {
+		restoredUser, err := q.RestoreUser(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return domain.ErrUserNotFound
+			}
+			// 削除後に同じメールアドレスで登録されたユーザーがいる場合は一意制約違反になる
+			return r.handleError(ctx, err)
+		}
+		restored = toDomainUser(restoredUser)
+		return insertUserEvent(ctx, q, domain.EventTypeUserRestored, restored)
+	})
 	if err != nil {
-		return handlePostgresError(err)
+		return nil, err
 	}
-	return nil
+	return r.withRoles(ctx, restored)
 }
 
-func (r *postgresUserRepository) ListUsers(ctx context.Context, limit int32, offset int32) ([]*domain.User, error) {
+func (r *postgresUserRepository) PurgeDeleted(ctx context.Context, retention time.Duration) (int64, error) {
+	purged, err := r.queries.PurgeDeletedUsers(ctx, retention.Milliseconds())
+	if err != nil {
+		return 0, r.handleError(ctx, err)
+	}
+	return purged, nil
+}
+
+func (r *postgresUserRepository) ListUsers(ctx context.Context, filter domain.UserFilter, sort domain.UserSort, limit int32, offset int32) ([]*domain.User, error) {
 	// Use converter function for parameters
-	params := toListUsersParams(limit, offset)
+	params := toListUsersParams(filter, sort, limit, offset)
 
 	users, err := r.queries.ListUsers(ctx, params)
 	if err != nil {
-		return nil, handlePostgresError(err)
+		return nil, r.handleError(ctx, err)
 	}
 
 	// Use converter function for batch conversion
-	return toDomainUsers(users), nil
+	return r.withRolesBatch(ctx, toDomainUsers(users))
+}
+
+func (r *postgresUserRepository) ListUsersByCursor(ctx context.Context, filter domain.UserFilter, cursor domain.UserCursor, limit int32) ([]*domain.User, error) {
+	var (
+		users []db.User
+		err   error
+	)
+	switch cursor.Direction {
+	case domain.CursorNext:
+		users, err = r.queries.ListUsersAfter(ctx, toListUsersAfterParams(filter, cursor, limit))
+	case domain.CursorPrev:
+		users, err = r.queries.ListUsersBefore(ctx, toListUsersBeforeParams(filter, cursor, limit))
+		// 古い順に取得しているため新しい順に戻す
+		slices.Reverse(users)
+	default:
+		return nil, domain.ErrInvalidCursor
+	}
+	if err != nil {
+		return nil, r.handleError(ctx, err)
+	}
+
+	return r.withRolesBatch(ctx, toDomainUsers(users))
+}
+
+func (r *postgresUserRepository) SearchUsers(ctx context.Context, query string, limit int32, offset int32) ([]*domain.UserSearchHit, error) {
+	rows, err := r.queries.SearchUsers(ctx, toSearchUsersParams(query, limit, offset))
+	if err != nil {
+		return nil, r.handleError(ctx, err)
+	}
+
+	hits := toDomainUserSearchHits(rows)
+	users := make([]*domain.User, 0, len(hits))
+	for _, hit := range hits {
+		users = append(users, hit.User)
+	}
+	if _, err := r.withRolesBatch(ctx, users); err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
+
+func (r *postgresUserRepository) CountSearchUsers(ctx context.Context, query string) (int64, error) {
+	count, err := r.queries.CountSearchUsers(ctx, toCountSearchUsersParams(query))
+	if err != nil {
+		return 0, r.handleError(ctx, err)
+	}
+	return count, nil
+}
+
+func (r *postgresUserRepository) CountUsers(ctx context.Context, filter domain.UserFilter) (int64, bool, error) {
+	if filter.IsEmpty() {
+		// 大きなテーブルの COUNT(*) は全件走査になるため統計情報の概算値で代用する
+		estimate, err := r.queries.EstimateUserCount(ctx)
+		if err != nil {
+			return 0, false, r.handleError(ctx, err)
+		}
+		if estimate >= userCountEstimateThreshold {
+			return estimate, true, nil
+		}
+	}
+
+	count, err := r.queries.CountUsers(ctx, toCountUsersParams(filter))
+	if err != nil {
+		return 0, false, r.handleError(ctx, err)
+	}
+	return count, false, nil
+}
+
+func (r *postgresUserRepository) GrantRole(ctx context.Context, userID uuid.UUID, role domain.Role) error {
+	err := r.queries.GrantUserRole(ctx, db.GrantUserRoleParams{
+		UserID: userID,
+		Role:   string(role),
+	})
+	if err != nil {
+		return r.handleError(ctx, err)
+	}
+	return nil
+}
+
+func (r *postgresUserRepository) RevokeRole(ctx context.Context, userID uuid.UUID, role domain.Role) error {
+	err := r.queries.RevokeUserRole(ctx, db.RevokeUserRoleParams{
+		UserID: userID,
+		Role:   string(role),
+	})
+	if err != nil {
+		return r.handleError(ctx, err)
+	}
+	return nil
+}
+
+// withRolesBatch loads the roles of all users with a single query to avoid N+1
+func (r *postgresUserRepository) withRolesBatch(ctx context.Context, users []*domain.User) ([]*domain.User, error) {
+	ids := make([]uuid.UUID, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	rows, err := r.queries.ListUserRolesByUserIDs(ctx, ids)
+	if err != nil {
+		return nil, r.handleError(ctx, err)
+	}
+	assignRoles(users, rows)
+	return users, nil
+}
+
+// withRoles loads the roles of the user
+func (r *postgresUserRepository) withRoles(ctx context.Context, user *domain.User) (*domain.User, error) {
+	roles, err := r.queries.ListUserRoles(ctx, user.ID)
+	if err != nil {
+		return nil, r.handleError(ctx, err)
+	}
+	user.Roles = toDomainRoles(roles)
+	return user, nil
+}
+
+// handleError converts err with handlePostgresError; the SQLSTATE and constraint that the domain error drops are logged for diagnosis
+func (r *postgresUserRepository) handleError(ctx context.Context, err error) error {
+	var pgErr *pq.Error
+	if errors.As(err, &pgErr) {
+		// Detail は値（メールアドレスなど）を含むため出力しない
+		r.logger.Debug(ctx, "PostgreSQL error",
+			zap.String("sqlstate", string(pgErr.Code)),
+			zap.String("table", pgErr.Table),
+			zap.String("constraint", pgErr.Constraint))
+	}
+	return handlePostgresError(err)
 }
@@ -3,6 +3,7 @@ package postgres_test
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
@@ -14,6 +15,7 @@ import (
 	postgresDriver "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
 	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
 	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/infrastructure/postgres"
 	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
@@ -47,7 +49,7 @@ func (suite *UserRepositoryTestSuite) SetupSuite() {
 	}
 
 	suite.db = db
-	suite.repo = postgres.NewUserRepository(db)
+	suite.repo = postgres.NewUserRepository(db, logger.NewNop())
 
 	// マイグレーションを実行
 	suite.setupTestTable()
@@ -106,6 +108,25 @@ func (suite *UserRepositoryTestSuite) setupTestTable() {
 func (suite *UserRepositoryTestSuite) cleanupTestData() {
 	_, err := suite.db.Exec("DELETE FROM users")
 	require.NoError(suite.T(), err)
+	_, err = suite.db.Exec("DELETE FROM outbox_events")
+	require.NoError(suite.T(), err)
+	_, err = suite.db.Exec("DELETE FROM login_throttles")
+	require.NoError(suite.T(), err)
+	_, err = suite.db.Exec("DELETE FROM idempotency_keys")
+	require.NoError(suite.T(), err)
+	_, err = suite.db.Exec("DELETE FROM audit_events")
+	require.NoError(suite.T(), err)
+}
+
+// 指定したイベント種別・ユーザーIDの outbox_events 行数を取得
+func (suite *UserRepositoryTestSuite) countOutboxEvents(eventType domain.EventType, userID uuid.UUID) int {
+	var count int
+	err := suite.db.QueryRow(
+		"SELECT COUNT(*) FROM outbox_events WHERE event_type = $1 AND payload->>'user_id' = $2 AND status = 'pending'",
+		string(eventType), userID.String(),
+	).Scan(&count)
+	require.NoError(suite.T(), err)
+	return count
 }
 
 // テスト: Create
@@ -177,6 +198,63 @@ func (suite *UserRepositoryTestSuite) TestCreate() {
 	}
 }
 
+// テスト: CreateBatch
+func (suite *UserRepositoryTestSuite) TestCreateBatch() {
+	ctx := context.Background()
+
+	suite.Run("正常系:ユーザーとイベントを一括作成", func() {
+		users := []*domain.User{
+			domain.NewUser("batch1@example.com", "batchPass123", "Batch One"),
+			domain.NewUser("batch2@example.com", "batchPass123", "山田花子"),
+		}
+
+		err := suite.repo.CreateBatch(ctx, users)
+		require.NoError(suite.T(), err)
+
+		for _, user := range users {
+			assert.Equal(suite.T(), int64(1), user.Version)
+			got, err := suite.repo.GetByID(ctx, user.ID)
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), user.Email, got.Email)
+			assert.Equal(suite.T(), user.Name, got.Name)
+			assert.Equal(suite.T(), 1, suite.countOutboxEvents(domain.EventTypeUserCreated, user.ID))
+		}
+	})
+
+	suite.Run("異常系:重複したEmailがあれば全件ロールバック", func() {
+		existing := domain.NewUser("batch-dup@example.com", "batchPass123", "Existing")
+		require.NoError(suite.T(), suite.repo.Create(ctx, existing))
+
+		fresh := domain.NewUser("batch-fresh@example.com", "batchPass123", "Fresh")
+		err := suite.repo.CreateBatch(ctx, []*domain.User{
+			fresh,
+			domain.NewUser("Batch-Dup@example.com", "batchPass123", "Duplicate"),
+		})
+
+		assert.ErrorIs(suite.T(), err, domain.ErrDuplicateEmail)
+		_, err = suite.repo.GetByID(ctx, fresh.ID)
+		assert.ErrorIs(suite.T(), err, domain.ErrUserNotFound)
+		assert.Equal(suite.T(), 0, suite.countOutboxEvents(domain.EventTypeUserCreated, fresh.ID))
+	})
+}
+
+// テスト: ListTakenEmails
+func (suite *UserRepositoryTestSuite) TestListTakenEmails() {
+	ctx := context.Background()
+
+	live := domain.NewUser("taken@example.com", "takenPass123", "Taken")
+	require.NoError(suite.T(), suite.repo.Create(ctx, live))
+	deleted := domain.NewUser("deleted@example.com", "deletedPass123", "Deleted")
+	require.NoError(suite.T(), suite.repo.Create(ctx, deleted))
+	require.NoError(suite.T(), suite.repo.Delete(ctx, deleted.ID))
+
+	// 大文字小文字を区別せず、論理削除済みのユーザーは含めない
+	taken, err := suite.repo.ListTakenEmails(ctx, []domain.Email{"Taken@Example.com", "deleted@example.com", "free@example.com"})
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []domain.Email{"taken@example.com"}, taken)
+}
+
 // テスト: GetByID
 func (suite *UserRepositoryTestSuite) TestGetByID() {
 	// テストデータを事前作成
@@ -289,7 +367,7 @@ func (suite *UserRepositoryTestSuite) TestListUsers() {
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
 			// テスト実行
-			result, err := suite.repo.ListUsers(context.Background(), tc.limit, tc.offset)
+			result, err := suite.repo.ListUsers(context.Background(), domain.UserFilter{}, domain.DefaultUserSort(), tc.limit, tc.offset)
 
 			assert.NoError(suite.T(), err)
 			assert.Len(suite.T(), result, tc.wantCount)
@@ -305,6 +383,284 @@ func (suite *UserRepositoryTestSuite) TestListUsers() {
 	}
 }
 
+// テスト: ListUsersByCursor
+func (suite *UserRepositoryTestSuite) TestListUsersByCursor() {
+	ctx := context.Background()
+	for i := 1; i <= 5; i++ {
+		user := &domain.User{
+			Email:    domain.Email(fmt.Sprintf("cursor%d@example.com", i)),
+			Password: domain.Password("cursorPass"),
+			Name:     domain.Name(fmt.Sprintf("Cursor User %d", i)),
+		}
+		require.NoError(suite.T(), suite.repo.Create(ctx, user))
+	}
+	// 同時刻のユーザーも id で順序付けられること
+	_, err := suite.db.Exec("UPDATE users SET created_at = '2025-01-01T00:00:00Z' WHERE email IN ('cursor2@example.com', 'cursor3@example.com')")
+	require.NoError(suite.T(), err)
+
+	all, err := suite.repo.ListUsers(ctx, domain.UserFilter{}, domain.DefaultUserSort(), 100, 0)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), all, 5)
+
+	ids := func(users []*domain.User) []uuid.UUID {
+		result := make([]uuid.UUID, 0, len(users))
+		for _, user := range users {
+			result = append(result, user.ID)
+		}
+		return result
+	}
+
+	suite.Run("次のページはカーソルより古いユーザー", func() {
+		result, err := suite.repo.ListUsersByCursor(ctx, domain.UserFilter{}, domain.NextUserCursor(all[1]), 2)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), ids(all[2:4]), ids(result))
+	})
+
+	suite.Run("前のページはカーソルより新しいユーザーを新しい順で返す", func() {
+		result, err := suite.repo.ListUsersByCursor(ctx, domain.UserFilter{}, domain.PrevUserCursor(all[4]), 2)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), ids(all[2:4]), ids(result))
+	})
+
+	suite.Run("カーソルで辿るとオフセットと同じ順序になる", func() {
+		var walked []*domain.User
+		page, err := suite.repo.ListUsers(ctx, domain.UserFilter{}, domain.DefaultUserSort(), 2, 0)
+		require.NoError(suite.T(), err)
+		for len(page) > 0 {
+			walked = append(walked, page...)
+			page, err = suite.repo.ListUsersByCursor(ctx, domain.UserFilter{}, domain.NextUserCursor(page[len(page)-1]), 2)
+			require.NoError(suite.T(), err)
+		}
+		assert.Equal(suite.T(), ids(all), ids(walked))
+	})
+}
+
+// テスト: ListUsers のフィルタ・並び順と CountUsers
+func (suite *UserRepositoryTestSuite) TestListUsersFilterAndSort() {
+	ctx := context.Background()
+	// 照合順序に依存しないよう、並び順の比較は先頭の異なる文字で決まる値にする
+	for name, email := range map[string]string{
+		"Alan":  "alan@filter.example.com",
+		"Bella": "bella@filter.example.com",
+		"Carol": "carol@filter.example.com",
+		"Dana":  "dana@filter.example.com",
+		"Dave":  "d_ave@filter.example.com",
+	} {
+		user := &domain.User{
+			Email:    domain.Email(email),
+			Password: domain.Password("filterPass"),
+			Name:     domain.Name(name),
+		}
+		require.NoError(suite.T(), suite.repo.Create(ctx, user))
+	}
+	_, err := suite.db.Exec("UPDATE users SET created_at = '2024-01-01T00:00:00Z' WHERE name IN ('Alan', 'Carol')")
+	require.NoError(suite.T(), err)
+
+	names := func(users []*domain.User) []string {
+		result := make([]string, 0, len(users))
+		for _, user := range users {
+			result = append(result, string(user.Name))
+		}
+		return result
+	}
+	boundary := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	nameAsc := domain.UserSort{Field: domain.UserSortName, Order: domain.SortAsc}
+
+	testCases := []struct {
+		name      string
+		filter    domain.UserFilter
+		sort      domain.UserSort
+		wantNames []string
+	}{
+		{
+			name:      "正常系:名前の昇順",
+			sort:      nameAsc,
+			wantNames: []string{"Alan", "Bella", "Carol", "Dana", "Dave"},
+		},
+		{
+			name:      "正常系:名前の降順",
+			sort:      domain.UserSort{Field: domain.UserSortName, Order: domain.SortDesc},
+			wantNames: []string{"Dave", "Dana", "Carol", "Bella", "Alan"},
+		},
+		{
+			name:      "正常系:作成日時より前で絞り込みメールアドレスの降順",
+			filter:    domain.UserFilter{CreatedTo: &boundary},
+			sort:      domain.UserSort{Field: domain.UserSortEmail, Order: domain.SortDesc},
+			wantNames: []string{"Carol", "Alan"},
+		},
+		{
+			name:      "正常系:作成日時の下限",
+			filter:    domain.UserFilter{CreatedFrom: &boundary},
+			sort:      nameAsc,
+			wantNames: []string{"Bella", "Dana", "Dave"},
+		},
+		{
+			name:      "正常系:名前の前方一致は大文字小文字を区別しない",
+			filter:    domain.UserFilter{NamePrefix: "da"},
+			sort:      nameAsc,
+			wantNames: []string{"Dana", "Dave"},
+		},
+		{
+			name:      "正常系:前方一致のワイルドカードはエスケープされる",
+			filter:    domain.UserFilter{EmailPrefix: "d_"},
+			sort:      nameAsc,
+			wantNames: []string{"Dave"},
+		},
+		{
+			name:      "正常系:該当なし",
+			filter:    domain.UserFilter{NamePrefix: "Zed"},
+			sort:      nameAsc,
+			wantNames: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			result, err := suite.repo.ListUsers(ctx, tc.filter, tc.sort, 100, 0)
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tc.wantNames, names(result))
+
+			count, estimated, err := suite.repo.CountUsers(ctx, tc.filter)
+			require.NoError(suite.T(), err)
+			assert.False(suite.T(), estimated)
+			assert.Equal(suite.T(), int64(len(tc.wantNames)), count)
+		})
+	}
+
+	suite.Run("正常系:カーソルにもフィルタが適用される", func() {
+		filter := domain.UserFilter{CreatedFrom: &boundary}
+		first, err := suite.repo.ListUsers(ctx, filter, domain.DefaultUserSort(), 1, 0)
+		require.NoError(suite.T(), err)
+		require.Len(suite.T(), first, 1)
+
+		rest, err := suite.repo.ListUsersByCursor(ctx, filter, domain.NextUserCursor(first[0]), 100)
+		require.NoError(suite.T(), err)
+		assert.Len(suite.T(), rest, 2)
+	})
+
+	suite.Run("正常系:論理削除済みのユーザーは数えない", func() {
+		user, err := suite.repo.GetByEmail(ctx, domain.Email("alan@filter.example.com"))
+		require.NoError(suite.T(), err)
+		require.NoError(suite.T(), suite.repo.Delete(ctx, user.ID))
+
+		count, _, err := suite.repo.CountUsers(ctx, domain.UserFilter{CreatedTo: &boundary})
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(1), count)
+	})
+}
+
+// テスト: SearchUsers / CountSearchUsers
+func (suite *UserRepositoryTestSuite) TestSearchUsers() {
+	ctx := context.Background()
+	created := map[string]*domain.User{}
+	for name, email := range map[string]string{
+		"Alice":       "alice@example.com",
+		"Alicia":      "alicia@example.com",
+		"Bob Builder": "bob@example.com",
+		"Dave":        "d_ave@example.com",
+	} {
+		user := &domain.User{
+			Email:    domain.Email(email),
+			Password: domain.Password("searchPass"),
+			Name:     domain.Name(name),
+		}
+		require.NoError(suite.T(), suite.repo.Create(ctx, user))
+		created[name] = user
+	}
+
+	names := func(hits []*domain.UserSearchHit) []string {
+		result := make([]string, 0, len(hits))
+		for _, hit := range hits {
+			result = append(result, string(hit.User.Name))
+		}
+		return result
+	}
+
+	suite.Run("正常系:部分一致したユーザーを類似度の高い順に返す", func() {
+		hits, err := suite.repo.SearchUsers(ctx, "alic", 10, 0)
+		require.NoError(suite.T(), err)
+		assert.ElementsMatch(suite.T(), []string{"Alice", "Alicia"}, names(hits))
+		assert.GreaterOrEqual(suite.T(), hits[0].Score, hits[1].Score)
+		for _, hit := range hits {
+			assert.Greater(suite.T(), hit.Score, 0.0)
+			assert.NotNil(suite.T(), hit.User.Roles)
+		}
+
+		count, err := suite.repo.CountSearchUsers(ctx, "alic")
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(2), count)
+	})
+
+	suite.Run("正常系:メールアドレスでも検索できる", func() {
+		hits, err := suite.repo.SearchUsers(ctx, "bob@", 10, 0)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), []string{"Bob Builder"}, names(hits))
+	})
+
+	suite.Run("正常系:綴りの誤りも類似度で検索できる", func() {
+		hits, err := suite.repo.SearchUsers(ctx, "Alise", 10, 0)
+		require.NoError(suite.T(), err)
+		require.NotEmpty(suite.T(), hits)
+		assert.Equal(suite.T(), "Alice", string(hits[0].User.Name))
+	})
+
+	suite.Run("正常系:ワイルドカードはエスケープされる", func() {
+		hits, err := suite.repo.SearchUsers(ctx, "_", 10, 0)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), []string{"Dave"}, names(hits))
+	})
+
+	suite.Run("正常系:ページネーション", func() {
+		first, err := suite.repo.SearchUsers(ctx, "alic", 1, 0)
+		require.NoError(suite.T(), err)
+		second, err := suite.repo.SearchUsers(ctx, "alic", 1, 1)
+		require.NoError(suite.T(), err)
+		require.Len(suite.T(), first, 1)
+		require.Len(suite.T(), second, 1)
+		assert.NotEqual(suite.T(), first[0].User.ID, second[0].User.ID)
+	})
+
+	suite.Run("正常系:論理削除済みのユーザーは検索しない", func() {
+		require.NoError(suite.T(), suite.repo.Delete(ctx, created["Alicia"].ID))
+
+		hits, err := suite.repo.SearchUsers(ctx, "alic", 10, 0)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), []string{"Alice"}, names(hits))
+
+		count, err := suite.repo.CountSearchUsers(ctx, "alic")
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(1), count)
+	})
+}
+
+// テスト: GetByEmail
+func (suite *UserRepositoryTestSuite) TestGetByEmail() {
+	ctx := context.Background()
+	carol := &domain.User{Email: domain.Email("Carol@example.com"), Password: domain.Password("lookupPass"), Name: domain.Name("Carol")}
+	require.NoError(suite.T(), suite.repo.Create(ctx, carol))
+
+	suite.Run("正常系:大文字小文字を区別せずに検索できる", func() {
+		user, err := suite.repo.GetByEmail(ctx, domain.Email(" CAROL@Example.COM "))
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), carol.ID, user.ID)
+		assert.Equal(suite.T(), domain.Email("Carol@example.com"), user.Email)
+		assert.NotNil(suite.T(), user.Roles)
+	})
+
+	suite.Run("異常系:大文字小文字だけが異なるメールアドレスは登録できない", func() {
+		dup := &domain.User{Email: domain.Email("carol@EXAMPLE.com"), Password: domain.Password("lookupPass"), Name: domain.Name("Carol Dup")}
+		err := suite.repo.Create(ctx, dup)
+		assert.ErrorIs(suite.T(), err, domain.ErrDuplicateEmail)
+	})
+
+	suite.Run("異常系:論理削除済みのユーザーは検索しない", func() {
+		require.NoError(suite.T(), suite.repo.Delete(ctx, carol.ID))
+
+		_, err := suite.repo.GetByEmail(ctx, domain.Email("carol@example.com"))
+		assert.ErrorIs(suite.T(), err, domain.ErrUserNotFound)
+	})
+}
+
 // テスト: Update
 func (suite *UserRepositoryTestSuite) TestUpdate() {
 	// テストデータを事前作成
@@ -395,6 +751,127 @@ func (suite *UserRepositoryTestSuite) TestUpdate() {
 	}
 }
 
+// テスト: 楽観的排他制御
+func (suite *UserRepositoryTestSuite) TestUpdateVersion() {
+	ctx := context.Background()
+	user := &domain.User{
+		Email:    domain.Email("version@example.com"),
+		Password: domain.Password("versionPass"),
+		Name:     domain.Name("Version User"),
+	}
+	require.NoError(suite.T(), suite.repo.Create(ctx, user))
+	assert.Equal(suite.T(), int64(1), user.Version)
+
+	suite.Run("正常系:更新のたびにバージョンが増える", func() {
+		current, err := suite.repo.GetByID(ctx, user.ID)
+		require.NoError(suite.T(), err)
+		current.Name = domain.Name("First Update")
+		require.NoError(suite.T(), suite.repo.Update(ctx, current))
+		assert.Equal(suite.T(), int64(2), current.Version)
+
+		found, err := suite.repo.GetByID(ctx, user.ID)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(2), found.Version)
+	})
+
+	suite.Run("異常系:古いバージョンでは更新できない", func() {
+		stale := *user
+		stale.Name = domain.Name("Stale Update")
+		err := suite.repo.Update(ctx, &stale)
+		assert.ErrorIs(suite.T(), err, domain.ErrConcurrentModification)
+
+		found, err := suite.repo.GetByID(ctx, user.ID)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), domain.Name("First Update"), found.Name)
+		assert.Equal(suite.T(), 1, suite.countOutboxEvents(domain.EventTypeUserUpdated, user.ID))
+	})
+
+	suite.Run("異常系:存在しないユーザーはErrUserNotFound", func() {
+		missing := *user
+		missing.ID = uuid.New()
+		assert.ErrorIs(suite.T(), suite.repo.Update(ctx, &missing), domain.ErrUserNotFound)
+	})
+}
+
+// テスト: Update 以外の書き込みでもバージョンが増える
+func (suite *UserRepositoryTestSuite) TestVersionBumpedByOtherWrites() {
+	ctx := context.Background()
+	user := &domain.User{
+		Email:    domain.Email("version-writes@example.com"),
+		Password: domain.Password("versionPass"),
+		Name:     domain.Name("Version Writes User"),
+	}
+	require.NoError(suite.T(), suite.repo.Create(ctx, user))
+
+	versionOf := func() int64 {
+		found, err := suite.repo.GetByID(ctx, user.ID)
+		require.NoError(suite.T(), err)
+		return found.Version
+	}
+
+	require.NoError(suite.T(), suite.repo.UpdatePassword(ctx, user.ID, domain.Password("newHashedPass")))
+	assert.Equal(suite.T(), int64(2), versionOf())
+
+	require.NoError(suite.T(), suite.repo.MarkEmailVerified(ctx, user.ID, user.Email))
+	assert.Equal(suite.T(), int64(3), versionOf())
+
+	require.NoError(suite.T(), suite.repo.Delete(ctx, user.ID))
+	restored, err := suite.repo.Restore(ctx, user.ID)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(5), restored.Version)
+
+	// 読み込んだ時点のバージョンでは更新できない
+	user.Name = domain.Name("Stale Update")
+	assert.ErrorIs(suite.T(), suite.repo.Update(ctx, user), domain.ErrConcurrentModification)
+}
+
+// テスト: 部分更新
+func (suite *UserRepositoryTestSuite) TestPatch() {
+	ctx := context.Background()
+	user := &domain.User{
+		Email:    domain.Email("patch@example.com"),
+		Password: domain.Password("patchPass"),
+		Name:     domain.Name("Patch User"),
+	}
+	require.NoError(suite.T(), suite.repo.Create(ctx, user))
+	require.NoError(suite.T(), suite.repo.MarkEmailVerified(ctx, user.ID, user.Email))
+
+	suite.Run("正常系:指定した列のみ更新する", func() {
+		current, err := suite.repo.GetByID(ctx, user.ID)
+		require.NoError(suite.T(), err)
+		name := domain.Name("Patched Name")
+		require.NoError(suite.T(), suite.repo.Patch(ctx, current, domain.UserPatch{Name: &name}))
+		// メールアドレスの確認でも version は進む
+		assert.Equal(suite.T(), int64(3), current.Version)
+
+		found, err := suite.repo.GetByID(ctx, user.ID)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), name, found.Name)
+		assert.Equal(suite.T(), user.Email, found.Email)
+		assert.True(suite.T(), found.IsEmailVerified())
+		assert.Equal(suite.T(), 1, suite.countOutboxEvents(domain.EventTypeUserUpdated, user.ID))
+	})
+
+	suite.Run("正常系:メールアドレスを変更すると未確認に戻る", func() {
+		current, err := suite.repo.GetByID(ctx, user.ID)
+		require.NoError(suite.T(), err)
+		email := domain.Email("patched@example.com")
+		require.NoError(suite.T(), suite.repo.Patch(ctx, current, domain.UserPatch{Email: &email}))
+
+		found, err := suite.repo.GetByID(ctx, user.ID)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), email, found.Email)
+		assert.Equal(suite.T(), domain.Name("Patched Name"), found.Name)
+		assert.False(suite.T(), found.IsEmailVerified())
+	})
+
+	suite.Run("異常系:古いバージョンでは更新できない", func() {
+		name := domain.Name("Stale Name")
+		err := suite.repo.Patch(ctx, user, domain.UserPatch{Name: &name})
+		assert.ErrorIs(suite.T(), err, domain.ErrConcurrentModification)
+	})
+}
+
 // テスト: Delete
 func (suite *UserRepositoryTestSuite) TestDelete() {
 	// テストデータを事前作成
@@ -453,6 +930,650 @@ func (suite *UserRepositoryTestSuite) TestDelete() {
 	}
 }
 
+// テスト: 論理削除・復元・物理削除
+func (suite *UserRepositoryTestSuite) TestSoftDelete() {
+	ctx := context.Background()
+	newUser := func(email string) *domain.User {
+		user := &domain.User{
+			Email:    domain.Email(email),
+			Password: domain.Password("softDeletePass"),
+			Name:     domain.Name("Soft Delete User"),
+		}
+		require.NoError(suite.T(), suite.repo.Create(ctx, user))
+		return user
+	}
+
+	suite.Run("論理削除したユーザーは取得・一覧の対象外", func() {
+		user := newUser("soft-delete@example.com")
+		require.NoError(suite.T(), suite.repo.Delete(ctx, user.ID))
+
+		_, err := suite.repo.GetByID(ctx, user.ID)
+		assert.ErrorIs(suite.T(), err, domain.ErrUserNotFound)
+		_, err = suite.repo.GetByEmail(ctx, user.Email)
+		assert.ErrorIs(suite.T(), err, domain.ErrUserNotFound)
+
+		users, err := suite.repo.ListUsers(ctx, domain.UserFilter{}, domain.DefaultUserSort(), 100, 0)
+		require.NoError(suite.T(), err)
+		for _, listed := range users {
+			assert.NotEqual(suite.T(), user.ID, listed.ID)
+		}
+
+		// 二重削除ではイベントを書き込まない
+		require.NoError(suite.T(), suite.repo.Delete(ctx, user.ID))
+		assert.Equal(suite.T(), 1, suite.countOutboxEvents(domain.EventTypeUserDeleted, user.ID))
+	})
+
+	suite.Run("復元するとuser.restoredが書き込まれる", func() {
+		user := newUser("restore@example.com")
+		require.NoError(suite.T(), suite.repo.Delete(ctx, user.ID))
+
+		restored, err := suite.repo.Restore(ctx, user.ID)
+		require.NoError(suite.T(), err)
+		assert.False(suite.T(), restored.IsDeleted())
+		assert.Equal(suite.T(), 1, suite.countOutboxEvents(domain.EventTypeUserRestored, user.ID))
+
+		found, err := suite.repo.GetByID(ctx, user.ID)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), user.Email, found.Email)
+	})
+
+	suite.Run("削除されていないユーザーは復元できない", func() {
+		user := newUser("restore-live@example.com")
+		_, err := suite.repo.Restore(ctx, user.ID)
+		assert.ErrorIs(suite.T(), err, domain.ErrUserNotFound)
+	})
+
+	suite.Run("削除済みユーザーのメールアドレスで再登録できる", func() {
+		user := newUser("reuse@example.com")
+		require.NoError(suite.T(), suite.repo.Delete(ctx, user.ID))
+
+		newUser("reuse@example.com")
+
+		// 有効なユーザーとメールアドレスが重複するため復元できない
+		_, err := suite.repo.Restore(ctx, user.ID)
+		assert.ErrorIs(suite.T(), err, domain.ErrDuplicateEmail)
+	})
+
+	suite.Run("保持期間を過ぎた削除済みユーザーのみ物理削除", func() {
+		expired := newUser("purge-expired@example.com")
+		recent := newUser("purge-recent@example.com")
+		require.NoError(suite.T(), suite.repo.Delete(ctx, expired.ID))
+		require.NoError(suite.T(), suite.repo.Delete(ctx, recent.ID))
+		_, err := suite.db.Exec("UPDATE users SET deleted_at = NOW() - INTERVAL '2 days' WHERE id = $1", expired.ID)
+		require.NoError(suite.T(), err)
+
+		purged, err := suite.repo.PurgeDeleted(ctx, 24*time.Hour)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(1), purged)
+
+		_, err = suite.repo.Restore(ctx, expired.ID)
+		assert.ErrorIs(suite.T(), err, domain.ErrUserNotFound)
+		_, err = suite.repo.Restore(ctx, recent.ID)
+		assert.NoError(suite.T(), err)
+	})
+}
+
+// テスト: Outboxイベントの書き込み
+func (suite *UserRepositoryTestSuite) TestOutboxEvents() {
+	ctx := context.Background()
+	user := &domain.User{
+		Email:    domain.Email("outbox@example.com"),
+		Password: domain.Password("outboxPass"),
+		Name:     domain.Name("Outbox User"),
+	}
+
+	suite.Run("Createでuser.createdが書き込まれる", func() {
+		require.NoError(suite.T(), suite.repo.Create(ctx, user))
+		assert.Equal(suite.T(), 1, suite.countOutboxEvents(domain.EventTypeUserCreated, user.ID))
+	})
+
+	suite.Run("Updateでuser.updatedが書き込まれる", func() {
+		user.Name = domain.Name("Outbox User Updated")
+		require.NoError(suite.T(), suite.repo.Update(ctx, user))
+		assert.Equal(suite.T(), 1, suite.countOutboxEvents(domain.EventTypeUserUpdated, user.ID))
+
+		var payload domain.UserEventPayload
+		var raw []byte
+		err := suite.db.QueryRow(
+			"SELECT payload FROM outbox_events WHERE event_type = $1 AND payload->>'user_id' = $2",
+			string(domain.EventTypeUserUpdated), user.ID.String(),
+		).Scan(&raw)
+		require.NoError(suite.T(), err)
+		require.NoError(suite.T(), json.Unmarshal(raw, &payload))
+		assert.Equal(suite.T(), user.Name, payload.Name)
+	})
+
+	suite.Run("UpdatePasswordでuser.updatedが書き込まれる", func() {
+		require.NoError(suite.T(), suite.repo.UpdatePassword(ctx, user.ID, domain.Password("outboxNewPass")))
+		assert.Equal(suite.T(), 2, suite.countOutboxEvents(domain.EventTypeUserUpdated, user.ID))
+	})
+
+	suite.Run("Deleteでuser.deletedが書き込まれる", func() {
+		require.NoError(suite.T(), suite.repo.Delete(ctx, user.ID))
+		assert.Equal(suite.T(), 1, suite.countOutboxEvents(domain.EventTypeUserDeleted, user.ID))
+	})
+
+	suite.Run("削除済みユーザーのパスワード更新ではイベントを書き込まない", func() {
+		err := suite.repo.UpdatePassword(ctx, user.ID, domain.Password("outboxOtherPass"))
+		assert.ErrorIs(suite.T(), err, domain.ErrUserNotFound)
+		assert.Equal(suite.T(), 2, suite.countOutboxEvents(domain.EventTypeUserUpdated, user.ID))
+	})
+
+	suite.Run("存在しないユーザーの削除ではイベントを書き込まない", func() {
+		missingID := uuid.New()
+		require.NoError(suite.T(), suite.repo.Delete(ctx, missingID))
+		assert.Equal(suite.T(), 0, suite.countOutboxEvents(domain.EventTypeUserDeleted, missingID))
+	})
+
+	suite.Run("ユーザー登録失敗時はイベントもロールバックされる", func() {
+		existing := &domain.User{
+			Email:    domain.Email("outbox-dup@example.com"),
+			Password: domain.Password("outboxPass"),
+			Name:     domain.Name("Outbox Existing"),
+		}
+		require.NoError(suite.T(), suite.repo.Create(ctx, existing))
+
+		err := suite.repo.Create(ctx, &domain.User{
+			Email:    existing.Email,
+			Password: domain.Password("outboxPass"),
+			Name:     domain.Name("Outbox Duplicate"),
+		})
+		assert.Error(suite.T(), err)
+
+		// 成功した登録分(user, existing)のイベントのみが残っている
+		var total int
+		err = suite.db.QueryRow("SELECT COUNT(*) FROM outbox_events WHERE event_type = $1",
+			string(domain.EventTypeUserCreated)).Scan(&total)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), 2, total)
+	})
+}
+
+// テスト: OutboxRepository（リレーによる取得と状態更新）
+func (suite *UserRepositoryTestSuite) TestOutboxRepository() {
+	ctx := context.Background()
+	outboxRepo := postgres.NewOutboxRepository(suite.db)
+
+	for i := 0; i < 3; i++ {
+		err := suite.repo.Create(ctx, &domain.User{
+			Email:    domain.Email(fmt.Sprintf("relay%d@example.com", i)),
+			Password: domain.Password("relayPass"),
+			Name:     domain.Name(fmt.Sprintf("Relay User %d", i)),
+		})
+		require.NoError(suite.T(), err)
+	}
+
+	suite.Run("pendingイベントをseq_id順にリースして取得", func() {
+		events, err := outboxRepo.ClaimPending(ctx, 2, time.Minute)
+		require.NoError(suite.T(), err)
+		require.Len(suite.T(), events, 2)
+		assert.Less(suite.T(), events[0].SeqID, events[1].SeqID)
+		assert.Equal(suite.T(), 1, events[0].Attempts)
+		assert.Equal(suite.T(), domain.EventTypeUserCreated, events[0].Event.Type)
+
+		// リース中のイベントは再取得されない
+		rest, err := outboxRepo.ClaimPending(ctx, 10, time.Minute)
+		require.NoError(suite.T(), err)
+		assert.Len(suite.T(), rest, 1)
+
+		require.NoError(suite.T(), outboxRepo.MarkPublished(ctx, events[0].SeqID))
+		require.NoError(suite.T(), outboxRepo.MarkFailed(ctx, events[1].SeqID, "permanent error"))
+		require.NoError(suite.T(), outboxRepo.ScheduleRetry(ctx, rest[0].SeqID, "temporary error", 0))
+
+		var status string
+		var reason sql.NullString
+		err = suite.db.QueryRow("SELECT status, error_reason FROM outbox_events WHERE seq_id = $1", events[1].SeqID).
+			Scan(&status, &reason)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), string(domain.OutboxStatusFailed), status)
+		assert.Equal(suite.T(), "permanent error", reason.String)
+	})
+
+	suite.Run("再試行予定のイベントは待機時間経過後に再取得される", func() {
+		events, err := outboxRepo.ClaimPending(ctx, 10, time.Minute)
+		require.NoError(suite.T(), err)
+		require.Len(suite.T(), events, 1)
+		assert.Equal(suite.T(), 2, events[0].Attempts)
+		assert.Equal(suite.T(), "temporary error", events[0].ErrorReason)
+	})
+}
+
+func (suite *UserRepositoryTestSuite) TestRefreshTokenRepository() {
+	ctx := context.Background()
+	tokenRepo := postgres.NewRefreshTokenRepository(suite.db)
+
+	user := &domain.User{
+		Email:    domain.Email("token@example.com"),
+		Password: domain.Password("tokenPass"),
+		Name:     domain.Name("Token User"),
+	}
+	require.NoError(suite.T(), suite.repo.Create(ctx, user))
+
+	token := domain.NewRefreshToken(user.ID, "token-hash-1", time.Now().Add(time.Hour))
+	require.NoError(suite.T(), tokenRepo.Create(ctx, token))
+	other := domain.NewRefreshToken(user.ID, "token-hash-2", time.Now().Add(time.Hour))
+	require.NoError(suite.T(), tokenRepo.Create(ctx, other))
+
+	suite.Run("ハッシュでトークンを取得", func() {
+		found, err := tokenRepo.GetByHash(ctx, "token-hash-1")
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), token.ID, found.ID)
+		assert.Equal(suite.T(), user.ID, found.UserID)
+		assert.False(suite.T(), found.IsRevoked())
+	})
+
+	suite.Run("存在しないハッシュはErrNotFound", func() {
+		_, err := tokenRepo.GetByHash(ctx, "unknown-hash")
+		assert.ErrorIs(suite.T(), err, domain.ErrNotFound)
+	})
+
+	suite.Run("失効は一度だけ成功する", func() {
+		require.NoError(suite.T(), tokenRepo.Revoke(ctx, token.ID))
+		assert.ErrorIs(suite.T(), tokenRepo.Revoke(ctx, token.ID), domain.ErrNotFound)
+
+		found, err := tokenRepo.GetByHash(ctx, "token-hash-1")
+		require.NoError(suite.T(), err)
+		assert.True(suite.T(), found.IsRevoked())
+	})
+
+	suite.Run("ユーザーの全トークンを失効", func() {
+		require.NoError(suite.T(), tokenRepo.RevokeAllForUser(ctx, user.ID))
+
+		found, err := tokenRepo.GetByHash(ctx, "token-hash-2")
+		require.NoError(suite.T(), err)
+		assert.True(suite.T(), found.IsRevoked())
+	})
+
+	suite.Run("パスワードを更新", func() {
+		require.NoError(suite.T(), suite.repo.UpdatePassword(ctx, user.ID, domain.Password("new_hash")))
+
+		updated, err := suite.repo.GetByID(ctx, user.ID)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), domain.Password("new_hash"), updated.Password)
+	})
+}
+
+func (suite *UserRepositoryTestSuite) TestPasswordResetTokenRepository() {
+	ctx := context.Background()
+	resetRepo := postgres.NewPasswordResetTokenRepository(suite.db)
+
+	user := &domain.User{
+		Email:    domain.Email("reset@example.com"),
+		Password: domain.Password("resetPass"),
+		Name:     domain.Name("Reset User"),
+	}
+	require.NoError(suite.T(), suite.repo.Create(ctx, user))
+
+	token := domain.NewPasswordResetToken(user.ID, "reset-hash-1", time.Now().Add(time.Hour))
+	require.NoError(suite.T(), resetRepo.Create(ctx, token))
+	other := domain.NewPasswordResetToken(user.ID, "reset-hash-2", time.Now().Add(time.Hour))
+	require.NoError(suite.T(), resetRepo.Create(ctx, other))
+
+	suite.Run("ハッシュでトークンを取得", func() {
+		found, err := resetRepo.GetByHash(ctx, "reset-hash-1")
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), token.ID, found.ID)
+		assert.False(suite.T(), found.IsUsed())
+	})
+
+	suite.Run("存在しないハッシュはErrNotFound", func() {
+		_, err := resetRepo.GetByHash(ctx, "unknown-hash")
+		assert.ErrorIs(suite.T(), err, domain.ErrNotFound)
+	})
+
+	suite.Run("使用は一度だけ成功する", func() {
+		require.NoError(suite.T(), resetRepo.MarkUsed(ctx, token.ID))
+		assert.ErrorIs(suite.T(), resetRepo.MarkUsed(ctx, token.ID), domain.ErrNotFound)
+	})
+
+	suite.Run("ユーザーの全トークンを無効化", func() {
+		require.NoError(suite.T(), resetRepo.InvalidateAllForUser(ctx, user.ID))
+
+		found, err := resetRepo.GetByHash(ctx, "reset-hash-2")
+		require.NoError(suite.T(), err)
+		assert.True(suite.T(), found.IsUsed())
+	})
+}
+
+func (suite *UserRepositoryTestSuite) TestEmailVerificationTokenRepository() {
+	ctx := context.Background()
+	verifyRepo := postgres.NewEmailVerificationTokenRepository(suite.db)
+
+	user := &domain.User{
+		Email:    domain.Email("verify@example.com"),
+		Password: domain.Password("verifyPass"),
+		Name:     domain.Name("Verify User"),
+	}
+	require.NoError(suite.T(), suite.repo.Create(ctx, user))
+
+	issuedAt := time.Now()
+	token := domain.NewEmailVerificationToken(user, "verify-hash-1", time.Now().Add(time.Hour))
+	require.NoError(suite.T(), verifyRepo.Create(ctx, token))
+	other := domain.NewEmailVerificationToken(user, "verify-hash-2", time.Now().Add(time.Hour))
+	require.NoError(suite.T(), verifyRepo.Create(ctx, other))
+
+	suite.Run("ハッシュでトークンを取得", func() {
+		found, err := verifyRepo.GetByHash(ctx, "verify-hash-1")
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), token.ID, found.ID)
+		assert.Equal(suite.T(), user.Email, found.Email)
+		assert.False(suite.T(), found.IsUsed())
+	})
+
+	suite.Run("存在しないハッシュはErrNotFound", func() {
+		_, err := verifyRepo.GetByHash(ctx, "unknown-hash")
+		assert.ErrorIs(suite.T(), err, domain.ErrNotFound)
+	})
+
+	suite.Run("期間内の発行数を数える", func() {
+		count, err := verifyRepo.CountIssuedSince(ctx, user.ID, issuedAt.Add(-time.Minute))
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), 2, count)
+
+		count, err = verifyRepo.CountIssuedSince(ctx, user.ID, time.Now().Add(time.Minute))
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), 0, count)
+	})
+
+	suite.Run("使用は一度だけ成功する", func() {
+		require.NoError(suite.T(), verifyRepo.MarkUsed(ctx, token.ID))
+		assert.ErrorIs(suite.T(), verifyRepo.MarkUsed(ctx, token.ID), domain.ErrNotFound)
+	})
+
+	suite.Run("ユーザーの全トークンを無効化しても発行数には含まれる", func() {
+		require.NoError(suite.T(), verifyRepo.InvalidateAllForUser(ctx, user.ID))
+
+		found, err := verifyRepo.GetByHash(ctx, "verify-hash-2")
+		require.NoError(suite.T(), err)
+		assert.True(suite.T(), found.IsUsed())
+
+		count, err := verifyRepo.CountIssuedSince(ctx, user.ID, issuedAt.Add(-time.Minute))
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), 2, count)
+	})
+}
+
+func (suite *UserRepositoryTestSuite) TestMarkEmailVerified() {
+	ctx := context.Background()
+
+	user := &domain.User{
+		Email:    domain.Email("verified@example.com"),
+		Password: domain.Password("verifyPass"),
+		Name:     domain.Name("Verified User"),
+	}
+	require.NoError(suite.T(), suite.repo.Create(ctx, user))
+
+	suite.Run("正常系:新規ユーザーは未確認", func() {
+		found, err := suite.repo.GetByID(ctx, user.ID)
+		require.NoError(suite.T(), err)
+		assert.False(suite.T(), found.IsEmailVerified())
+	})
+
+	suite.Run("異常系:トークン発行後にメールアドレスが変わっていれば確認しない", func() {
+		err := suite.repo.MarkEmailVerified(ctx, user.ID, domain.Email("old@example.com"))
+		assert.ErrorIs(suite.T(), err, domain.ErrUserNotFound)
+		assert.Equal(suite.T(), 0, suite.countOutboxEvents(domain.EventTypeUserUpdated, user.ID))
+	})
+
+	suite.Run("正常系:メールアドレスが一致すれば確認済みになる", func() {
+		require.NoError(suite.T(), suite.repo.MarkEmailVerified(ctx, user.ID, domain.Email("Verified@EXAMPLE.com")))
+
+		found, err := suite.repo.GetByID(ctx, user.ID)
+		require.NoError(suite.T(), err)
+		assert.True(suite.T(), found.IsEmailVerified())
+
+		// 確認日時を含むuser.updatedが同じトランザクションで書き込まれる
+		assert.Equal(suite.T(), 1, suite.countOutboxEvents(domain.EventTypeUserUpdated, user.ID))
+		var raw []byte
+		err = suite.db.QueryRow(
+			"SELECT payload FROM outbox_events WHERE event_type = $1 AND payload->>'user_id' = $2",
+			string(domain.EventTypeUserUpdated), user.ID.String(),
+		).Scan(&raw)
+		require.NoError(suite.T(), err)
+		var payload domain.UserEventPayload
+		require.NoError(suite.T(), json.Unmarshal(raw, &payload))
+		assert.NotNil(suite.T(), payload.EmailVerifiedAt)
+	})
+
+	suite.Run("正常系:メールアドレスを変更すると未確認に戻る", func() {
+		found, err := suite.repo.GetByID(ctx, user.ID)
+		require.NoError(suite.T(), err)
+		require.NoError(suite.T(), found.UpdateEmail(domain.Email("changed@example.com")))
+		require.NoError(suite.T(), suite.repo.Update(ctx, found))
+
+		updated, err := suite.repo.GetByID(ctx, user.ID)
+		require.NoError(suite.T(), err)
+		assert.False(suite.T(), updated.IsEmailVerified())
+	})
+}
+
+func (suite *UserRepositoryTestSuite) TestLoginThrottleRepository() {
+	ctx := context.Background()
+	throttleRepo := postgres.NewLoginThrottleRepository(suite.db)
+	scope := domain.LoginThrottleScopeIP
+	subject := "192.0.2.10"
+
+	suite.Run("記録がなければErrNotFound", func() {
+		_, err := throttleRepo.Get(ctx, scope, subject)
+		assert.ErrorIs(suite.T(), err, domain.ErrNotFound)
+	})
+
+	suite.Run("ウィンドウ内の失敗は加算される", func() {
+		first, err := throttleRepo.RecordFailure(ctx, scope, subject, time.Minute)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), 1, first.Failures)
+
+		second, err := throttleRepo.RecordFailure(ctx, scope, subject, time.Minute)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), 2, second.Failures)
+	})
+
+	suite.Run("ウィンドウ外の失敗はカウントをリセット", func() {
+		throttle, err := throttleRepo.RecordFailure(ctx, scope, subject, 0)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), 1, throttle.Failures)
+	})
+
+	suite.Run("ロックと解除", func() {
+		require.NoError(suite.T(), throttleRepo.Lock(ctx, scope, subject, time.Hour))
+
+		throttle, err := throttleRepo.Get(ctx, scope, subject)
+		require.NoError(suite.T(), err)
+		assert.True(suite.T(), throttle.IsLocked(time.Now()))
+
+		require.NoError(suite.T(), throttleRepo.Reset(ctx, scope, subject))
+		_, err = throttleRepo.Get(ctx, scope, subject)
+		assert.ErrorIs(suite.T(), err, domain.ErrNotFound)
+	})
+
+	suite.Run("ウィンドウ外でロックされていない行だけ削除", func() {
+		locked := "192.0.2.11"
+		_, err := throttleRepo.RecordFailure(ctx, scope, subject, time.Minute)
+		require.NoError(suite.T(), err)
+		_, err = throttleRepo.RecordFailure(ctx, scope, locked, time.Minute)
+		require.NoError(suite.T(), err)
+		require.NoError(suite.T(), throttleRepo.Lock(ctx, scope, locked, time.Hour))
+
+		// ウィンドウ内の失敗は残る
+		purged, err := throttleRepo.PurgeExpired(ctx, time.Hour)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(0), purged)
+
+		purged, err = throttleRepo.PurgeExpired(ctx, 0)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(1), purged)
+		_, err = throttleRepo.Get(ctx, scope, subject)
+		assert.ErrorIs(suite.T(), err, domain.ErrNotFound)
+		_, err = throttleRepo.Get(ctx, scope, locked)
+		assert.NoError(suite.T(), err)
+	})
+}
+
+func (suite *UserRepositoryTestSuite) TestIdempotencyKeyRepository() {
+	ctx := context.Background()
+	keyRepo := postgres.NewIdempotencyKeyRepository(suite.db)
+
+	suite.Run("予約・完了・既存キーの取得", func() {
+		key := domain.NewIdempotencyKey("anonymous", "key-1", "hash-1", time.Now().Add(time.Hour))
+		existing, reserved, err := keyRepo.Reserve(ctx, key)
+		require.NoError(suite.T(), err)
+		assert.True(suite.T(), reserved)
+		assert.Nil(suite.T(), existing)
+
+		// 処理中のキーはそのまま返る
+		existing, reserved, err = keyRepo.Reserve(ctx, domain.NewIdempotencyKey("anonymous", "key-1", "hash-2", time.Now().Add(time.Hour)))
+		require.NoError(suite.T(), err)
+		assert.False(suite.T(), reserved)
+		assert.Equal(suite.T(), "hash-1", existing.RequestHash)
+		assert.False(suite.T(), existing.IsCompleted())
+
+		key.StatusCode = 201
+		key.ResponseHeaders = map[string]string{"Content-Type": "application/json"}
+		key.ResponseBody = []byte(`{"id":"1"}`)
+		require.NoError(suite.T(), keyRepo.Complete(ctx, key))
+
+		existing, reserved, err = keyRepo.Reserve(ctx, domain.NewIdempotencyKey("anonymous", "key-1", "hash-1", time.Now().Add(time.Hour)))
+		require.NoError(suite.T(), err)
+		assert.False(suite.T(), reserved)
+		assert.Equal(suite.T(), 201, existing.StatusCode)
+		assert.Equal(suite.T(), "application/json", existing.ResponseHeaders["Content-Type"])
+		assert.Equal(suite.T(), []byte(`{"id":"1"}`), existing.ResponseBody)
+	})
+
+	suite.Run("スコープが異なれば別のキー", func() {
+		_, reserved, err := keyRepo.Reserve(ctx, domain.NewIdempotencyKey(uuid.NewString(), "key-1", "hash-1", time.Now().Add(time.Hour)))
+		require.NoError(suite.T(), err)
+		assert.True(suite.T(), reserved)
+	})
+
+	suite.Run("解除したキーは再予約できる", func() {
+		key := domain.NewIdempotencyKey("anonymous", "key-2", "hash-1", time.Now().Add(time.Hour))
+		_, reserved, err := keyRepo.Reserve(ctx, key)
+		require.NoError(suite.T(), err)
+		require.True(suite.T(), reserved)
+
+		require.NoError(suite.T(), keyRepo.Release(ctx, key.Scope, key.Key))
+
+		_, reserved, err = keyRepo.Reserve(ctx, key)
+		require.NoError(suite.T(), err)
+		assert.True(suite.T(), reserved)
+	})
+
+	suite.Run("期限切れのキーは上書きでき、削除対象になる", func() {
+		expired := domain.NewIdempotencyKey("anonymous", "key-3", "hash-1", time.Now().Add(-time.Minute))
+		_, reserved, err := keyRepo.Reserve(ctx, expired)
+		require.NoError(suite.T(), err)
+		require.True(suite.T(), reserved)
+
+		_, reserved, err = keyRepo.Reserve(ctx, domain.NewIdempotencyKey("anonymous", "key-3", "hash-2", time.Now().Add(-time.Minute)))
+		require.NoError(suite.T(), err)
+		assert.True(suite.T(), reserved)
+
+		purged, err := keyRepo.PurgeExpired(ctx)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(1), purged)
+	})
+}
+
+func (suite *UserRepositoryTestSuite) TestAuditEventRepository() {
+	ctx := context.Background()
+	auditRepo := postgres.NewAuditEventRepository(suite.db)
+	userID := uuid.New()
+	actorID := uuid.New()
+
+	suite.Run("記録したイベントを新しい順に取得", func() {
+		older := domain.NewAuditEvent(domain.AuditActionUserCreate, userID, nil)
+		older.OccurredAt = time.Now().Add(-time.Hour)
+		older.Changes = []domain.AuditChange{{Field: "name", Before: nil, After: "Alice"}}
+		require.NoError(suite.T(), auditRepo.Create(ctx, older))
+
+		newer := domain.NewAuditEvent(domain.AuditActionUserUpdate, userID, domain.ErrConcurrentModification)
+		newer.ActorID = &actorID
+		newer.RequestID = "host/abc-000001"
+		newer.ClientIP = "192.0.2.1"
+		require.NoError(suite.T(), auditRepo.Create(ctx, newer))
+
+		events, err := auditRepo.ListByUser(ctx, userID, 10, 0)
+		require.NoError(suite.T(), err)
+		require.Len(suite.T(), events, 2)
+		assert.Equal(suite.T(), newer.ID, events[0].ID)
+		assert.Equal(suite.T(), domain.AuditOutcomeFailure, events[0].Outcome)
+		assert.Equal(suite.T(), domain.ErrConcurrentModification.Error(), events[0].Error)
+		assert.Equal(suite.T(), &actorID, events[0].ActorID)
+		assert.Equal(suite.T(), "host/abc-000001", events[0].RequestID)
+		assert.Equal(suite.T(), "192.0.2.1", events[0].ClientIP)
+		assert.Empty(suite.T(), events[0].Changes)
+		assert.Equal(suite.T(), older.ID, events[1].ID)
+		assert.Nil(suite.T(), events[1].ActorID)
+		assert.Equal(suite.T(), older.Changes, events[1].Changes)
+
+		total, err := auditRepo.CountByUser(ctx, userID)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(2), total)
+
+		// ページング
+		events, err = auditRepo.ListByUser(ctx, userID, 1, 1)
+		require.NoError(suite.T(), err)
+		require.Len(suite.T(), events, 1)
+		assert.Equal(suite.T(), older.ID, events[0].ID)
+	})
+
+	suite.Run("一括記録", func() {
+		otherID := uuid.New()
+		events := []*domain.AuditEvent{
+			domain.NewAuditEvent(domain.AuditActionUserCreate, otherID, nil),
+			domain.NewAuditEvent(domain.AuditActionUserCreate, uuid.New(), nil),
+		}
+		require.NoError(suite.T(), auditRepo.CreateBatch(ctx, events))
+
+		total, err := auditRepo.CountByUser(ctx, otherID)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(1), total)
+	})
+}
+
+func (suite *UserRepositoryTestSuite) TestRoles() {
+	ctx := context.Background()
+
+	user := &domain.User{
+		Email:    domain.Email("role@example.com"),
+		Password: domain.Password("rolePass"),
+		Name:     domain.Name("Role User"),
+	}
+	require.NoError(suite.T(), suite.repo.Create(ctx, user))
+
+	suite.Run("ロールを付与すると取得時に含まれる", func() {
+		require.NoError(suite.T(), suite.repo.GrantRole(ctx, user.ID, domain.RoleSupport))
+		// 二重付与はエラーにならない
+		require.NoError(suite.T(), suite.repo.GrantRole(ctx, user.ID, domain.RoleSupport))
+		require.NoError(suite.T(), suite.repo.GrantRole(ctx, user.ID, domain.RoleAdmin))
+
+		found, err := suite.repo.GetByID(ctx, user.ID)
+		require.NoError(suite.T(), err)
+		assert.ElementsMatch(suite.T(), []domain.Role{domain.RoleAdmin, domain.RoleSupport}, found.Roles)
+
+		users, err := suite.repo.ListUsers(ctx, domain.UserFilter{}, domain.DefaultUserSort(), 10, 0)
+		require.NoError(suite.T(), err)
+		require.Len(suite.T(), users, 1)
+		assert.ElementsMatch(suite.T(), []domain.Role{domain.RoleAdmin, domain.RoleSupport}, users[0].Roles)
+	})
+
+	suite.Run("ロールを剥奪する", func() {
+		require.NoError(suite.T(), suite.repo.RevokeRole(ctx, user.ID, domain.RoleAdmin))
+		// 未付与のロールの剥奪はエラーにならない
+		require.NoError(suite.T(), suite.repo.RevokeRole(ctx, user.ID, domain.RoleAdmin))
+
+		found, err := suite.repo.GetByEmail(ctx, user.Email)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), []domain.Role{domain.RoleSupport}, found.Roles)
+	})
+
+	suite.Run("未定義のロールは付与できない", func() {
+		err := suite.repo.GrantRole(ctx, user.ID, domain.Role("owner"))
+		assert.Error(suite.T(), err)
+	})
+}
+
 // トランザクションのテスト
 func (suite *UserRepositoryTestSuite) TestTransaction() {
 	suite.Run("トランザクション内での複数操作", func() {
@@ -481,7 +1602,7 @@ func BenchmarkUserRepository_Create(b *testing.B) {
 	}
 	defer db.Close()
 
-	repo := postgres.NewUserRepository(db)
+	repo := postgres.NewUserRepository(db, logger.NewNop())
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -503,7 +1624,7 @@ func BenchmarkUserRepository_GetByID(b *testing.B) {
 	}
 	defer db.Close()
 
-	repo := postgres.NewUserRepository(db)
+	repo := postgres.NewUserRepository(db, logger.NewNop())
 
 	// テストユーザー作成
 	user := &domain.User{
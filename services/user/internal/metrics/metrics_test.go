@@ -0,0 +1,55 @@
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scrape returns the metrics exposed by the handler
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	return rec.Body.String()
+}
+
+func TestMetrics(t *testing.T) {
+	t.Run("正常系：ルートのパターンごとにリクエストを記録する", func(t *testing.T) {
+		m := metrics.New(nil)
+		m.ObserveRequest("/api/v1/users/{userID}/", http.MethodGet, http.StatusOK, 20*time.Millisecond)
+		m.ObserveRequest("/api/v1/users/{userID}/", http.MethodGet, http.StatusNotFound, 5*time.Millisecond)
+
+		body := scrape(t, m)
+
+		assert.Contains(t, body, `http_request_duration_seconds_count{method="GET",route="/api/v1/users/{userID}/",status="200"} 1`)
+		assert.Contains(t, body, `http_request_duration_seconds_count{method="GET",route="/api/v1/users/{userID}/",status="404"} 1`)
+	})
+
+	t.Run("正常系：業務カウンター", func(t *testing.T) {
+		m := metrics.New(nil)
+		m.UsersCreated(1)
+		m.UsersCreated(3)
+		m.AuthenticationAttempted(false)
+
+		body := scrape(t, m)
+
+		assert.Contains(t, body, "users_created_total 4")
+		assert.Contains(t, body, `user_authentications_total{outcome="failure"} 1`)
+		// 未発生の結果も 0 で出力する
+		assert.Contains(t, body, `user_authentications_total{outcome="success"} 0`)
+	})
+
+	t.Run("正常系：DBがなければ接続プールの統計は出力しない", func(t *testing.T) {
+		body := scrape(t, metrics.New(nil))
+
+		assert.False(t, strings.Contains(body, "go_sql_"))
+	})
+}
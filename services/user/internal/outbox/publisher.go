@@ -0,0 +1,66 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+)
+
+// Publisher delivers outbox events to a message broker
+type Publisher interface {
+	Publish(ctx context.Context, event *domain.Event) error
+}
+
+// コンパイル時にインターフェースを満たしているか確認
+var (
+	_ Publisher = (*ChannelPublisher)(nil)
+	_ Publisher = (*WriterPublisher)(nil)
+)
+
+// ChannelPublisher publishes events to a Go channel (in-process delivery / tests)
+type ChannelPublisher struct {
+	ch chan<- *domain.Event
+}
+
+// NewChannelPublisher creates a new ChannelPublisher
+func NewChannelPublisher(ch chan<- *domain.Event) *ChannelPublisher {
+	return &ChannelPublisher{ch: ch}
+}
+
+func (p *ChannelPublisher) Publish(ctx context.Context, event *domain.Event) error {
+	select {
+	case p.ch <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WriterPublisher writes events as newline-delimited JSON (stdout / file)
+type WriterPublisher struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewWriterPublisher creates a new WriterPublisher
+func NewWriterPublisher(w io.Writer) *WriterPublisher {
+	return &WriterPublisher{enc: json.NewEncoder(w)}
+}
+
+func (p *WriterPublisher) Publish(ctx context.Context, event *domain.Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.enc.Encode(event); err != nil {
+		return fmt.Errorf("failed to write event %s: %w", event.ID, err)
+	}
+	return nil
+}
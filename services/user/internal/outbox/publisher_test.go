@@ -0,0 +1,49 @@
+package outbox_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/outbox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterPublisher_Publish(t *testing.T) {
+	var buf bytes.Buffer
+	publisher := outbox.NewWriterPublisher(&buf)
+
+	first := newOutboxEvent(1, 1).Event
+	second := newOutboxEvent(2, 1).Event
+	require.NoError(t, publisher.Publish(context.Background(), &first))
+	require.NoError(t, publisher.Publish(context.Background(), &second))
+
+	// 1行1イベントのNDJSONとして書き込まれる
+	scanner := bufio.NewScanner(&buf)
+	var got []domain.Event
+	for scanner.Scan() {
+		var event domain.Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		got = append(got, event)
+	}
+	require.Len(t, got, 2)
+	assert.Equal(t, first.ID, got[0].ID)
+	assert.Equal(t, second.ID, got[1].ID)
+	assert.Equal(t, domain.EventTypeUserCreated, got[0].Type)
+}
+
+func TestChannelPublisher_Publish_ContextCancelled(t *testing.T) {
+	// バッファなしチャネルで受信者がいない場合はコンテキストのキャンセルで抜ける
+	publisher := outbox.NewChannelPublisher(make(chan *domain.Event))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	event := newOutboxEvent(1, 1).Event
+	err := publisher.Publish(ctx, &event)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
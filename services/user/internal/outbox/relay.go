@@ -0,0 +1,153 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+	"go.uber.org/zap"
+)
+
+// RelayConfig configures polling, leasing and retry behaviour of the relay
+type RelayConfig struct {
+	BatchSize    int32
+	PollInterval time.Duration
+	// Lease is how long a claimed event stays invisible to other relays before it can be re-claimed
+	Lease       time.Duration
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// DefaultRelayConfig returns the default relay configuration
+func DefaultRelayConfig() RelayConfig {
+	return RelayConfig{
+		BatchSize:    100,
+		PollInterval: time.Second,
+		Lease:        30 * time.Second,
+		MaxAttempts:  10,
+		BaseBackoff:  time.Second,
+		MaxBackoff:   5 * time.Minute,
+	}
+}
+
+// Relay ships outbox events to a Publisher with at-least-once delivery
+type Relay struct {
+	repo      repository.OutboxRepository
+	publisher Publisher
+	logger    logger.Logger
+	cfg       RelayConfig
+}
+
+// NewRelay creates a new outbox Relay
+func NewRelay(repo repository.OutboxRepository, publisher Publisher, logger logger.Logger, cfg RelayConfig) *Relay {
+	return &Relay{
+		repo:      repo,
+		publisher: publisher,
+		logger:    logger,
+		cfg:       cfg,
+	}
+}
+
+// Run polls the outbox until ctx is cancelled
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	r.logger.Info(ctx, "Starting outbox relay",
+		zap.Int32("batch_size", r.cfg.BatchSize),
+		zap.Duration("poll_interval", r.cfg.PollInterval))
+
+	for {
+		processed, err := r.ProcessBatch(ctx)
+		if err != nil && ctx.Err() == nil {
+			r.logger.Error(ctx, "Failed to process outbox batch", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			r.logger.Info(ctx, "Stopping outbox relay")
+			return
+		default:
+		}
+
+		// バッチが満杯の場合は滞留しているとみなし、待たずに次のバッチを処理する
+		if err == nil && processed == int(r.cfg.BatchSize) {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			r.logger.Info(ctx, "Stopping outbox relay")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessBatch claims one batch of pending events and publishes them, returning the number of claimed events
+func (r *Relay) ProcessBatch(ctx context.Context) (int, error) {
+	events, err := r.repo.ClaimPending(ctx, r.cfg.BatchSize, r.cfg.Lease)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+
+	for _, event := range events {
+		if err := r.deliver(ctx, event); err != nil {
+			return len(events), err
+		}
+	}
+	return len(events), nil
+}
+
+// deliver publishes a single event and records the outcome in the outbox
+func (r *Relay) deliver(ctx context.Context, event *domain.OutboxEvent) error {
+	publishErr := r.publisher.Publish(ctx, &event.Event)
+	if publishErr == nil {
+		if err := r.repo.MarkPublished(ctx, event.SeqID); err != nil {
+			// publish 済みだが記録に失敗した場合はリース切れ後に再送される（at-least-once）
+			return fmt.Errorf("failed to mark outbox event %d as published: %w", event.SeqID, err)
+		}
+		return nil
+	}
+
+	reason := publishErr.Error()
+	if event.Attempts >= r.cfg.MaxAttempts {
+		r.logger.Error(ctx, "Giving up publishing outbox event",
+			zap.Int64("seq_id", event.SeqID),
+			zap.String("event_id", event.Event.ID.String()),
+			zap.Int("attempts", event.Attempts),
+			zap.Error(publishErr))
+		if err := r.repo.MarkFailed(ctx, event.SeqID, reason); err != nil {
+			return fmt.Errorf("failed to mark outbox event %d as failed: %w", event.SeqID, err)
+		}
+		return nil
+	}
+
+	delay := r.backoff(event.Attempts)
+	r.logger.Warn(ctx, "Failed to publish outbox event, scheduling retry",
+		zap.Int64("seq_id", event.SeqID),
+		zap.String("event_id", event.Event.ID.String()),
+		zap.Int("attempts", event.Attempts),
+		zap.Duration("retry_in", delay),
+		zap.Error(publishErr))
+	if err := r.repo.ScheduleRetry(ctx, event.SeqID, reason, delay); err != nil {
+		return fmt.Errorf("failed to schedule retry for outbox event %d: %w", event.SeqID, err)
+	}
+	return nil
+}
+
+// backoff returns the exponential backoff delay for the given attempt (1-origin)
+func (r *Relay) backoff(attempt int) time.Duration {
+	delay := r.cfg.BaseBackoff
+	for i := 1; i < attempt && delay < r.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > r.cfg.MaxBackoff {
+		delay = r.cfg.MaxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,194 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/outbox"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockPublisher is a mock implementation of Publisher
+type MockPublisher struct {
+	mock.Mock
+}
+
+func (m *MockPublisher) Publish(ctx context.Context, event *domain.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func newOutboxEvent(seqID int64, attempts int) *domain.OutboxEvent {
+	return &domain.OutboxEvent{
+		SeqID: seqID,
+		Event: domain.Event{
+			ID:         uuid.New(),
+			Type:       domain.EventTypeUserCreated,
+			Version:    domain.UserEventVersion,
+			OccurredAt: time.Now(),
+			Producer:   domain.EventProducer,
+			Payload:    []byte(`{"user_id":"00000000-0000-0000-0000-000000000000"}`),
+		},
+		Status:   domain.OutboxStatusPending,
+		Attempts: attempts,
+	}
+}
+
+func testRelayConfig() outbox.RelayConfig {
+	cfg := outbox.DefaultRelayConfig()
+	cfg.BatchSize = 10
+	cfg.MaxAttempts = 3
+	cfg.BaseBackoff = time.Second
+	cfg.MaxBackoff = 3 * time.Second
+	return cfg
+}
+
+func TestRelay_ProcessBatch_Published(t *testing.T) {
+	mockRepo := new(repository.MockOutboxRepository)
+	ch := make(chan *domain.Event, 2)
+	relay := outbox.NewRelay(mockRepo, outbox.NewChannelPublisher(ch), logger.NewNop(), testRelayConfig())
+
+	events := []*domain.OutboxEvent{newOutboxEvent(1, 1), newOutboxEvent(2, 1)}
+	mockRepo.On("ClaimPending", mock.Anything, int32(10), 30*time.Second).Return(events, nil).Once()
+	mockRepo.On("MarkPublished", mock.Anything, int64(1)).Return(nil).Once()
+	mockRepo.On("MarkPublished", mock.Anything, int64(2)).Return(nil).Once()
+
+	processed, err := relay.ProcessBatch(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, processed)
+	require.Len(t, ch, 2)
+	assert.Equal(t, events[0].Event.ID, (<-ch).ID)
+	assert.Equal(t, events[1].Event.ID, (<-ch).ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRelay_ProcessBatch_PublishFailure(t *testing.T) {
+	publishErr := errors.New("broker unavailable")
+
+	tests := []struct {
+		name      string
+		attempts  int
+		mockSetup func(*repository.MockOutboxRepository)
+	}{
+		{
+			name:     "1回目の失敗はBaseBackoffで再試行",
+			attempts: 1,
+			mockSetup: func(m *repository.MockOutboxRepository) {
+				m.On("ScheduleRetry", mock.Anything, int64(1), publishErr.Error(), time.Second).Return(nil).Once()
+			},
+		},
+		{
+			name:     "2回目の失敗は指数的に待機時間が伸びる",
+			attempts: 2,
+			mockSetup: func(m *repository.MockOutboxRepository) {
+				m.On("ScheduleRetry", mock.Anything, int64(1), publishErr.Error(), 2*time.Second).Return(nil).Once()
+			},
+		},
+		{
+			name:     "MaxAttemptsに達したらfailedにする",
+			attempts: 3,
+			mockSetup: func(m *repository.MockOutboxRepository) {
+				m.On("MarkFailed", mock.Anything, int64(1), publishErr.Error()).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockOutboxRepository)
+			mockPublisher := new(MockPublisher)
+			relay := outbox.NewRelay(mockRepo, mockPublisher, logger.NewNop(), testRelayConfig())
+
+			mockRepo.On("ClaimPending", mock.Anything, mock.Anything, mock.Anything).
+				Return([]*domain.OutboxEvent{newOutboxEvent(1, tt.attempts)}, nil).Once()
+			mockPublisher.On("Publish", mock.Anything, mock.Anything).Return(publishErr).Once()
+			tt.mockSetup(mockRepo)
+
+			processed, err := relay.ProcessBatch(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, 1, processed)
+			mockRepo.AssertNotCalled(t, "MarkPublished", mock.Anything, mock.Anything)
+			mockRepo.AssertExpectations(t)
+			mockPublisher.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRelay_ProcessBatch_BackoffIsCapped(t *testing.T) {
+	mockRepo := new(repository.MockOutboxRepository)
+	mockPublisher := new(MockPublisher)
+	cfg := testRelayConfig()
+	cfg.MaxAttempts = 10
+	relay := outbox.NewRelay(mockRepo, mockPublisher, logger.NewNop(), cfg)
+
+	mockRepo.On("ClaimPending", mock.Anything, mock.Anything, mock.Anything).
+		Return([]*domain.OutboxEvent{newOutboxEvent(1, 8)}, nil).Once()
+	mockPublisher.On("Publish", mock.Anything, mock.Anything).Return(errors.New("timeout")).Once()
+	mockRepo.On("ScheduleRetry", mock.Anything, int64(1), "timeout", cfg.MaxBackoff).Return(nil).Once()
+
+	_, err := relay.ProcessBatch(context.Background())
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRelay_ProcessBatch_ClaimError(t *testing.T) {
+	mockRepo := new(repository.MockOutboxRepository)
+	mockPublisher := new(MockPublisher)
+	relay := outbox.NewRelay(mockRepo, mockPublisher, logger.NewNop(), testRelayConfig())
+
+	mockRepo.On("ClaimPending", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("database error")).Once()
+
+	processed, err := relay.ProcessBatch(context.Background())
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, processed)
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestRelay_Run_StopsOnContextCancel(t *testing.T) {
+	mockRepo := new(repository.MockOutboxRepository)
+	ch := make(chan *domain.Event, 1)
+	cfg := testRelayConfig()
+	cfg.PollInterval = 10 * time.Millisecond
+	relay := outbox.NewRelay(mockRepo, outbox.NewChannelPublisher(ch), logger.NewNop(), cfg)
+
+	event := newOutboxEvent(1, 1)
+	mockRepo.On("ClaimPending", mock.Anything, mock.Anything, mock.Anything).
+		Return([]*domain.OutboxEvent{event}, nil).Once()
+	mockRepo.On("ClaimPending", mock.Anything, mock.Anything, mock.Anything).
+		Return([]*domain.OutboxEvent{}, nil)
+	mockRepo.On("MarkPublished", mock.Anything, int64(1)).Return(nil).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		relay.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case got := <-ch:
+		assert.Equal(t, event.Event.ID, got.ID)
+	case <-time.After(time.Second):
+		t.Fatal("event was not published")
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("relay did not stop after context cancel")
+	}
+}
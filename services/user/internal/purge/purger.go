@@ -0,0 +1,102 @@
+package purge
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+	"go.uber.org/zap"
+)
+
+// PurgerConfig configures how often and how far back soft-deleted users are hard-deleted
+type PurgerConfig struct {
+	Interval time.Duration
+	// Retention is how long a soft-deleted user stays restorable before it is purged
+	Retention time.Duration
+	// LoginThrottleWindow is the failure window of the login limiter; unlocked throttles whose last failure is older are purged
+	LoginThrottleWindow time.Duration
+}
+
+// DefaultPurgerConfig returns the default purger configuration
+func DefaultPurgerConfig() PurgerConfig {
+	return PurgerConfig{
+		Interval:  time.Hour,
+		Retention: 30 * 24 * time.Hour,
+		// ログイン制限の既定のウィンドウと同じ
+		LoginThrottleWindow: 15 * time.Minute,
+	}
+}
+
+// Purger periodically hard-deletes users whose soft delete is older than the retention window,
+// expired idempotency keys and expired login throttles
+type Purger struct {
+	repo      repository.UserRepository
+	keys      repository.IdempotencyKeyRepository
+	throttles repository.LoginThrottleRepository
+	logger    logger.Logger
+	cfg       PurgerConfig
+}
+
+// NewPurger creates a new Purger
+func NewPurger(repo repository.UserRepository, keys repository.IdempotencyKeyRepository, throttles repository.LoginThrottleRepository, logger logger.Logger, cfg PurgerConfig) *Purger {
+	return &Purger{
+		repo:      repo,
+		keys:      keys,
+		throttles: throttles,
+		logger:    logger,
+		cfg:       cfg,
+	}
+}
+
+// Run purges on every interval until ctx is cancelled
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+
+	p.logger.Info(ctx, "Starting user purger",
+		zap.Duration("interval", p.cfg.Interval),
+		zap.Duration("retention", p.cfg.Retention))
+
+	for {
+		if _, err := p.PurgeOnce(ctx); err != nil && ctx.Err() == nil {
+			p.logger.Error(ctx, "Failed to purge deleted users", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			p.logger.Info(ctx, "Stopping user purger")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeOnce hard-deletes expired soft-deleted users, idempotency keys and login throttles and returns how many users were removed
+func (p *Purger) PurgeOnce(ctx context.Context) (int64, error) {
+	purged, err := p.repo.PurgeDeleted(ctx, p.cfg.Retention)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted users: %w", err)
+	}
+	if purged > 0 {
+		p.logger.Info(ctx, "Purged deleted users", zap.Int64("count", purged))
+	}
+
+	keys, err := p.keys.PurgeExpired(ctx)
+	if err != nil {
+		return purged, fmt.Errorf("failed to purge expired idempotency keys: %w", err)
+	}
+	if keys > 0 {
+		p.logger.Info(ctx, "Purged expired idempotency keys", zap.Int64("count", keys))
+	}
+
+	throttles, err := p.throttles.PurgeExpired(ctx, p.cfg.LoginThrottleWindow)
+	if err != nil {
+		return purged, fmt.Errorf("failed to purge expired login throttles: %w", err)
+	}
+	if throttles > 0 {
+		p.logger.Info(ctx, "Purged expired login throttles", zap.Int64("count", throttles))
+	}
+	return purged, nil
+}
@@ -0,0 +1,118 @@
+package purge_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/purge"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPurger_PurgeOnce(t *testing.T) {
+	retention := 7 * 24 * time.Hour
+	window := 15 * time.Minute
+
+	tests := []struct {
+		name      string
+		mockSetup func(*repository.MockUserRepository, *repository.MockIdempotencyKeyRepository, *repository.MockLoginThrottleRepository)
+		want      int64
+		wantErr   bool
+	}{
+		{
+			name: "正常系：保持期間を過ぎたユーザーと期限切れの冪等キー・ログイン制限を削除",
+			mockSetup: func(m *repository.MockUserRepository, k *repository.MockIdempotencyKeyRepository, l *repository.MockLoginThrottleRepository) {
+				m.On("PurgeDeleted", mock.Anything, retention).Return(int64(3), nil).Once()
+				k.On("PurgeExpired", mock.Anything).Return(int64(5), nil).Once()
+				l.On("PurgeExpired", mock.Anything, window).Return(int64(2), nil).Once()
+			},
+			want: 3,
+		},
+		{
+			name: "正常系：対象なし",
+			mockSetup: func(m *repository.MockUserRepository, k *repository.MockIdempotencyKeyRepository, l *repository.MockLoginThrottleRepository) {
+				m.On("PurgeDeleted", mock.Anything, retention).Return(int64(0), nil).Once()
+				k.On("PurgeExpired", mock.Anything).Return(int64(0), nil).Once()
+				l.On("PurgeExpired", mock.Anything, window).Return(int64(0), nil).Once()
+			},
+			want: 0,
+		},
+		{
+			name: "異常系：リポジトリエラー",
+			mockSetup: func(m *repository.MockUserRepository, k *repository.MockIdempotencyKeyRepository, l *repository.MockLoginThrottleRepository) {
+				m.On("PurgeDeleted", mock.Anything, retention).Return(int64(0), errors.New("database error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "異常系：冪等キーの削除に失敗",
+			mockSetup: func(m *repository.MockUserRepository, k *repository.MockIdempotencyKeyRepository, l *repository.MockLoginThrottleRepository) {
+				m.On("PurgeDeleted", mock.Anything, retention).Return(int64(1), nil).Once()
+				k.On("PurgeExpired", mock.Anything).Return(int64(0), errors.New("database error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "異常系：ログイン制限の削除に失敗",
+			mockSetup: func(m *repository.MockUserRepository, k *repository.MockIdempotencyKeyRepository, l *repository.MockLoginThrottleRepository) {
+				m.On("PurgeDeleted", mock.Anything, retention).Return(int64(1), nil).Once()
+				k.On("PurgeExpired", mock.Anything).Return(int64(0), nil).Once()
+				l.On("PurgeExpired", mock.Anything, window).Return(int64(0), errors.New("database error")).Once()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockUserRepository)
+			mockKeys := new(repository.MockIdempotencyKeyRepository)
+			mockThrottles := new(repository.MockLoginThrottleRepository)
+			tt.mockSetup(mockRepo, mockKeys, mockThrottles)
+			purger := purge.NewPurger(mockRepo, mockKeys, mockThrottles, logger.NewNop(), purge.PurgerConfig{Interval: time.Hour, Retention: retention, LoginThrottleWindow: window})
+
+			got, err := purger.PurgeOnce(context.Background())
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			mockRepo.AssertExpectations(t)
+			mockKeys.AssertExpectations(t)
+			mockThrottles.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPurger_Run_StopsOnCancel(t *testing.T) {
+	mockRepo := new(repository.MockUserRepository)
+	mockRepo.On("PurgeDeleted", mock.Anything, mock.Anything).Return(int64(0), nil)
+	mockKeys := new(repository.MockIdempotencyKeyRepository)
+	mockKeys.On("PurgeExpired", mock.Anything).Return(int64(0), nil)
+	mockThrottles := new(repository.MockLoginThrottleRepository)
+	mockThrottles.On("PurgeExpired", mock.Anything, mock.Anything).Return(int64(0), nil)
+	purger := purge.NewPurger(mockRepo, mockKeys, mockThrottles, logger.NewNop(), purge.PurgerConfig{Interval: 10 * time.Millisecond, Retention: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		purger.Run(ctx)
+		close(done)
+	}()
+
+	time.Sleep(30 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("purger did not stop after cancel")
+	}
+	mockRepo.AssertCalled(t, "PurgeDeleted", mock.Anything, time.Hour)
+}
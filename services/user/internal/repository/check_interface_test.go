@@ -34,4 +34,4 @@ func TestInterfaceImplementation(t *testing.T) {
 func processRepository(repo repository.UserRepository) {
 	// UserRepository型として使用
 	// ここに到達できる = インターフェースを満たしている
-}
\ No newline at end of file
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
@@ -9,9 +10,112 @@ import (
 
 type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) error
-	ListUsers(ctx context.Context, limit int32, offset int32) ([]*domain.User, error)
+	// CreateBatch inserts the users and their user.created events in a single transaction; if any row fails, none is inserted
+	CreateBatch(ctx context.Context, users []*domain.User) error
+	// ListTakenEmails returns which of the emails are already used by live users, ignoring case
+	ListTakenEmails(ctx context.Context, emails []domain.Email) ([]domain.Email, error)
+	ListUsers(ctx context.Context, filter domain.UserFilter, sort domain.UserSort, limit int32, offset int32) ([]*domain.User, error)
+	// ListUsersByCursor returns up to limit users on the cursor's side of its position, newest first
+	ListUsersByCursor(ctx context.Context, filter domain.UserFilter, cursor domain.UserCursor, limit int32) ([]*domain.User, error)
+	// CountUsers counts the users matching the filter; estimated is true when an unfiltered count of a large table is taken from planner statistics
+	CountUsers(ctx context.Context, filter domain.UserFilter) (count int64, estimated bool, err error)
+	// SearchUsers matches the query against names and emails by substring or trigram similarity, best match first
+	SearchUsers(ctx context.Context, query string, limit int32, offset int32) ([]*domain.UserSearchHit, error)
+	CountSearchUsers(ctx context.Context, query string) (int64, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	// GetByEmail matches the normalized email ignoring case; returns domain.ErrUserNotFound if none
 	GetByEmail(ctx context.Context, email domain.Email) (*domain.User, error)
+	// Update saves the user only if it is still at user.Version (then increments it);
+	// otherwise it returns domain.ErrConcurrentModification
 	Update(ctx context.Context, user *domain.User) error
+	// Patch writes only the columns set in changes, with the same version check as Update
+	Patch(ctx context.Context, user *domain.User, changes domain.UserPatch) error
+	// UpdatePassword replaces the password hash; returns domain.ErrUserNotFound if the user is missing or deleted
+	UpdatePassword(ctx context.Context, id uuid.UUID, hashedPassword domain.Password) error
+	// MarkEmailVerified marks email as verified; returns domain.ErrUserNotFound if the user no longer has that email
+	MarkEmailVerified(ctx context.Context, id uuid.UUID, email domain.Email) error
+	// Delete soft-deletes the user; deleting a missing or already deleted user is a no-op
 	Delete(ctx context.Context, id uuid.UUID) error
+	// Restore undoes a soft delete; returns domain.ErrUserNotFound unless the user is soft-deleted
+	Restore(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	// PurgeDeleted hard-deletes users soft-deleted more than retention ago and returns how many were removed
+	PurgeDeleted(ctx context.Context, retention time.Duration) (int64, error)
+	// GrantRole is idempotent; granting an already granted role is a no-op
+	GrantRole(ctx context.Context, userID uuid.UUID, role domain.Role) error
+	// RevokeRole is idempotent; revoking a role that is not granted is a no-op
+	RevokeRole(ctx context.Context, userID uuid.UUID, role domain.Role) error
+}
+
+// RefreshTokenRepository persists refresh tokens by their hash
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *domain.RefreshToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error)
+	// Revoke revokes a single active token; returns domain.ErrNotFound if it is unknown or already revoked
+	Revoke(ctx context.Context, id uuid.UUID) error
+	RevokeAllForUser(ctx context.Context, userID uuid.UUID) error
+}
+
+// PasswordResetTokenRepository persists password reset tokens by their hash
+type PasswordResetTokenRepository interface {
+	Create(ctx context.Context, token *domain.PasswordResetToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error)
+	// MarkUsed consumes a single unused token; returns domain.ErrNotFound if it is unknown or already used
+	MarkUsed(ctx context.Context, id uuid.UUID) error
+	InvalidateAllForUser(ctx context.Context, userID uuid.UUID) error
+}
+
+// EmailVerificationTokenRepository persists email verification tokens by their hash
+type EmailVerificationTokenRepository interface {
+	Create(ctx context.Context, token *domain.EmailVerificationToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*domain.EmailVerificationToken, error)
+	// MarkUsed consumes a single unused token; returns domain.ErrNotFound if it is unknown or already used
+	MarkUsed(ctx context.Context, id uuid.UUID) error
+	InvalidateAllForUser(ctx context.Context, userID uuid.UUID) error
+	// CountIssuedSince counts the tokens issued to the user at or after since, used or not
+	CountIssuedSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error)
+}
+
+// LoginThrottleRepository tracks failed login attempts per scope and subject
+type LoginThrottleRepository interface {
+	// Get returns domain.ErrNotFound if no failure has been recorded
+	Get(ctx context.Context, scope domain.LoginThrottleScope, subject string) (*domain.LoginThrottle, error)
+	// RecordFailure increments the failure count; failures older than window are forgotten
+	RecordFailure(ctx context.Context, scope domain.LoginThrottleScope, subject string, window time.Duration) (*domain.LoginThrottle, error)
+	// Lock blocks logins for lockout and clears the failure count
+	Lock(ctx context.Context, scope domain.LoginThrottleScope, subject string, lockout time.Duration) error
+	Reset(ctx context.Context, scope domain.LoginThrottleScope, subject string) error
+	// PurgeExpired deletes unlocked throttles whose last failure is older than window and returns how many were removed
+	PurgeExpired(ctx context.Context, window time.Duration) (int64, error)
+}
+
+// IdempotencyKeyRepository stores the responses of requests sent with an Idempotency-Key header
+type IdempotencyKeyRepository interface {
+	// Reserve saves key unless an unexpired key with the same scope and key exists;
+	// in that case it returns the existing key and reserved is false
+	Reserve(ctx context.Context, key *domain.IdempotencyKey) (existing *domain.IdempotencyKey, reserved bool, err error)
+	// Complete stores the response of a reserved key
+	Complete(ctx context.Context, key *domain.IdempotencyKey) error
+	// Release deletes a reserved key so that the request can be retried
+	Release(ctx context.Context, scope, key string) error
+	// PurgeExpired deletes expired keys and returns how many were removed
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+// AuditEventRepository stores the audit log of operations on users
+type AuditEventRepository interface {
+	Create(ctx context.Context, event *domain.AuditEvent) error
+	// CreateBatch stores the events in a single statement
+	CreateBatch(ctx context.Context, events []*domain.AuditEvent) error
+	// ListByUser returns the events of the user, newest first
+	ListByUser(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]*domain.AuditEvent, error)
+	CountByUser(ctx context.Context, userID uuid.UUID) (int64, error)
+}
+
+// OutboxRepository claims and updates outbox events for the relay worker
+type OutboxRepository interface {
+	// ClaimPending leases up to batchSize pending events; unacknowledged events become claimable again after lease
+	ClaimPending(ctx context.Context, batchSize int32, lease time.Duration) ([]*domain.OutboxEvent, error)
+	MarkPublished(ctx context.Context, seqID int64) error
+	MarkFailed(ctx context.Context, seqID int64, reason string) error
+	ScheduleRetry(ctx context.Context, seqID int64, reason string, delay time.Duration) error
 }
@@ -129,4 +129,4 @@ func ExplainInterfaceCheck() {
 // 1. 疎結合：実装が後から追加できる
 // 2. テスタビリティ：モックを簡単に作れる
 // 3. 柔軟性：既存のコードを変更せずに新しい実装を追加できる
-// 4. シンプル：継承階層などの複雑な仕組みが不要
\ No newline at end of file
+// 4. シンプル：継承階層などの複雑な仕組みが不要
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// コンパイル時にインターフェースを満たしているか確認
+var _ AuditEventRepository = (*MockAuditEventRepository)(nil)
+
+// MockAuditEventRepository is a mock implementation of AuditEventRepository interface
+type MockAuditEventRepository struct {
+	mock.Mock
+}
+
+// Create mocks the Create method
+func (m *MockAuditEventRepository) Create(ctx context.Context, event *domain.AuditEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+// CreateBatch mocks the CreateBatch method
+func (m *MockAuditEventRepository) CreateBatch(ctx context.Context, events []*domain.AuditEvent) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
+// ListByUser mocks the ListByUser method
+func (m *MockAuditEventRepository) ListByUser(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]*domain.AuditEvent, error) {
+	args := m.Called(ctx, userID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.AuditEvent), args.Error(1)
+}
+
+// CountByUser mocks the CountByUser method
+func (m *MockAuditEventRepository) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// コンパイル時にインターフェースを満たしているか確認
+var _ EmailVerificationTokenRepository = (*MockEmailVerificationTokenRepository)(nil)
+
+// MockEmailVerificationTokenRepository is a mock implementation of EmailVerificationTokenRepository interface
+type MockEmailVerificationTokenRepository struct {
+	mock.Mock
+}
+
+// Create mocks the Create method
+func (m *MockEmailVerificationTokenRepository) Create(ctx context.Context, token *domain.EmailVerificationToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+// GetByHash mocks the GetByHash method
+func (m *MockEmailVerificationTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.EmailVerificationToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EmailVerificationToken), args.Error(1)
+}
+
+// MarkUsed mocks the MarkUsed method
+func (m *MockEmailVerificationTokenRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// InvalidateAllForUser mocks the InvalidateAllForUser method
+func (m *MockEmailVerificationTokenRepository) InvalidateAllForUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// CountIssuedSince mocks the CountIssuedSince method
+func (m *MockEmailVerificationTokenRepository) CountIssuedSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	args := m.Called(ctx, userID, since)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// コンパイル時にインターフェースを満たしているか確認
+var _ IdempotencyKeyRepository = (*MockIdempotencyKeyRepository)(nil)
+
+// MockIdempotencyKeyRepository is a mock implementation of IdempotencyKeyRepository interface
+type MockIdempotencyKeyRepository struct {
+	mock.Mock
+}
+
+// Reserve mocks the Reserve method
+func (m *MockIdempotencyKeyRepository) Reserve(ctx context.Context, key *domain.IdempotencyKey) (*domain.IdempotencyKey, bool, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).(*domain.IdempotencyKey), args.Bool(1), args.Error(2)
+}
+
+// Complete mocks the Complete method
+func (m *MockIdempotencyKeyRepository) Complete(ctx context.Context, key *domain.IdempotencyKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+// Release mocks the Release method
+func (m *MockIdempotencyKeyRepository) Release(ctx context.Context, scope, key string) error {
+	args := m.Called(ctx, scope, key)
+	return args.Error(0)
+}
+
+// PurgeExpired mocks the PurgeExpired method
+func (m *MockIdempotencyKeyRepository) PurgeExpired(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
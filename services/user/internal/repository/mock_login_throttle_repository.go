@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// コンパイル時にインターフェースを満たしているか確認
+var _ LoginThrottleRepository = (*MockLoginThrottleRepository)(nil)
+
+// MockLoginThrottleRepository is a mock implementation of LoginThrottleRepository interface
+type MockLoginThrottleRepository struct {
+	mock.Mock
+}
+
+// Get mocks the Get method
+func (m *MockLoginThrottleRepository) Get(ctx context.Context, scope domain.LoginThrottleScope, subject string) (*domain.LoginThrottle, error) {
+	args := m.Called(ctx, scope, subject)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LoginThrottle), args.Error(1)
+}
+
+// RecordFailure mocks the RecordFailure method
+func (m *MockLoginThrottleRepository) RecordFailure(ctx context.Context, scope domain.LoginThrottleScope, subject string, window time.Duration) (*domain.LoginThrottle, error) {
+	args := m.Called(ctx, scope, subject, window)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LoginThrottle), args.Error(1)
+}
+
+// Lock mocks the Lock method
+func (m *MockLoginThrottleRepository) Lock(ctx context.Context, scope domain.LoginThrottleScope, subject string, lockout time.Duration) error {
+	args := m.Called(ctx, scope, subject, lockout)
+	return args.Error(0)
+}
+
+// Reset mocks the Reset method
+func (m *MockLoginThrottleRepository) Reset(ctx context.Context, scope domain.LoginThrottleScope, subject string) error {
+	args := m.Called(ctx, scope, subject)
+	return args.Error(0)
+}
+
+// PurgeExpired mocks the PurgeExpired method
+func (m *MockLoginThrottleRepository) PurgeExpired(ctx context.Context, window time.Duration) (int64, error) {
+	args := m.Called(ctx, window)
+	return args.Get(0).(int64), args.Error(1)
+}
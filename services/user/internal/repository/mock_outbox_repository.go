@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// コンパイル時にインターフェースを満たしているか確認
+var _ OutboxRepository = (*MockOutboxRepository)(nil)
+
+// MockOutboxRepository is a mock implementation of OutboxRepository interface
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+// ClaimPending mocks the ClaimPending method
+func (m *MockOutboxRepository) ClaimPending(ctx context.Context, batchSize int32, lease time.Duration) ([]*domain.OutboxEvent, error) {
+	args := m.Called(ctx, batchSize, lease)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.OutboxEvent), args.Error(1)
+}
+
+// MarkPublished mocks the MarkPublished method
+func (m *MockOutboxRepository) MarkPublished(ctx context.Context, seqID int64) error {
+	args := m.Called(ctx, seqID)
+	return args.Error(0)
+}
+
+// MarkFailed mocks the MarkFailed method
+func (m *MockOutboxRepository) MarkFailed(ctx context.Context, seqID int64, reason string) error {
+	args := m.Called(ctx, seqID, reason)
+	return args.Error(0)
+}
+
+// ScheduleRetry mocks the ScheduleRetry method
+func (m *MockOutboxRepository) ScheduleRetry(ctx context.Context, seqID int64, reason string, delay time.Duration) error {
+	args := m.Called(ctx, seqID, reason, delay)
+	return args.Error(0)
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// コンパイル時にインターフェースを満たしているか確認
+var _ PasswordResetTokenRepository = (*MockPasswordResetTokenRepository)(nil)
+
+// MockPasswordResetTokenRepository is a mock implementation of PasswordResetTokenRepository interface
+type MockPasswordResetTokenRepository struct {
+	mock.Mock
+}
+
+// Create mocks the Create method
+func (m *MockPasswordResetTokenRepository) Create(ctx context.Context, token *domain.PasswordResetToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+// GetByHash mocks the GetByHash method
+func (m *MockPasswordResetTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PasswordResetToken), args.Error(1)
+}
+
+// MarkUsed mocks the MarkUsed method
+func (m *MockPasswordResetTokenRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// InvalidateAllForUser mocks the InvalidateAllForUser method
+func (m *MockPasswordResetTokenRepository) InvalidateAllForUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
@@ -22,6 +23,21 @@ func (m *MockUserRepository) Create(ctx context.Context, user *domain.User) erro
 	return args.Error(0)
 }
 
+// CreateBatch mocks the CreateBatch method
+func (m *MockUserRepository) CreateBatch(ctx context.Context, users []*domain.User) error {
+	args := m.Called(ctx, users)
+	return args.Error(0)
+}
+
+// ListTakenEmails mocks the ListTakenEmails method
+func (m *MockUserRepository) ListTakenEmails(ctx context.Context, emails []domain.Email) ([]domain.Email, error) {
+	args := m.Called(ctx, emails)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Email), args.Error(1)
+}
+
 // GetByID mocks the GetByID method
 func (m *MockUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	args := m.Called(ctx, id)
@@ -46,17 +62,92 @@ func (m *MockUserRepository) Update(ctx context.Context, user *domain.User) erro
 	return args.Error(0)
 }
 
+// Patch mocks the Patch method
+func (m *MockUserRepository) Patch(ctx context.Context, user *domain.User, changes domain.UserPatch) error {
+	args := m.Called(ctx, user, changes)
+	return args.Error(0)
+}
+
+// GrantRole mocks the GrantRole method
+func (m *MockUserRepository) GrantRole(ctx context.Context, userID uuid.UUID, role domain.Role) error {
+	args := m.Called(ctx, userID, role)
+	return args.Error(0)
+}
+
+// RevokeRole mocks the RevokeRole method
+func (m *MockUserRepository) RevokeRole(ctx context.Context, userID uuid.UUID, role domain.Role) error {
+	args := m.Called(ctx, userID, role)
+	return args.Error(0)
+}
+
+// UpdatePassword mocks the UpdatePassword method
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, id uuid.UUID, hashedPassword domain.Password) error {
+	args := m.Called(ctx, id, hashedPassword)
+	return args.Error(0)
+}
+
+// MarkEmailVerified mocks the MarkEmailVerified method
+func (m *MockUserRepository) MarkEmailVerified(ctx context.Context, id uuid.UUID, email domain.Email) error {
+	args := m.Called(ctx, id, email)
+	return args.Error(0)
+}
+
 // Delete mocks the Delete method
 func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
+// ListUsersByCursor mocks the ListUsersByCursor method
+func (m *MockUserRepository) ListUsersByCursor(ctx context.Context, filter domain.UserFilter, cursor domain.UserCursor, limit int32) ([]*domain.User, error) {
+	args := m.Called(ctx, filter, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+
+// Restore mocks the Restore method
+func (m *MockUserRepository) Restore(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+// PurgeDeleted mocks the PurgeDeleted method
+func (m *MockUserRepository) PurgeDeleted(ctx context.Context, retention time.Duration) (int64, error) {
+	args := m.Called(ctx, retention)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 // ListUsers mocks the ListUsers method
-func (m *MockUserRepository) ListUsers(ctx context.Context, limit int32, offset int32) ([]*domain.User, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockUserRepository) ListUsers(ctx context.Context, filter domain.UserFilter, sort domain.UserSort, limit int32, offset int32) ([]*domain.User, error) {
+	args := m.Called(ctx, filter, sort, limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*domain.User), args.Error(1)
-}
\ No newline at end of file
+}
+
+// SearchUsers mocks the SearchUsers method
+func (m *MockUserRepository) SearchUsers(ctx context.Context, query string, limit int32, offset int32) ([]*domain.UserSearchHit, error) {
+	args := m.Called(ctx, query, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.UserSearchHit), args.Error(1)
+}
+
+// CountSearchUsers mocks the CountSearchUsers method
+func (m *MockUserRepository) CountSearchUsers(ctx context.Context, query string) (int64, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// CountUsers mocks the CountUsers method
+func (m *MockUserRepository) CountUsers(ctx context.Context, filter domain.UserFilter) (int64, bool, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Bool(1), args.Error(2)
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/auth"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+	"go.uber.org/zap"
+)
+
+// AuditLog records who did what to which user and reads the records back.
+// Recording is best effort: a failed write is logged and never fails the audited operation.
+type AuditLog struct {
+	repo   repository.AuditEventRepository
+	logger logger.Logger
+}
+
+// NewAuditLog creates a new AuditLog
+func NewAuditLog(repo repository.AuditEventRepository, logger logger.Logger) *AuditLog {
+	return &AuditLog{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// Record fills in the actor, request ID and client IP of the event from ctx and stores it
+func (a *AuditLog) Record(ctx context.Context, event *domain.AuditEvent) {
+	a.fromContext(ctx, event)
+	// リクエストが中断されても、実行済みの操作の記録は残す
+	if err := a.repo.Create(context.WithoutCancel(ctx), event); err != nil {
+		a.logger.Error(ctx, "Failed to record audit event",
+			zap.String("action", string(event.Action)),
+			zap.String("outcome", string(event.Outcome)),
+			zap.Error(err))
+	}
+}
+
+// RecordBatch stores events made in the same request at once
+func (a *AuditLog) RecordBatch(ctx context.Context, events []*domain.AuditEvent) {
+	if len(events) == 0 {
+		return
+	}
+	for _, event := range events {
+		a.fromContext(ctx, event)
+	}
+	if err := a.repo.CreateBatch(context.WithoutCancel(ctx), events); err != nil {
+		a.logger.Error(ctx, "Failed to record audit events", zap.Int("events", len(events)), zap.Error(err))
+	}
+}
+
+// fromContext sets the fields of the event that describe the request it was made in
+func (a *AuditLog) fromContext(ctx context.Context, event *domain.AuditEvent) {
+	if event.ActorID == nil {
+		if principal, ok := auth.PrincipalFromContext(ctx); ok {
+			actorID := principal.UserID
+			event.ActorID = &actorID
+		}
+	}
+	info := auth.RequestInfoFromContext(ctx)
+	event.RequestID = info.RequestID
+	event.ClientIP = info.ClientIP
+}
+
+// List returns the events of the user, newest first, and the total number of them
+func (a *AuditLog) List(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]*domain.AuditEvent, int64, error) {
+	events, err := a.repo.ListByUser(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := a.repo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+type ListUserAuditEventsRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+	Limit  int32     `json:"limit"`
+	Offset int32     `json:"offset"`
+}
+
+type AuditEventResponse struct {
+	ID         uuid.UUID            `json:"id"`
+	Action     domain.AuditAction   `json:"action"`
+	Outcome    domain.AuditOutcome  `json:"outcome"`
+	ActorID    *uuid.UUID           `json:"actor_id,omitempty"`
+	UserID     *uuid.UUID           `json:"user_id,omitempty"`
+	RequestID  string               `json:"request_id,omitempty"`
+	ClientIP   string               `json:"client_ip,omitempty"`
+	Changes    []domain.AuditChange `json:"changes"`
+	Error      string               `json:"error,omitempty"`
+	OccurredAt time.Time            `json:"occurred_at"`
+}
+
+type ListUserAuditEventsResponse struct {
+	Events []*AuditEventResponse `json:"events"`
+	// TotalCount is the number of events of the user across all pages
+	TotalCount int64 `json:"total_count"`
+}
+
+// ListUserAuditEvents returns the audit log of the user, newest first; the log outlives the user, so the user need not exist
+func (s *userService) ListUserAuditEvents(ctx context.Context, req ListUserAuditEventsRequest) (*ListUserAuditEventsResponse, error) {
+	if req.UserID == uuid.Nil {
+		return nil, domain.ErrInvalidID
+	}
+	if req.Limit <= 0 {
+		return nil, domain.ErrInvalidLimit
+	}
+	if req.Offset < 0 {
+		return nil, domain.ErrInvalidOffset
+	}
+
+	events, total, err := s.audit.List(ctx, req.UserID, req.Limit, req.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ListUserAuditEventsResponse{
+		Events:     make([]*AuditEventResponse, 0, len(events)),
+		TotalCount: total,
+	}
+	for _, event := range events {
+		resp.Events = append(resp.Events, &AuditEventResponse{
+			ID:         event.ID,
+			Action:     event.Action,
+			Outcome:    event.Outcome,
+			ActorID:    event.ActorID,
+			UserID:     event.UserID,
+			RequestID:  event.RequestID,
+			ClientIP:   event.ClientIP,
+			Changes:    event.Changes,
+			Error:      event.Error,
+			OccurredAt: event.OccurredAt,
+		})
+	}
+	return resp, nil
+}
+
+// auditUserChange records the outcome of an operation on the user; the field diff is only recorded on success
+func (s *userService) auditUserChange(ctx context.Context, action domain.AuditAction, userID uuid.UUID, before, after *domain.User, err error) {
+	event := domain.NewAuditEvent(action, userID, err)
+	if err == nil {
+		event.Changes = domain.DiffUsers(before, after)
+	}
+	s.audit.Record(ctx, event)
+}
+
+// auditAuthentication records a login attempt; userID is uuid.Nil when the email is not registered
+func (s *userService) auditAuthentication(ctx context.Context, userID uuid.UUID, err error) {
+	event := domain.NewAuditEvent(domain.AuditActionUserAuthenticate, userID, err)
+	// ログインに成功した呼び出し元は本人とみなす
+	if err == nil {
+		event.ActorID = &userID
+	}
+	s.audit.Record(ctx, event)
+}
+
+// auditRoleChange records a grant (granted) or revocation of the role
+func (s *userService) auditRoleChange(ctx context.Context, userID uuid.UUID, role domain.Role, granted bool, err error) {
+	action, change := domain.AuditActionRoleRevoke, domain.AuditChange{Field: "roles", Before: role}
+	if granted {
+		action, change = domain.AuditActionRoleGrant, domain.AuditChange{Field: "roles", After: role}
+	}
+	event := domain.NewAuditEvent(action, userID, err)
+	if err == nil {
+		event.Changes = []domain.AuditChange{change}
+	}
+	s.audit.Record(ctx, event)
+}
+
+// snapshotUser copies the user before it is modified in place, so that the change can be audited
+func snapshotUser(user *domain.User) *domain.User {
+	snapshot := *user
+	snapshot.Roles = slices.Clone(user.Roles)
+	return &snapshot
+}
+
+// userIDOf returns the ID of the user, or uuid.Nil if it is not known
+func userIDOf(user *domain.User) uuid.UUID {
+	if user == nil {
+		return uuid.Nil
+	}
+	return user.ID
+}
@@ -0,0 +1,235 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/auth"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestAuditService creates a user service that records audit events to the given repository
+func newTestAuditService(repo *repository.MockUserRepository, hasher *MockPasswordHasher, auditRepo *repository.MockAuditEventRepository) service.UserService {
+	return newTestService(testServiceDeps{repo: repo, hasher: hasher, audit: service.NewAuditLog(auditRepo, createTestLogger())})
+}
+
+// captureAuditEvents makes the repository keep every event recorded one by one
+func captureAuditEvents(repo *repository.MockAuditEventRepository) *[]*domain.AuditEvent {
+	events := []*domain.AuditEvent{}
+	repo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		events = append(events, args.Get(1).(*domain.AuditEvent))
+	}).Return(nil)
+	return &events
+}
+
+func TestUserService_Audit_UpdateUser(t *testing.T) {
+	actorID := uuid.New()
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: actorID, Roles: []domain.Role{domain.RoleAdmin}})
+	ctx = auth.WithRequestInfo(ctx, auth.RequestInfo{RequestID: "host/abc-000001", ClientIP: "192.0.2.1"})
+
+	t.Run("正常系：変更したフィールドを実行者・リクエストとともに記録する", func(t *testing.T) {
+		mockRepo := new(repository.MockUserRepository)
+		auditRepo := new(repository.MockAuditEventRepository)
+		events := captureAuditEvents(auditRepo)
+		svc := newTestAuditService(mockRepo, new(MockPasswordHasher), auditRepo)
+
+		user := &domain.User{ID: uuid.New(), Email: "alice@example.com", Name: "Alice", Password: "hashedPassword", Version: 1}
+		mockRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil).Once()
+		mockRepo.On("Update", mock.Anything, user).Return(nil).Once()
+
+		err := svc.UpdateUser(ctx, service.UpdateUserRequest{ID: user.ID, Name: "Alice Smith"})
+
+		require.NoError(t, err)
+		require.Len(t, *events, 1)
+		event := (*events)[0]
+		assert.Equal(t, domain.AuditActionUserUpdate, event.Action)
+		assert.Equal(t, domain.AuditOutcomeSuccess, event.Outcome)
+		require.NotNil(t, event.ActorID)
+		assert.Equal(t, actorID, *event.ActorID)
+		require.NotNil(t, event.UserID)
+		assert.Equal(t, user.ID, *event.UserID)
+		assert.Equal(t, "host/abc-000001", event.RequestID)
+		assert.Equal(t, "192.0.2.1", event.ClientIP)
+		assert.Equal(t, []domain.AuditChange{{Field: "name", Before: "Alice", After: "Alice Smith"}}, event.Changes)
+	})
+
+	t.Run("異常系：失敗も理由とともに記録し、変更は記録しない", func(t *testing.T) {
+		mockRepo := new(repository.MockUserRepository)
+		auditRepo := new(repository.MockAuditEventRepository)
+		events := captureAuditEvents(auditRepo)
+		svc := newTestAuditService(mockRepo, new(MockPasswordHasher), auditRepo)
+
+		user := &domain.User{ID: uuid.New(), Email: "alice@example.com", Name: "Alice", Password: "hashedPassword", Version: 2}
+
+		mockRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil).Once()
+
+		err := svc.UpdateUser(ctx, service.UpdateUserRequest{ID: user.ID, Name: "Alice Smith", Version: 1})
+
+		assert.ErrorIs(t, err, domain.ErrConcurrentModification)
+		require.Len(t, *events, 1)
+		event := (*events)[0]
+		assert.Equal(t, domain.AuditOutcomeFailure, event.Outcome)
+		assert.Equal(t, domain.ErrConcurrentModification.Error(), event.Error)
+		assert.Empty(t, event.Changes)
+	})
+
+	t.Run("正常系：記録に失敗しても操作は成功する", func(t *testing.T) {
+		mockRepo := new(repository.MockUserRepository)
+		auditRepo := new(repository.MockAuditEventRepository)
+		auditRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("database error")).Once()
+		svc := newTestAuditService(mockRepo, new(MockPasswordHasher), auditRepo)
+
+		user := &domain.User{ID: uuid.New(), Email: "alice@example.com", Name: "Alice", Password: "hashedPassword", Version: 1}
+		mockRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil).Once()
+		mockRepo.On("Update", mock.Anything, user).Return(nil).Once()
+
+		err := svc.UpdateUser(ctx, service.UpdateUserRequest{ID: user.ID, Name: "Alice Smith"})
+
+		assert.NoError(t, err)
+		auditRepo.AssertExpectations(t)
+	})
+}
+
+func TestUserService_Audit_CreateUser(t *testing.T) {
+	mockRepo := new(repository.MockUserRepository)
+	mockHasher := new(MockPasswordHasher)
+	auditRepo := new(repository.MockAuditEventRepository)
+	events := captureAuditEvents(auditRepo)
+	svc := newTestAuditService(mockRepo, mockHasher, auditRepo)
+
+	mockRepo.On("GetByEmail", mock.Anything, domain.Email("alice@example.com")).Return(nil, domain.ErrUserNotFound).Maybe()
+	mockHasher.On("Hash", domain.Password("password123")).Return("hashedPassword", nil).Once()
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil).Once()
+
+	resp, err := svc.CreateUser(context.Background(), service.CreateUserRequest{
+		Email:    "alice@example.com",
+		Name:     "Alice",
+		Password: "password123",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, *events, 1)
+	event := (*events)[0]
+	assert.Equal(t, domain.AuditActionUserCreate, event.Action)
+	// サインアップは匿名
+	assert.Nil(t, event.ActorID)
+	require.NotNil(t, event.UserID)
+	assert.Equal(t, resp.ID, *event.UserID)
+	// パスワードのハッシュは記録しない
+	assert.Contains(t, event.Changes, domain.AuditChange{Field: "password", Before: nil, After: domain.AuditRedacted})
+	for _, change := range event.Changes {
+		assert.NotEqual(t, "hashedPassword", change.After)
+	}
+}
+
+func TestUserService_Audit_AuthenticateUser(t *testing.T) {
+	t.Run("異常系：未登録のメールアドレスは対象ユーザーなしで記録する", func(t *testing.T) {
+		mockRepo := new(repository.MockUserRepository)
+		auditRepo := new(repository.MockAuditEventRepository)
+		events := captureAuditEvents(auditRepo)
+		mockHasher := new(MockPasswordHasher)
+		svc := newTestAuditService(mockRepo, mockHasher, auditRepo)
+
+		mockRepo.On("GetByEmail", mock.Anything, domain.Email("nobody@example.com")).Return(nil, domain.ErrUserNotFound).Once()
+		mockHasher.On("Compare", mock.Anything, "password").Return(false).Once()
+
+		_, err := svc.AuthenticateUser(context.Background(), service.AuthenticateUserRequest{Email: "nobody@example.com", Password: "password"})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+		require.Len(t, *events, 1)
+		event := (*events)[0]
+		assert.Equal(t, domain.AuditActionUserAuthenticate, event.Action)
+		assert.Equal(t, domain.AuditOutcomeFailure, event.Outcome)
+		assert.Nil(t, event.UserID)
+		assert.Nil(t, event.ActorID)
+	})
+}
+
+func TestUserService_ListUserAuditEvents(t *testing.T) {
+	userID := uuid.New()
+	errDatabase := errors.New("database error")
+	stored := []*domain.AuditEvent{
+		{
+			ID:         uuid.New(),
+			Action:     domain.AuditActionUserUpdate,
+			Outcome:    domain.AuditOutcomeSuccess,
+			UserID:     &userID,
+			Changes:    []domain.AuditChange{{Field: "name", Before: "Alice", After: "Alice Smith"}},
+			OccurredAt: time.Now(),
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		req       service.ListUserAuditEventsRequest
+		setupMock func(repo *repository.MockAuditEventRepository)
+		wantErr   error
+		wantTotal int64
+	}{
+		{
+			name: "正常系：ページと総件数を返す",
+			req:  service.ListUserAuditEventsRequest{UserID: userID, Limit: 10, Offset: 0},
+			setupMock: func(repo *repository.MockAuditEventRepository) {
+				repo.On("ListByUser", mock.Anything, userID, int32(10), int32(0)).Return(stored, nil).Once()
+				repo.On("CountByUser", mock.Anything, userID).Return(int64(1), nil).Once()
+			},
+			wantTotal: 1,
+		},
+		{
+			name:      "異常系：IDが空",
+			req:       service.ListUserAuditEventsRequest{Limit: 10},
+			setupMock: func(repo *repository.MockAuditEventRepository) {},
+			wantErr:   domain.ErrInvalidID,
+		},
+		{
+			name:      "異常系：limitが0",
+			req:       service.ListUserAuditEventsRequest{UserID: userID, Limit: 0},
+			setupMock: func(repo *repository.MockAuditEventRepository) {},
+			wantErr:   domain.ErrInvalidLimit,
+		},
+		{
+			name:      "異常系：offsetが負",
+			req:       service.ListUserAuditEventsRequest{UserID: userID, Limit: 10, Offset: -1},
+			setupMock: func(repo *repository.MockAuditEventRepository) {},
+			wantErr:   domain.ErrInvalidOffset,
+		},
+		{
+			name: "異常系：リポジトリエラー",
+			req:  service.ListUserAuditEventsRequest{UserID: userID, Limit: 10},
+			setupMock: func(repo *repository.MockAuditEventRepository) {
+				repo.On("ListByUser", mock.Anything, userID, int32(10), int32(0)).Return(nil, errDatabase).Once()
+			},
+			wantErr: errDatabase,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			auditRepo := new(repository.MockAuditEventRepository)
+			tc.setupMock(auditRepo)
+			svc := newTestAuditService(new(repository.MockUserRepository), new(MockPasswordHasher), auditRepo)
+
+			resp, err := svc.ListUserAuditEvents(context.Background(), tc.req)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantTotal, resp.TotalCount)
+				require.Len(t, resp.Events, len(stored))
+				assert.Equal(t, stored[0].ID, resp.Events[0].ID)
+				assert.Equal(t, stored[0].Changes, resp.Events[0].Changes)
+			}
+			auditRepo.AssertExpectations(t)
+		})
+	}
+}
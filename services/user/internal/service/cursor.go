@@ -0,0 +1,79 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+)
+
+// CursorCodec turns keyset positions into opaque, tamper-proof page cursors
+type CursorCodec interface {
+	Encode(cursor domain.UserCursor) string
+	Decode(token string) (domain.UserCursor, error)
+}
+
+// cursorPayload is the signed content of a cursor; created_at keeps the microsecond precision of Postgres
+type cursorPayload struct {
+	CreatedAt int64                  `json:"t"`
+	ID        uuid.UUID              `json:"id"`
+	Direction domain.CursorDirection `json:"d"`
+}
+
+type hmacCursorCodec struct {
+	secret []byte
+}
+
+// NewHMACCursorCodec creates a CursorCodec that signs cursors with HMAC-SHA256
+func NewHMACCursorCodec(secret []byte) CursorCodec {
+	return &hmacCursorCodec{secret: secret}
+}
+
+func (c *hmacCursorCodec) Encode(cursor domain.UserCursor) string {
+	// cursorPayload は常に JSON に変換できる
+	payload, _ := json.Marshal(cursorPayload{
+		CreatedAt: cursor.CreatedAt.UnixMicro(),
+		ID:        cursor.ID,
+		Direction: cursor.Direction,
+	})
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + c.sign(encoded)
+}
+
+func (c *hmacCursorCodec) Decode(token string) (domain.UserCursor, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(c.sign(encoded))) {
+		return domain.UserCursor{}, domain.ErrInvalidCursor
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return domain.UserCursor{}, fmt.Errorf("failed to decode cursor: %w", domain.ErrInvalidCursor)
+	}
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return domain.UserCursor{}, fmt.Errorf("failed to unmarshal cursor: %w", domain.ErrInvalidCursor)
+	}
+
+	cursor := domain.UserCursor{
+		CreatedAt: time.UnixMicro(payload.CreatedAt).UTC(),
+		ID:        payload.ID,
+		Direction: payload.Direction,
+	}
+	if err := cursor.Validate(); err != nil {
+		return domain.UserCursor{}, err
+	}
+	return cursor, nil
+}
+
+func (c *hmacCursorCodec) sign(encoded string) string {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
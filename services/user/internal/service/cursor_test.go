@@ -0,0 +1,52 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHMACCursorCodec_RoundTrip(t *testing.T) {
+	codec := service.NewHMACCursorCodec([]byte("secret"))
+	// Postgres の timestamptz と同じマイクロ秒精度が保たれること
+	cursor := domain.UserCursor{
+		CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 123456000, time.UTC),
+		ID:        uuid.New(),
+		Direction: domain.CursorPrev,
+	}
+
+	decoded, err := codec.Decode(codec.Encode(cursor))
+
+	require.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+}
+
+func TestHMACCursorCodec_Decode_Invalid(t *testing.T) {
+	codec := service.NewHMACCursorCodec([]byte("secret"))
+	valid := codec.Encode(domain.UserCursor{CreatedAt: time.Now(), ID: uuid.New(), Direction: domain.CursorNext})
+	payload, signature, _ := strings.Cut(valid, ".")
+
+	testCases := []struct {
+		name  string
+		token string
+	}{
+		{name: "異常系：署名なし", token: payload},
+		{name: "異常系：署名の改ざん", token: payload + "." + signature[1:]},
+		{name: "異常系：別の鍵で署名", token: service.NewHMACCursorCodec([]byte("other")).Encode(domain.UserCursor{CreatedAt: time.Now(), ID: uuid.New(), Direction: domain.CursorNext})},
+		{name: "異常系：不正な形式", token: "not-a-cursor"},
+		{name: "異常系：空", token: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := codec.Decode(tc.token)
+			assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+	"go.uber.org/zap"
+)
+
+// EmailVerificationConfig configures the email verification workflow
+type EmailVerificationConfig struct {
+	// Required blocks AuthenticateUser (domain.ErrEmailNotVerified) until the email address is verified
+	Required bool
+	// MaxSendsPerWindow is the number of verification mails a user can receive within SendWindow
+	MaxSendsPerWindow int
+	SendWindow        time.Duration
+}
+
+// DefaultEmailVerificationConfig returns the default email verification configuration
+func DefaultEmailVerificationConfig() EmailVerificationConfig {
+	return EmailVerificationConfig{
+		Required:          false,
+		MaxSendsPerWindow: 5,
+		SendWindow:        time.Hour,
+	}
+}
+
+// EmailVerifier issues, delivers and consumes email verification tokens
+type EmailVerifier struct {
+	repo     repository.EmailVerificationTokenRepository
+	issuer   TokenIssuer
+	notifier Notifier
+	cfg      EmailVerificationConfig
+	logger   logger.Logger
+	now      func() time.Time
+}
+
+// NewEmailVerifier creates a new EmailVerifier
+func NewEmailVerifier(repo repository.EmailVerificationTokenRepository, issuer TokenIssuer, notifier Notifier, cfg EmailVerificationConfig, logger logger.Logger) *EmailVerifier {
+	return &EmailVerifier{
+		repo:     repo,
+		issuer:   issuer,
+		notifier: notifier,
+		cfg:      cfg,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// Required reports whether unverified users are blocked from logging in
+func (v *EmailVerifier) Required() bool {
+	return v.cfg.Required
+}
+
+// Send issues a new token for the current email of the user and delivers it; older tokens are invalidated.
+// It returns domain.ErrTooManyEmails once the user has received MaxSendsPerWindow mails within SendWindow.
+func (v *EmailVerifier) Send(ctx context.Context, user *domain.User) error {
+	sent, err := v.repo.CountIssuedSince(ctx, user.ID, v.now().Add(-v.cfg.SendWindow))
+	if err != nil {
+		return fmt.Errorf("failed to count email verification tokens: %w", err)
+	}
+	if sent >= v.cfg.MaxSendsPerWindow {
+		v.logger.Warn(ctx, "Email verification rate limited",
+			zap.String("user_id", user.ID.String()),
+			zap.Int("sent", sent),
+			zap.Duration("window", v.cfg.SendWindow))
+		return domain.ErrTooManyEmails
+	}
+
+	// 未使用の古いトークンは無効化し、有効なトークンを常に 1 つに保つ
+	if err := v.repo.InvalidateAllForUser(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to invalidate email verification tokens: %w", err)
+	}
+
+	token, tokenHash, expiresAt, err := v.issuer.IssueEmailVerificationToken()
+	if err != nil {
+		return err
+	}
+	if err := v.repo.Create(ctx, domain.NewEmailVerificationToken(user, tokenHash, expiresAt)); err != nil {
+		return fmt.Errorf("failed to save email verification token: %w", err)
+	}
+
+	if err := v.notifier.SendEmailVerification(ctx, user, token, expiresAt); err != nil {
+		return fmt.Errorf("failed to send email verification: %w", err)
+	}
+	return nil
+}
+
+// Consume marks the token as used and returns it; unknown, used or expired tokens are domain.ErrInvalidToken
+func (v *EmailVerifier) Consume(ctx context.Context, token string) (*domain.EmailVerificationToken, error) {
+	verification, err := v.repo.GetByHash(ctx, v.issuer.HashEmailVerificationToken(token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to get email verification token: %w", err)
+	}
+	if !verification.IsActive(v.now()) {
+		return nil, domain.ErrInvalidToken
+	}
+
+	if err := v.repo.MarkUsed(ctx, verification.ID); err != nil {
+		// 同時に使用された場合
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to mark email verification token as used: %w", err)
+	}
+	return verification, nil
+}
@@ -9,10 +9,26 @@ import (
 
 type UserService interface {
 	CreateUser(ctx context.Context, req CreateUserRequest) (*UserResponse, error)
+	BatchCreateUsers(ctx context.Context, req BatchCreateUsersRequest) (*BatchCreateUsersResponse, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (*UserResponse, error)
 	GetUserByEmail(ctx context.Context, email domain.Email) (*UserResponse, error)
 	UpdateUser(ctx context.Context, req UpdateUserRequest) error
+	PatchUser(ctx context.Context, req PatchUserRequest) (*UserResponse, error)
 	DeleteUser(ctx context.Context, req DeleteUserRequest) error
-	ListUsers(ctx context.Context, req ListUsersRequest) ([]*UserResponse, error)
-	AuthenticateUser(ctx context.Context, req AuthenticateUserRequest) error
+	RestoreUser(ctx context.Context, req RestoreUserRequest) (*UserResponse, error)
+	ListUsers(ctx context.Context, req ListUsersRequest) (*ListUsersResponse, error)
+	SearchUsers(ctx context.Context, req SearchUsersRequest) (*SearchUsersResponse, error)
+	ExportUsers(ctx context.Context, req ExportUsersRequest, fn func(*UserResponse) error) error
+	AuthenticateUser(ctx context.Context, req AuthenticateUserRequest) (*AuthTokens, error)
+	UnlockUser(ctx context.Context, req UnlockUserRequest) error
+	RefreshToken(ctx context.Context, req RefreshTokenRequest) (*AuthTokens, error)
+	Logout(ctx context.Context, req LogoutRequest) error
+	ChangePassword(ctx context.Context, req ChangePasswordRequest) error
+	RequestPasswordReset(ctx context.Context, req RequestPasswordResetRequest) error
+	ConfirmPasswordReset(ctx context.Context, req ConfirmPasswordResetRequest) error
+	VerifyEmail(ctx context.Context, req VerifyEmailRequest) error
+	ResendEmailVerification(ctx context.Context, req ResendEmailVerificationRequest) error
+	GrantRole(ctx context.Context, req GrantRoleRequest) error
+	RevokeRole(ctx context.Context, req RevokeRoleRequest) error
+	ListUserAuditEvents(ctx context.Context, req ListUserAuditEventsRequest) (*ListUserAuditEventsResponse, error)
 }
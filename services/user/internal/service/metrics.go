@@ -0,0 +1,21 @@
+package service
+
+// Metrics counts business events for monitoring
+type Metrics interface {
+	// UsersCreated counts users created by sign-up or bulk import
+	UsersCreated(n int)
+	// AuthenticationAttempted counts a login attempt
+	AuthenticationAttempted(success bool)
+}
+
+// nopMetrics discards every event
+type nopMetrics struct{}
+
+// NewNopMetrics creates a Metrics that records nothing, for tools and tests that do not export metrics
+func NewNopMetrics() Metrics {
+	return nopMetrics{}
+}
+
+func (nopMetrics) UsersCreated(int) {}
+
+func (nopMetrics) AuthenticationAttempted(bool) {}
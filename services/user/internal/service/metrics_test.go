@@ -0,0 +1,75 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMetrics is a mock implementation of Metrics
+type MockMetrics struct {
+	mock.Mock
+}
+
+func (m *MockMetrics) UsersCreated(n int) {
+	m.Called(n)
+}
+
+func (m *MockMetrics) AuthenticationAttempted(success bool) {
+	m.Called(success)
+}
+
+// newTestMetricsService creates a user service that reports business events to the given metrics
+func newTestMetricsService(repo *repository.MockUserRepository, hasher *MockPasswordHasher, metrics service.Metrics) service.UserService {
+	return newTestService(testServiceDeps{repo: repo, hasher: hasher, metrics: metrics})
+}
+
+func TestUserService_Metrics(t *testing.T) {
+	t.Run("正常系：作成したユーザーを数える", func(t *testing.T) {
+		mockRepo := new(repository.MockUserRepository)
+		mockHasher := new(MockPasswordHasher)
+		metrics := new(MockMetrics)
+		metrics.On("UsersCreated", 1).Once()
+		svc := newTestMetricsService(mockRepo, mockHasher, metrics)
+
+		mockRepo.On("GetByEmail", mock.Anything, domain.Email("alice@example.com")).Return(nil, domain.ErrUserNotFound).Once()
+		mockHasher.On("Hash", domain.Password("password123")).Return("hashedPassword", nil).Once()
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil).Once()
+
+		_, err := svc.CreateUser(context.Background(), service.CreateUserRequest{Email: "alice@example.com", Name: "Alice", Password: "password123"})
+
+		assert.NoError(t, err)
+		metrics.AssertExpectations(t)
+	})
+
+	t.Run("異常系：作成に失敗したユーザーは数えない", func(t *testing.T) {
+		metrics := new(MockMetrics)
+		svc := newTestMetricsService(new(repository.MockUserRepository), new(MockPasswordHasher), metrics)
+
+		_, err := svc.CreateUser(context.Background(), service.CreateUserRequest{Email: "alice@example.com", Name: "Alice", Password: "short"})
+
+		assert.Error(t, err)
+		metrics.AssertNotCalled(t, "UsersCreated", mock.Anything)
+	})
+
+	t.Run("異常系：ログインの失敗を数える", func(t *testing.T) {
+		mockRepo := new(repository.MockUserRepository)
+		metrics := new(MockMetrics)
+		metrics.On("AuthenticationAttempted", false).Once()
+		mockHasher := new(MockPasswordHasher)
+		svc := newTestMetricsService(mockRepo, mockHasher, metrics)
+
+		mockRepo.On("GetByEmail", mock.Anything, domain.Email("nobody@example.com")).Return(nil, domain.ErrUserNotFound).Once()
+		mockHasher.On("Compare", mock.Anything, "password").Return(false).Once()
+
+		_, err := svc.AuthenticateUser(context.Background(), service.AuthenticateUserRequest{Email: "nobody@example.com", Password: "password"})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+		metrics.AssertExpectations(t)
+	})
+}
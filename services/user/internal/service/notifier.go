@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"go.uber.org/zap"
+)
+
+// Notifier delivers out-of-band messages (e.g. password reset links) to users
+type Notifier interface {
+	SendPasswordReset(ctx context.Context, user *domain.User, token string, expiresAt time.Time) error
+	SendEmailVerification(ctx context.Context, user *domain.User, token string, expiresAt time.Time) error
+}
+
+// NotificationKind identifies the message written by the writer notifier
+type NotificationKind string
+
+const (
+	NotificationPasswordReset     NotificationKind = "password_reset"
+	NotificationEmailVerification NotificationKind = "email_verification"
+)
+
+// logNotifier is a dummy notifier that only records in the log that a message would have been sent
+type logNotifier struct {
+	logger logger.Logger
+}
+
+// NewLogNotifier creates a Notifier for local development; the tokens are redacted, as anyone reading the log could use them
+// to take over the account, so use the writer notifier to actually receive them
+func NewLogNotifier(logger logger.Logger) Notifier {
+	return &logNotifier{
+		logger: logger,
+	}
+}
+
+func (n *logNotifier) SendPasswordReset(ctx context.Context, user *domain.User, token string, expiresAt time.Time) error {
+	return n.send(ctx, "Password reset requested (dummy delivery)", user, token, expiresAt)
+}
+
+func (n *logNotifier) SendEmailVerification(ctx context.Context, user *domain.User, token string, expiresAt time.Time) error {
+	return n.send(ctx, "Email verification requested (dummy delivery)", user, token, expiresAt)
+}
+
+func (n *logNotifier) send(ctx context.Context, msg string, user *domain.User, token string, expiresAt time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	n.logger.Info(ctx, msg,
+		zap.String("user_id", user.ID.String()),
+		logger.Email("email", string(user.Email)),
+		logger.Redacted("token", token),
+		zap.Time("expires_at", expiresAt))
+	return nil
+}
+
+// Notification is a message written by the writer notifier
+type Notification struct {
+	Kind      NotificationKind `json:"kind"`
+	UserID    uuid.UUID        `json:"user_id"`
+	Email     domain.Email     `json:"email"`
+	Token     string           `json:"token"`
+	ExpiresAt time.Time        `json:"expires_at"`
+	SentAt    time.Time        `json:"sent_at"`
+}
+
+// writerNotifier writes messages as newline-delimited JSON (a local mailbox file for development)
+type writerNotifier struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewWriterNotifier creates a Notifier that appends each message to w; the tokens are written in plain text
+func NewWriterNotifier(w io.Writer) Notifier {
+	return &writerNotifier{enc: json.NewEncoder(w)}
+}
+
+func (n *writerNotifier) SendPasswordReset(ctx context.Context, user *domain.User, token string, expiresAt time.Time) error {
+	return n.write(ctx, NotificationPasswordReset, user, token, expiresAt)
+}
+
+func (n *writerNotifier) SendEmailVerification(ctx context.Context, user *domain.User, token string, expiresAt time.Time) error {
+	return n.write(ctx, NotificationEmailVerification, user, token, expiresAt)
+}
+
+func (n *writerNotifier) write(ctx context.Context, kind NotificationKind, user *domain.User, token string, expiresAt time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	err := n.enc.Encode(Notification{
+		Kind:      kind,
+		UserID:    user.ID,
+		Email:     user.Email,
+		Token:     token,
+		ExpiresAt: expiresAt,
+		SentAt:    time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write %s notification: %w", kind, err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package service_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWriterNotifier(t *testing.T) {
+	var buf bytes.Buffer
+	notifier := service.NewWriterNotifier(&buf)
+	user := &domain.User{ID: uuid.New(), Email: domain.Email("test@example.com")}
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	require.NoError(t, notifier.SendEmailVerification(context.Background(), user, "verify-token", expiresAt))
+	require.NoError(t, notifier.SendPasswordReset(context.Background(), user, "reset-token", expiresAt))
+
+	// 1 行 1 通知の JSON として書き出されること
+	var got []service.Notification
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var n service.Notification
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &n))
+		got = append(got, n)
+	}
+	require.Len(t, got, 2)
+	assert.Equal(t, service.NotificationEmailVerification, got[0].Kind)
+	assert.Equal(t, "verify-token", got[0].Token)
+	assert.Equal(t, service.NotificationPasswordReset, got[1].Kind)
+	assert.Equal(t, "reset-token", got[1].Token)
+	for _, n := range got {
+		assert.Equal(t, user.ID, n.UserID)
+		assert.Equal(t, user.Email, n.Email)
+		assert.True(t, expiresAt.Equal(n.ExpiresAt))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, notifier.SendEmailVerification(ctx, user, "token", expiresAt), context.Canceled)
+}
+
+func TestLogNotifier(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	notifier := service.NewLogNotifier(logger.New(zap.New(core), logger.DefaultRedactionConfig()))
+	user := &domain.User{ID: uuid.New(), Email: domain.Email("test@example.com")}
+
+	require.NoError(t, notifier.SendPasswordReset(context.Background(), user, "reset-token", time.Now().Add(time.Hour)))
+
+	// ログを読める人がアカウントを乗っ取れないよう、トークンは出力しない
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, user.ID.String(), fields["user_id"])
+	assert.NotEqual(t, "reset-token", fields["token"])
+}
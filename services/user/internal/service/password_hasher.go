@@ -5,6 +5,10 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// dummyPasswordHash is a bcrypt hash at the default cost that no password matches in practice. Logins with an
+// unknown email are compared against it, so that they take as long as logins with a wrong password.
+const dummyPasswordHash domain.Password = "$2a$10$xXtVtavm2w73WN1pRq2f0u3XrJzuCHUGltoI0srxcccgemyneNYcG"
+
 type PasswordHasher interface {
 	Hash(password domain.Password) (string, error)
 	Compare(hashedPassword domain.Password, plainPassword string) bool
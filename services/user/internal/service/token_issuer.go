@@ -0,0 +1,177 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+)
+
+// TokenIssuer issues and verifies access tokens and generates opaque refresh / password reset / email verification tokens
+type TokenIssuer interface {
+	IssueAccessToken(userID uuid.UUID, roles []domain.Role) (token string, expiresAt time.Time, err error)
+	ParseAccessToken(token string) (*AccessTokenClaims, error)
+	IssueRefreshToken() (token string, tokenHash string, expiresAt time.Time, err error)
+	HashRefreshToken(token string) string
+	IssuePasswordResetToken() (token string, tokenHash string, expiresAt time.Time, err error)
+	HashPasswordResetToken(token string) string
+	IssueEmailVerificationToken() (token string, tokenHash string, expiresAt time.Time, err error)
+	HashEmailVerificationToken(token string) string
+}
+
+// AccessTokenClaims are the verified claims of an access token
+type AccessTokenClaims struct {
+	UserID    uuid.UUID
+	Roles     []domain.Role
+	ExpiresAt time.Time
+}
+
+// jwtClaims is the JWT payload of an access token
+type jwtClaims struct {
+	Roles []domain.Role `json:"roles,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// TokenConfig configures token signing and lifetimes
+type TokenConfig struct {
+	// Secret is the HMAC-SHA256 signing key for access tokens
+	Secret                []byte
+	Issuer                string
+	AccessTokenTTL        time.Duration
+	RefreshTokenTTL       time.Duration
+	PasswordResetTokenTTL time.Duration
+	// EmailVerificationTokenTTL is how long a verification link stays valid
+	EmailVerificationTokenTTL time.Duration
+}
+
+// DefaultTokenConfig returns the default token configuration for the given secret
+func DefaultTokenConfig(secret []byte) TokenConfig {
+	return TokenConfig{
+		Secret:                    secret,
+		Issuer:                    domain.EventProducer,
+		AccessTokenTTL:            15 * time.Minute,
+		RefreshTokenTTL:           30 * 24 * time.Hour,
+		PasswordResetTokenTTL:     time.Hour,
+		EmailVerificationTokenTTL: 24 * time.Hour,
+	}
+}
+
+type jwtTokenIssuer struct {
+	cfg TokenConfig
+	now func() time.Time
+}
+
+func NewJWTTokenIssuer(cfg TokenConfig) TokenIssuer {
+	return &jwtTokenIssuer{
+		cfg: cfg,
+		now: time.Now,
+	}
+}
+
+func (i *jwtTokenIssuer) IssueAccessToken(userID uuid.UUID, roles []domain.Role) (string, time.Time, error) {
+	now := i.now()
+	expiresAt := now.Add(i.cfg.AccessTokenTTL)
+
+	claims := jwtClaims{
+		Roles: roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    i.cfg.Issuer,
+			Subject:   userID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			ID:        uuid.NewString(),
+		},
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(i.cfg.Secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign access token: %w", err)
+	}
+	return signed, expiresAt, nil
+}
+
+func (i *jwtTokenIssuer) ParseAccessToken(token string) (*AccessTokenClaims, error) {
+	var claims jwtClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		return i.cfg.Secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(i.cfg.Issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(i.now),
+	)
+	if err != nil {
+		return nil, errors.Join(domain.ErrInvalidToken, err)
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subject: %w", domain.ErrInvalidToken)
+	}
+
+	return &AccessTokenClaims{
+		UserID:    userID,
+		Roles:     claims.Roles,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
+
+func (i *jwtTokenIssuer) IssueRefreshToken() (string, string, time.Time, error) {
+	token, err := newOpaqueToken()
+	if err != nil {
+		return "", "", time.Time{}, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	return token, i.HashRefreshToken(token), i.now().Add(i.cfg.RefreshTokenTTL), nil
+}
+
+// HashRefreshToken returns the hex encoded SHA-256 of the token; refresh tokens are high entropy so no salt is needed
+func (i *jwtTokenIssuer) HashRefreshToken(token string) string {
+	return hashOpaqueToken(token)
+}
+
+func (i *jwtTokenIssuer) IssuePasswordResetToken() (string, string, time.Time, error) {
+	token, err := newOpaqueToken()
+	if err != nil {
+		return "", "", time.Time{}, fmt.Errorf("failed to generate password reset token: %w", err)
+	}
+	return token, i.HashPasswordResetToken(token), i.now().Add(i.cfg.PasswordResetTokenTTL), nil
+}
+
+// HashPasswordResetToken returns the hex encoded SHA-256 of the token
+func (i *jwtTokenIssuer) HashPasswordResetToken(token string) string {
+	return hashOpaqueToken(token)
+}
+
+func (i *jwtTokenIssuer) IssueEmailVerificationToken() (string, string, time.Time, error) {
+	token, err := newOpaqueToken()
+	if err != nil {
+		return "", "", time.Time{}, fmt.Errorf("failed to generate email verification token: %w", err)
+	}
+	return token, i.HashEmailVerificationToken(token), i.now().Add(i.cfg.EmailVerificationTokenTTL), nil
+}
+
+// HashEmailVerificationToken returns the hex encoded SHA-256 of the token
+func (i *jwtTokenIssuer) HashEmailVerificationToken(token string) string {
+	return hashOpaqueToken(token)
+}
+
+// newOpaqueToken generates a random 256-bit URL-safe token
+func newOpaqueToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,90 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWTTokenIssuer_AccessToken(t *testing.T) {
+	issuer := newTestTokenIssuer()
+	userID := uuid.New()
+
+	token, expiresAt, err := issuer.IssueAccessToken(userID, []domain.Role{domain.RoleAdmin})
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), expiresAt, 5*time.Second)
+
+	t.Run("正常系：発行したトークンを検証できる", func(t *testing.T) {
+		claims, err := issuer.ParseAccessToken(token)
+
+		require.NoError(t, err)
+		assert.Equal(t, userID, claims.UserID)
+		assert.Equal(t, []domain.Role{domain.RoleAdmin}, claims.Roles)
+	})
+
+	t.Run("異常系：別の鍵で署名されたトークン", func(t *testing.T) {
+		other := service.NewJWTTokenIssuer(service.DefaultTokenConfig([]byte("other-secret")))
+
+		_, err := other.ParseAccessToken(token)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+
+	t.Run("異常系：有効期限切れ", func(t *testing.T) {
+		cfg := service.DefaultTokenConfig([]byte("test-secret"))
+		cfg.AccessTokenTTL = -time.Minute
+		expired, _, err := service.NewJWTTokenIssuer(cfg).IssueAccessToken(userID, nil)
+		require.NoError(t, err)
+
+		_, err = issuer.ParseAccessToken(expired)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+
+	t.Run("異常系：不正な形式", func(t *testing.T) {
+		_, err := issuer.ParseAccessToken("not-a-jwt")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+}
+
+func TestJWTTokenIssuer_RefreshToken(t *testing.T) {
+	issuer := newTestTokenIssuer()
+
+	token1, hash1, expiresAt, err := issuer.IssueRefreshToken()
+	require.NoError(t, err)
+	token2, hash2, _, err := issuer.IssueRefreshToken()
+	require.NoError(t, err)
+
+	assert.NotEqual(t, token1, token2)
+	assert.NotEqual(t, hash1, hash2)
+	assert.Equal(t, hash1, issuer.HashRefreshToken(token1))
+	assert.WithinDuration(t, time.Now().Add(30*24*time.Hour), expiresAt, 5*time.Second)
+}
+
+func TestJWTTokenIssuer_PasswordResetToken(t *testing.T) {
+	issuer := newTestTokenIssuer()
+
+	token, hash, expiresAt, err := issuer.IssuePasswordResetToken()
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, token)
+	assert.Equal(t, hash, issuer.HashPasswordResetToken(token))
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, 5*time.Second)
+}
+
+func TestJWTTokenIssuer_EmailVerificationToken(t *testing.T) {
+	issuer := newTestTokenIssuer()
+
+	token, hash, expiresAt, err := issuer.IssueEmailVerificationToken()
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, token)
+	assert.Equal(t, hash, issuer.HashEmailVerificationToken(token))
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), expiresAt, 5*time.Second)
+}
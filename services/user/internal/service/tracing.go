@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/tracing"
+)
+
+// tracedUserService starts a span around every UserService method and marks it as failed when the method returns an error
+type tracedUserService struct {
+	next UserService
+}
+
+func newTracedUserService(next UserService) UserService {
+	return &tracedUserService{
+		next: next,
+	}
+}
+
+func (s *tracedUserService) CreateUser(ctx context.Context, req CreateUserRequest) (*UserResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.CreateUser")
+	resp, err := s.next.CreateUser(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (s *tracedUserService) BatchCreateUsers(ctx context.Context, req BatchCreateUsersRequest) (*BatchCreateUsersResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.BatchCreateUsers")
+	resp, err := s.next.BatchCreateUsers(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (s *tracedUserService) GetUserByID(ctx context.Context, id uuid.UUID) (*UserResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserByID")
+	resp, err := s.next.GetUserByID(ctx, id)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (s *tracedUserService) GetUserByEmail(ctx context.Context, email domain.Email) (*UserResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserByEmail")
+	resp, err := s.next.GetUserByEmail(ctx, email)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (s *tracedUserService) UpdateUser(ctx context.Context, req UpdateUserRequest) error {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateUser")
+	err := s.next.UpdateUser(ctx, req)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedUserService) PatchUser(ctx context.Context, req PatchUserRequest) (*UserResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.PatchUser")
+	resp, err := s.next.PatchUser(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (s *tracedUserService) DeleteUser(ctx context.Context, req DeleteUserRequest) error {
+	ctx, span := tracing.Start(ctx, "UserService.DeleteUser")
+	err := s.next.DeleteUser(ctx, req)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedUserService) RestoreUser(ctx context.Context, req RestoreUserRequest) (*UserResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.RestoreUser")
+	resp, err := s.next.RestoreUser(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (s *tracedUserService) ListUsers(ctx context.Context, req ListUsersRequest) (*ListUsersResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.ListUsers")
+	resp, err := s.next.ListUsers(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (s *tracedUserService) SearchUsers(ctx context.Context, req SearchUsersRequest) (*SearchUsersResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.SearchUsers")
+	resp, err := s.next.SearchUsers(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (s *tracedUserService) ExportUsers(ctx context.Context, req ExportUsersRequest, fn func(*UserResponse) error) error {
+	ctx, span := tracing.Start(ctx, "UserService.ExportUsers")
+	err := s.next.ExportUsers(ctx, req, fn)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedUserService) AuthenticateUser(ctx context.Context, req AuthenticateUserRequest) (*AuthTokens, error) {
+	ctx, span := tracing.Start(ctx, "UserService.AuthenticateUser")
+	resp, err := s.next.AuthenticateUser(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (s *tracedUserService) UnlockUser(ctx context.Context, req UnlockUserRequest) error {
+	ctx, span := tracing.Start(ctx, "UserService.UnlockUser")
+	err := s.next.UnlockUser(ctx, req)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedUserService) RefreshToken(ctx context.Context, req RefreshTokenRequest) (*AuthTokens, error) {
+	ctx, span := tracing.Start(ctx, "UserService.RefreshToken")
+	resp, err := s.next.RefreshToken(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (s *tracedUserService) Logout(ctx context.Context, req LogoutRequest) error {
+	ctx, span := tracing.Start(ctx, "UserService.Logout")
+	err := s.next.Logout(ctx, req)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedUserService) ChangePassword(ctx context.Context, req ChangePasswordRequest) error {
+	ctx, span := tracing.Start(ctx, "UserService.ChangePassword")
+	err := s.next.ChangePassword(ctx, req)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedUserService) RequestPasswordReset(ctx context.Context, req RequestPasswordResetRequest) error {
+	ctx, span := tracing.Start(ctx, "UserService.RequestPasswordReset")
+	err := s.next.RequestPasswordReset(ctx, req)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedUserService) ConfirmPasswordReset(ctx context.Context, req ConfirmPasswordResetRequest) error {
+	ctx, span := tracing.Start(ctx, "UserService.ConfirmPasswordReset")
+	err := s.next.ConfirmPasswordReset(ctx, req)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedUserService) VerifyEmail(ctx context.Context, req VerifyEmailRequest) error {
+	ctx, span := tracing.Start(ctx, "UserService.VerifyEmail")
+	err := s.next.VerifyEmail(ctx, req)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedUserService) ResendEmailVerification(ctx context.Context, req ResendEmailVerificationRequest) error {
+	ctx, span := tracing.Start(ctx, "UserService.ResendEmailVerification")
+	err := s.next.ResendEmailVerification(ctx, req)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedUserService) GrantRole(ctx context.Context, req GrantRoleRequest) error {
+	ctx, span := tracing.Start(ctx, "UserService.GrantRole")
+	err := s.next.GrantRole(ctx, req)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedUserService) RevokeRole(ctx context.Context, req RevokeRoleRequest) error {
+	ctx, span := tracing.Start(ctx, "UserService.RevokeRole")
+	err := s.next.RevokeRole(ctx, req)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedUserService) ListUserAuditEvents(ctx context.Context, req ListUserAuditEventsRequest) (*ListUserAuditEventsResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.ListUserAuditEvents")
+	resp, err := s.next.ListUserAuditEvents(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
@@ -0,0 +1,67 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/service"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestUserService_Tracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	previous := otel.GetTracerProvider()
+	tracing.Install(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	t.Run("正常系：呼び出し元のスパンの子としてメソッドごとにスパンを記録する", func(t *testing.T) {
+		exporter.Reset()
+		mockRepo := new(repository.MockUserRepository)
+		svc := newTestMetricsService(mockRepo, new(MockPasswordHasher), service.NewNopMetrics())
+		user := &domain.User{ID: uuid.New(), Email: "alice@example.com", Name: "Alice", Password: "hashedPassword"}
+		var repoSpan trace.SpanContext
+		mockRepo.On("GetByID", mock.Anything, user.ID).Run(func(args mock.Arguments) {
+			// リポジトリにはサービスのスパンが渡る
+			repoSpan = trace.SpanContextFromContext(args.Get(0).(context.Context))
+		}).Return(user, nil).Once()
+
+		ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+		_, err := svc.GetUserByID(ctx, user.ID)
+		parent.End()
+
+		require.NoError(t, err)
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 2)
+		assert.Equal(t, "UserService.GetUserByID", spans[0].Name)
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+		assert.Equal(t, spans[0].SpanContext.SpanID(), repoSpan.SpanID())
+		assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	})
+
+	t.Run("異常系：エラーをスパンに記録する", func(t *testing.T) {
+		exporter.Reset()
+		mockRepo := new(repository.MockUserRepository)
+		svc := newTestMetricsService(mockRepo, new(MockPasswordHasher), service.NewNopMetrics())
+		userID := uuid.New()
+		mockRepo.On("GetByID", mock.Anything, userID).Return(nil, domain.ErrUserNotFound).Once()
+
+		_, err := svc.GetUserByID(context.Background(), userID)
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+		assert.Equal(t, domain.ErrUserNotFound.Error(), spans[0].Status.Description)
+	})
+}
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"go.uber.org/zap"
+)
+
+// BatchConfig configures bulk import and export of users
+type BatchConfig struct {
+	// MaxRows is the largest number of rows a single BatchCreateUsers call accepts.
+	// Hashing dominates the time of an import, so it must fit the timeout of the bulk import route.
+	MaxRows int
+	// HashWorkers bounds how many passwords are hashed concurrently
+	HashWorkers int
+	// ExportPageSize is the number of users read per query while exporting
+	ExportPageSize int32
+}
+
+// DefaultBatchConfig returns the default bulk import and export configuration
+func DefaultBatchConfig() BatchConfig {
+	return BatchConfig{
+		// bcrypt の既定コストで 1 件あたり約 100ms、1 CPU でも一括登録のタイムアウト（5 分）に収まる件数
+		MaxRows:        2000,
+		HashWorkers:    runtime.GOMAXPROCS(0),
+		ExportPageSize: 500,
+	}
+}
+
+type BatchCreateUsersRequest struct {
+	Users []CreateUserRequest `json:"users"`
+	// ChunkSize commits every ChunkSize valid rows in their own transaction.
+	// 0 creates all rows in a single transaction, which is not run at all if any row is rejected.
+	ChunkSize int `json:"chunk_size"`
+}
+
+// BatchRowStatus is the outcome of one row of a bulk import
+type BatchRowStatus string
+
+const (
+	BatchRowCreated BatchRowStatus = "created"
+	// BatchRowRejected rows are invalid or use an email that is already taken
+	BatchRowRejected BatchRowStatus = "rejected"
+	// BatchRowSkipped rows are valid but were not created because their transaction was rolled back or not run
+	BatchRowSkipped BatchRowStatus = "skipped"
+)
+
+type BatchCreateUserResult struct {
+	// Index is the position of the row in the request, starting at 0
+	Index  int            `json:"index"`
+	Status BatchRowStatus `json:"status"`
+	Email  domain.Email   `json:"email"`
+	// User is set for created rows
+	User *UserResponse `json:"user,omitempty"`
+	// Err tells why a row was rejected or skipped
+	Err error `json:"-"`
+}
+
+type BatchCreateUsersResponse struct {
+	// Results has one entry per row, in request order
+	Results  []*BatchCreateUserResult `json:"results"`
+	Created  int                      `json:"created"`
+	Rejected int                      `json:"rejected"`
+	Skipped  int                      `json:"skipped"`
+}
+
+// errBatchHasRejectedRows is the reason valid rows of a single-transaction import are not created
+var errBatchHasRejectedRows = errors.New("not created because other rows were rejected")
+
+// batchRow is a row of a bulk import while it is being processed
+type batchRow struct {
+	result   *BatchCreateUserResult
+	password domain.Password
+	user     *domain.User
+}
+
+// BatchCreateUsers validates, hashes and inserts many users at once; each row gets its own result
+func (s *userService) BatchCreateUsers(ctx context.Context, req BatchCreateUsersRequest) (*BatchCreateUsersResponse, error) {
+	if len(req.Users) == 0 {
+		return nil, fmt.Errorf("no users to create: %w", domain.ErrInvalidInput)
+	}
+	if len(req.Users) > s.batch.MaxRows {
+		return nil, fmt.Errorf("%d rows exceed the limit of %d: %w", len(req.Users), s.batch.MaxRows, domain.ErrBatchTooLarge)
+	}
+	if req.ChunkSize < 0 {
+		return nil, fmt.Errorf("chunk size must not be negative: %w", domain.ErrInvalidInput)
+	}
+
+	rows := validateBatchRows(req.Users)
+	if err := s.rejectTakenEmails(ctx, rows); err != nil {
+		return nil, err
+	}
+
+	valid := make([]*batchRow, 0, len(rows))
+	for _, row := range rows {
+		if row.result.Status != BatchRowRejected {
+			valid = append(valid, row)
+		}
+	}
+	// 単一トランザクションでは 1 行でも不正なら何も作成しないため、ハッシュ化も省く
+	if req.ChunkSize == 0 && len(valid) < len(rows) {
+		skipBatchRows(valid, errBatchHasRejectedRows)
+		return toBatchCreateUsersResponse(rows), nil
+	}
+
+	chunkSize := req.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = len(valid)
+	}
+	// チャンクごとにハッシュ化してコミットし、タイムアウトしてもコミット済みのチャンクは残す
+	for start := 0; start < len(valid); start += chunkSize {
+		chunk := valid[start:min(start+chunkSize, len(valid))]
+		if err := s.hashBatchPasswords(ctx, chunk); err != nil {
+			skipBatchRows(chunk, err)
+			continue
+		}
+		if req.ChunkSize == 0 && hasRejectedBatchRows(chunk) {
+			skipBatchRows(chunk, errBatchHasRejectedRows)
+			continue
+		}
+		s.createBatchChunk(ctx, chunk)
+	}
+
+	return toBatchCreateUsersResponse(rows), nil
+}
+
+// validateBatchRows validates every row and rejects emails repeated within the batch, ignoring case
+func validateBatchRows(users []CreateUserRequest) []*batchRow {
+	rows := make([]*batchRow, 0, len(users))
+	seen := make(map[string]int, len(users))
+	for i, u := range users {
+		email := domain.NormalizeEmail(u.Email)
+		row := &batchRow{
+			result:   &BatchCreateUserResult{Index: i, Status: BatchRowSkipped, Email: email},
+			password: u.Password,
+		}
+		rows = append(rows, row)
+
+		if err := validateBatchUser(email, u.Name, u.Password); err != nil {
+			rejectBatchRow(row, err)
+			continue
+		}
+		key := strings.ToLower(string(email))
+		if first, ok := seen[key]; ok {
+			rejectBatchRow(row, fmt.Errorf("email is the same as row %d: %w", first, domain.ErrUserAlreadyExists))
+			continue
+		}
+		seen[key] = i
+		row.user = domain.NewUser(email, "", u.Name)
+	}
+	return rows
+}
+
+func validateBatchUser(email domain.Email, name domain.Name, password domain.Password) error {
+	if err := domain.ValidateEmail(email); err != nil {
+		return err
+	}
+	if err := domain.ValidateName(name); err != nil {
+		return err
+	}
+	return domain.ValidatePassword(password)
+}
+
+// rejectTakenEmails rejects the rows whose email is already used by a live user
+func (s *userService) rejectTakenEmails(ctx context.Context, rows []*batchRow) error {
+	emails := make([]domain.Email, 0, len(rows))
+	for _, row := range rows {
+		if row.user != nil {
+			emails = append(emails, row.user.Email)
+		}
+	}
+	if len(emails) == 0 {
+		return nil
+	}
+
+	taken, err := s.repo.ListTakenEmails(ctx, emails)
+	if err != nil {
+		return fmt.Errorf("failed to check existing emails: %w", err)
+	}
+	takenSet := make(map[string]struct{}, len(taken))
+	for _, email := range taken {
+		takenSet[strings.ToLower(string(email))] = struct{}{}
+	}
+	for _, row := range rows {
+		if row.user == nil {
+			continue
+		}
+		if _, ok := takenSet[strings.ToLower(string(row.user.Email))]; ok {
+			rejectBatchRow(row, domain.ErrUserAlreadyExists)
+		}
+	}
+	return nil
+}
+
+// hashBatchPasswords hashes the passwords of the rows with at most HashWorkers goroutines; it returns ctx's error if ctx is done
+func (s *userService) hashBatchPasswords(ctx context.Context, rows []*batchRow) error {
+	workers := max(s.batch.HashWorkers, 1)
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+
+	for _, row := range rows {
+		if ctx.Err() != nil {
+			break
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			hashed, err := s.hasher.Hash(row.password)
+			if err != nil {
+				// 行ごとの結果には内部エラーの詳細を出さない
+				s.logger.Error(ctx, "Password hashing failed", zap.Int("index", row.result.Index), zap.Error(err))
+				rejectBatchRow(row, errors.New("failed to hash password"))
+				return
+			}
+			row.user.Password = domain.Password(hashed)
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// createBatchChunk inserts the hashed rows of one chunk in a single transaction
+func (s *userService) createBatchChunk(ctx context.Context, chunk []*batchRow) {
+	rows := make([]*batchRow, 0, len(chunk))
+	users := make([]*domain.User, 0, len(chunk))
+	for _, row := range chunk {
+		if row.result.Status == BatchRowRejected {
+			continue
+		}
+		rows = append(rows, row)
+		users = append(users, row.user)
+	}
+	if len(users) == 0 {
+		return
+	}
+
+	if err := s.repo.CreateBatch(ctx, users); err != nil {
+		s.logger.Error(ctx, "Failed to create user batch", zap.Int("rows", len(users)), zap.Error(err))
+		// 確認後に同じメールアドレスで登録されたユーザーがいる
+		if errors.Is(err, domain.ErrDuplicateEmail) {
+			err = domain.ErrUserAlreadyExists
+		}
+		skipBatchRows(rows, err)
+		return
+	}
+
+	s.metrics.UsersCreated(len(rows))
+
+	events := make([]*domain.AuditEvent, 0, len(rows))
+	for _, row := range rows {
+		row.result.Status = BatchRowCreated
+		row.result.User = toUserResponse(row.user)
+		event := domain.NewAuditEvent(domain.AuditActionUserCreate, row.user.ID, nil)
+		event.Changes = domain.DiffUsers(nil, row.user)
+		events = append(events, event)
+		s.sendEmailVerification(ctx, row.user)
+	}
+	s.audit.RecordBatch(ctx, events)
+}
+
+func hasRejectedBatchRows(rows []*batchRow) bool {
+	for _, row := range rows {
+		if row.result.Status == BatchRowRejected {
+			return true
+		}
+	}
+	return false
+}
+
+func rejectBatchRow(row *batchRow, err error) {
+	row.result.Status = BatchRowRejected
+	row.result.Err = err
+}
+
+// skipBatchRows records why valid rows were not created
+func skipBatchRows(rows []*batchRow, err error) {
+	for _, row := range rows {
+		if row.result.Status == BatchRowRejected {
+			continue
+		}
+		row.result.Status = BatchRowSkipped
+		row.result.Err = err
+	}
+}
+
+func toBatchCreateUsersResponse(rows []*batchRow) *BatchCreateUsersResponse {
+	resp := &BatchCreateUsersResponse{Results: make([]*BatchCreateUserResult, 0, len(rows))}
+	for _, row := range rows {
+		switch row.result.Status {
+		case BatchRowCreated:
+			resp.Created++
+		case BatchRowRejected:
+			resp.Rejected++
+		case BatchRowSkipped:
+			resp.Skipped++
+		}
+		resp.Results = append(resp.Results, row.result)
+	}
+	return resp
+}
+
+type ExportUsersRequest struct {
+	EmailPrefix string     `json:"email_prefix"`
+	NamePrefix  string     `json:"name_prefix"`
+	CreatedFrom *time.Time `json:"created_from"`
+	CreatedTo   *time.Time `json:"created_to"`
+}
+
+// ExportUsers passes every user matching the filter to fn, newest first, reading ExportPageSize users at a time.
+// It stops at the first error returned by fn.
+func (s *userService) ExportUsers(ctx context.Context, req ExportUsersRequest, fn func(*UserResponse) error) error {
+	filter := domain.UserFilter{
+		EmailPrefix: req.EmailPrefix,
+		NamePrefix:  req.NamePrefix,
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+	}
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+
+	pageSize := s.batch.ExportPageSize
+	// オフセットは件数に比例して遅くなるため、2 ページ目以降はキーセットで読む
+	users, err := s.repo.ListUsers(ctx, filter, domain.DefaultUserSort(), pageSize, 0)
+	for {
+		if err != nil {
+			return err
+		}
+		for _, user := range users {
+			if err := fn(toUserResponse(user)); err != nil {
+				return err
+			}
+		}
+		if len(users) < int(pageSize) {
+			return nil
+		}
+		users, err = s.repo.ListUsersByCursor(ctx, filter, domain.NextUserCursor(users[len(users)-1]), pageSize)
+	}
+}
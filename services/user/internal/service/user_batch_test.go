@@ -0,0 +1,270 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
+	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestBatchService creates a user service for bulk import and export tests
+func newTestBatchService(repo *repository.MockUserRepository, hasher *MockPasswordHasher, cfg service.BatchConfig) service.UserService {
+	return newTestService(testServiceDeps{repo: repo, hasher: hasher, batch: cfg})
+}
+
+func batchUser(email string) service.CreateUserRequest {
+	return service.CreateUserRequest{
+		Email:    domain.Email(email),
+		Name:     domain.Name("Batch User"),
+		Password: domain.Password("batchPass123"),
+	}
+}
+
+func batchStatuses(resp *service.BatchCreateUsersResponse) []service.BatchRowStatus {
+	statuses := make([]service.BatchRowStatus, 0, len(resp.Results))
+	for _, result := range resp.Results {
+		statuses = append(statuses, result.Status)
+	}
+	return statuses
+}
+
+func TestUserService_BatchCreateUsers(t *testing.T) {
+	errDatabase := errors.New("database error")
+	// users の件数が一致する CreateBatch の呼び出し
+	usersOfLen := func(n int) any {
+		return mock.MatchedBy(func(users []*domain.User) bool { return len(users) == n })
+	}
+
+	tests := []struct {
+		name         string
+		req          service.BatchCreateUsersRequest
+		mockSetup    func(*repository.MockUserRepository, *MockPasswordHasher)
+		wantStatuses []service.BatchRowStatus
+		wantErr      error
+	}{
+		{
+			name: "正常系：単一トランザクションで全件作成",
+			req:  service.BatchCreateUsersRequest{Users: []service.CreateUserRequest{batchUser("a@example.com"), batchUser("b@example.com")}},
+			mockSetup: func(m *repository.MockUserRepository, h *MockPasswordHasher) {
+				m.On("ListTakenEmails", mock.Anything, []domain.Email{"a@example.com", "b@example.com"}).Return([]domain.Email{}, nil).Once()
+				h.On("Hash", domain.Password("batchPass123")).Return("hashed", nil).Twice()
+				m.On("CreateBatch", mock.Anything, usersOfLen(2)).Return(nil).Once()
+			},
+			wantStatuses: []service.BatchRowStatus{service.BatchRowCreated, service.BatchRowCreated},
+		},
+		{
+			name: "異常系：単一トランザクションでは不正な行があれば何も作成しない",
+			req: service.BatchCreateUsersRequest{Users: []service.CreateUserRequest{
+				batchUser("a@example.com"),
+				batchUser("invalid"),
+				batchUser("A@example.com"),
+			}},
+			mockSetup: func(m *repository.MockUserRepository, h *MockPasswordHasher) {
+				m.On("ListTakenEmails", mock.Anything, []domain.Email{"a@example.com"}).Return([]domain.Email{}, nil).Once()
+			},
+			wantStatuses: []service.BatchRowStatus{service.BatchRowSkipped, service.BatchRowRejected, service.BatchRowRejected},
+		},
+		{
+			name: "正常系：チャンクごとにコミットし、登録済みのメールアドレスは拒否",
+			req: service.BatchCreateUsersRequest{
+				Users: []service.CreateUserRequest{
+					batchUser("a@example.com"),
+					batchUser("taken@example.com"),
+					batchUser("b@example.com"),
+					batchUser("c@example.com"),
+				},
+				ChunkSize: 2,
+			},
+			mockSetup: func(m *repository.MockUserRepository, h *MockPasswordHasher) {
+				m.On("ListTakenEmails", mock.Anything, mock.Anything).Return([]domain.Email{"Taken@example.com"}, nil).Once()
+				h.On("Hash", mock.Anything).Return("hashed", nil).Times(3)
+				m.On("CreateBatch", mock.Anything, usersOfLen(2)).Return(nil).Once()
+				// 確認後に同じメールアドレスで登録された
+				m.On("CreateBatch", mock.Anything, usersOfLen(1)).Return(domain.ErrDuplicateEmail).Once()
+			},
+			wantStatuses: []service.BatchRowStatus{service.BatchRowCreated, service.BatchRowRejected, service.BatchRowCreated, service.BatchRowSkipped},
+		},
+		{
+			name: "異常系：上限を超える行数",
+			req: service.BatchCreateUsersRequest{Users: []service.CreateUserRequest{
+				batchUser("a@example.com"), batchUser("b@example.com"), batchUser("c@example.com"),
+				batchUser("d@example.com"), batchUser("e@example.com"),
+			}},
+			mockSetup: func(m *repository.MockUserRepository, h *MockPasswordHasher) {},
+			wantErr:   domain.ErrBatchTooLarge,
+		},
+		{
+			name:      "異常系：空のバッチ",
+			req:       service.BatchCreateUsersRequest{},
+			mockSetup: func(m *repository.MockUserRepository, h *MockPasswordHasher) {},
+			wantErr:   domain.ErrInvalidInput,
+		},
+		{
+			name:      "異常系：負のチャンクサイズ",
+			req:       service.BatchCreateUsersRequest{Users: []service.CreateUserRequest{batchUser("a@example.com")}, ChunkSize: -1},
+			mockSetup: func(m *repository.MockUserRepository, h *MockPasswordHasher) {},
+			wantErr:   domain.ErrInvalidInput,
+		},
+		{
+			name: "異常系：メールアドレスの確認に失敗",
+			req:  service.BatchCreateUsersRequest{Users: []service.CreateUserRequest{batchUser("a@example.com")}},
+			mockSetup: func(m *repository.MockUserRepository, h *MockPasswordHasher) {
+				m.On("ListTakenEmails", mock.Anything, mock.Anything).Return(nil, errDatabase).Once()
+			},
+			wantErr: errDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockUserRepository)
+			mockHasher := new(MockPasswordHasher)
+			tt.mockSetup(mockRepo, mockHasher)
+			cfg := service.DefaultBatchConfig()
+			cfg.MaxRows = 4
+			cfg.HashWorkers = 2
+			svc := newTestBatchService(mockRepo, mockHasher, cfg)
+
+			resp, err := svc.BatchCreateUsers(context.Background(), tt.req)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantStatuses, batchStatuses(resp))
+				for i, result := range resp.Results {
+					assert.Equal(t, i, result.Index)
+					if result.Status == service.BatchRowCreated {
+						assert.NotNil(t, result.User)
+						assert.NoError(t, result.Err)
+					} else {
+						assert.Error(t, result.Err)
+					}
+				}
+			}
+			mockRepo.AssertExpectations(t)
+			mockHasher.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_BatchCreateUsers_Timeout(t *testing.T) {
+	t.Run("正常系：タイムアウトしてもコミット済みのチャンクは残る", func(t *testing.T) {
+		mockRepo := new(repository.MockUserRepository)
+		mockHasher := new(MockPasswordHasher)
+		svc := newTestBatchService(mockRepo, mockHasher, service.DefaultBatchConfig())
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		mockRepo.On("ListTakenEmails", mock.Anything, mock.Anything).Return([]domain.Email{}, nil).Once()
+		// 2 つ目のチャンクはハッシュ化しない
+		mockHasher.On("Hash", mock.Anything).Return("hashed", nil).Twice()
+		mockRepo.On("CreateBatch", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+			cancel()
+		}).Return(nil).Once()
+
+		resp, err := svc.BatchCreateUsers(ctx, service.BatchCreateUsersRequest{
+			Users: []service.CreateUserRequest{
+				batchUser("a@example.com"), batchUser("b@example.com"),
+				batchUser("c@example.com"), batchUser("d@example.com"),
+			},
+			ChunkSize: 2,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []service.BatchRowStatus{service.BatchRowCreated, service.BatchRowCreated, service.BatchRowSkipped, service.BatchRowSkipped}, batchStatuses(resp))
+		assert.ErrorIs(t, resp.Results[2].Err, context.Canceled)
+		mockRepo.AssertExpectations(t)
+		mockHasher.AssertExpectations(t)
+	})
+}
+
+func TestUserService_BatchCreateUsers_DuplicateWithinBatch(t *testing.T) {
+	mockRepo := new(repository.MockUserRepository)
+	mockHasher := new(MockPasswordHasher)
+	svc := newTestBatchService(mockRepo, mockHasher, service.DefaultBatchConfig())
+
+	mockRepo.On("ListTakenEmails", mock.Anything, []domain.Email{"a@example.com"}).Return([]domain.Email{}, nil).Once()
+	mockHasher.On("Hash", mock.Anything).Return("hashed", nil).Once()
+	mockRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil).Once()
+
+	resp, err := svc.BatchCreateUsers(context.Background(), service.BatchCreateUsersRequest{
+		Users:     []service.CreateUserRequest{batchUser("a@example.com"), batchUser(" A@EXAMPLE.com ")},
+		ChunkSize: 10,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []service.BatchRowStatus{service.BatchRowCreated, service.BatchRowRejected}, batchStatuses(resp))
+	assert.ErrorIs(t, resp.Results[1].Err, domain.ErrUserAlreadyExists)
+	assert.Equal(t, 1, resp.Created)
+	assert.Equal(t, 1, resp.Rejected)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserService_ExportUsers(t *testing.T) {
+	newUsers := func(n int) []*domain.User {
+		users := make([]*domain.User, 0, n)
+		for i := 0; i < n; i++ {
+			users = append(users, &domain.User{ID: uuid.New(), Email: "export@example.com", CreatedAt: time.Now()})
+		}
+		return users
+	}
+
+	t.Run("正常系：ページ単位で全件を読む", func(t *testing.T) {
+		mockRepo := new(repository.MockUserRepository)
+		cfg := service.DefaultBatchConfig()
+		cfg.ExportPageSize = 2
+		svc := newTestBatchService(mockRepo, new(MockPasswordHasher), cfg)
+
+		first, second := newUsers(2), newUsers(1)
+		filter := domain.UserFilter{EmailPrefix: "export"}
+		mockRepo.On("ListUsers", mock.Anything, filter, domain.DefaultUserSort(), int32(2), int32(0)).Return(first, nil).Once()
+		mockRepo.On("ListUsersByCursor", mock.Anything, filter, domain.NextUserCursor(first[1]), int32(2)).Return(second, nil).Once()
+
+		var exported []uuid.UUID
+		err := svc.ExportUsers(context.Background(), service.ExportUsersRequest{EmailPrefix: "export"}, func(user *service.UserResponse) error {
+			exported = append(exported, user.ID)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{first[0].ID, first[1].ID, second[0].ID}, exported)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系：書き込みエラーで中断", func(t *testing.T) {
+		mockRepo := new(repository.MockUserRepository)
+		svc := newTestBatchService(mockRepo, new(MockPasswordHasher), service.DefaultBatchConfig())
+		mockRepo.On("ListUsers", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(newUsers(3), nil).Once()
+
+		calls := 0
+		writeErr := errors.New("client gone")
+		err := svc.ExportUsers(context.Background(), service.ExportUsersRequest{}, func(user *service.UserResponse) error {
+			calls++
+			return writeErr
+		})
+
+		assert.ErrorIs(t, err, writeErr)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("異常系：不正な期間", func(t *testing.T) {
+		svc := newTestBatchService(new(repository.MockUserRepository), new(MockPasswordHasher), service.DefaultBatchConfig())
+		now := time.Now()
+
+		err := svc.ExportUsers(context.Background(), service.ExportUsersRequest{CreatedFrom: &now, CreatedTo: &now}, func(*service.UserResponse) error {
+			return nil
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidFilter)
+	})
+}
@@ -2,10 +2,13 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
 	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
 	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
 	"go.uber.org/zap"
@@ -16,26 +19,51 @@ var _ UserService = (*userService)(nil)
 
 // userService provides business logic for user operations
 type userService struct {
-	repo   repository.UserRepository
-	hasher PasswordHasher
-	logger *zap.Logger
+	repo      repository.UserRepository
+	tokenRepo repository.RefreshTokenRepository
+	resetRepo repository.PasswordResetTokenRepository
+	hasher    PasswordHasher
+	issuer    TokenIssuer
+	notifier  Notifier
+	limiter   *LoginLimiter
+	verifier  *EmailVerifier
+	cursors   CursorCodec
+	batch     BatchConfig
+	audit     *AuditLog
+	metrics   Metrics
+	logger    logger.Logger
 }
 
-// NewUserService creates a new UserService instance
-func NewUserService(repo repository.UserRepository, hasher PasswordHasher, logger *zap.Logger) UserService {
-	return &userService{
-		repo:   repo,
-		hasher: hasher,
-		logger: logger,
-	}
+// NewUserService creates a new UserService instance; every method runs in its own span
+func NewUserService(repo repository.UserRepository, tokenRepo repository.RefreshTokenRepository, resetRepo repository.PasswordResetTokenRepository, hasher PasswordHasher, issuer TokenIssuer, notifier Notifier, limiter *LoginLimiter, verifier *EmailVerifier, cursors CursorCodec, batch BatchConfig, audit *AuditLog, metrics Metrics, logger logger.Logger) UserService {
+	return newTracedUserService(&userService{
+		repo:      repo,
+		tokenRepo: tokenRepo,
+		resetRepo: resetRepo,
+		hasher:    hasher,
+		issuer:    issuer,
+		notifier:  notifier,
+		limiter:   limiter,
+		verifier:  verifier,
+		cursors:   cursors,
+		batch:     batch,
+		audit:     audit,
+		metrics:   metrics,
+		logger:    logger,
+	})
 }
 
 type UserResponse struct {
-	ID        uuid.UUID    `json:"id"`
-	Email     domain.Email `json:"email"`
-	Name      domain.Name  `json:"name"`
-	CreatedAt time.Time    `json:"created_at"`
-	UpdatedAt time.Time    `json:"updated_at"`
+	ID        uuid.UUID     `json:"id"`
+	Email     domain.Email  `json:"email"`
+	Name      domain.Name   `json:"name"`
+	Roles     []domain.Role `json:"roles"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	// EmailVerifiedAt is nil until the current email address is verified
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	// Version is sent as the ETag header rather than in the body
+	Version int64 `json:"-"`
 }
 
 type CreateUserRequest struct {
@@ -45,7 +73,12 @@ type CreateUserRequest struct {
 }
 
 // CreateUser creates a new user with the given email and name
-func (s *userService) CreateUser(ctx context.Context, req CreateUserRequest) (*UserResponse, error) {
+func (s *userService) CreateUser(ctx context.Context, req CreateUserRequest) (_ *UserResponse, err error) {
+	var created *domain.User
+	defer func() {
+		s.auditUserChange(ctx, domain.AuditActionUserCreate, userIDOf(created), nil, created, err)
+	}()
+
 	if req.Email == "" {
 		return nil, domain.ErrInvalidEmail
 	}
@@ -57,41 +90,41 @@ func (s *userService) CreateUser(ctx context.Context, req CreateUserRequest) (*U
 	}
 
 	// まず元のパスワードでバリデーション
-	s.logger.Info("Validating password", zap.String("password_length", fmt.Sprintf("%d", len(req.Password))))
+	s.logger.Info(ctx, "Validating password", zap.String("password_length", fmt.Sprintf("%d", len(req.Password))))
 	if err := domain.ValidatePassword(req.Password); err != nil {
-		s.logger.Error("Password validation failed", zap.Error(err))
+		s.logger.Error(ctx, "Password validation failed", zap.Error(err))
 		return nil, fmt.Errorf("password validation failed: %w", err)
 	}
 
 	// パスワードのハッシュ化
-	s.logger.Info("Hashing password")
+	s.logger.Info(ctx, "Hashing password")
 	hashedPassword, err := s.hasher.Hash(req.Password)
 	if err != nil {
-		s.logger.Error("Password hashing failed", zap.Error(err))
+		s.logger.Error(ctx, "Password hashing failed", zap.Error(err))
 		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
 	// ドメインモデルの作成
-	s.logger.Info("Creating domain user",
-		zap.String("email", string(req.Email)),
-		zap.String("name", string(req.Name)),
+	s.logger.Debug(ctx, "Creating domain user",
+		logger.Email("email", string(req.Email)),
+		logger.Name("name", string(req.Name)),
 		zap.String("hashed_password_length", fmt.Sprintf("%d", len(hashedPassword))))
 	user := domain.NewUser(req.Email, domain.Password(hashedPassword), req.Name)
 
 	// ドメインモデルのバリデーション（パスワード以外）
-	s.logger.Info("Validating email")
+	s.logger.Info(ctx, "Validating email")
 	if err := domain.ValidateEmail(user.Email); err != nil {
-		s.logger.Error("Email validation failed", zap.Error(err))
+		s.logger.Error(ctx, "Email validation failed", zap.Error(err))
 		return nil, fmt.Errorf("email validation failed: %w", err)
 	}
-	s.logger.Info("Validating name")
+	s.logger.Info(ctx, "Validating name")
 	if err := domain.ValidateName(user.Name); err != nil {
-		s.logger.Error("Name validation failed", zap.Error(err))
+		s.logger.Error(ctx, "Name validation failed", zap.Error(err))
 		return nil, fmt.Errorf("name validation failed: %w", err)
 	}
 
 	// 重複チェック
-	if existing, _ := s.repo.GetByEmail(ctx, req.Email); existing != nil {
+	if existing, _ := s.repo.GetByEmail(ctx, user.Email); existing != nil {
 		return nil, domain.ErrUserAlreadyExists
 	}
 
@@ -99,14 +132,11 @@ func (s *userService) CreateUser(ctx context.Context, req CreateUserRequest) (*U
 	if err := s.repo.Create(ctx, user); err != nil {
 		return nil, err
 	}
+	created = user
+	s.metrics.UsersCreated(1)
+	s.sendEmailVerification(ctx, user)
 
-	return &UserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		Name:      user.Name,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}, nil
+	return toUserResponse(user), nil
 }
 
 // GetUserByID retrieves a user by ID
@@ -118,40 +148,37 @@ func (s *userService) GetUserByID(ctx context.Context, id uuid.UUID) (*UserRespo
 	if err != nil {
 		return nil, err
 	}
-	return &UserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		Name:      user.Name,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}, nil
+	return toUserResponse(user), nil
 }
 
+// GetUserByEmail looks up a user by email, ignoring case
 func (s *userService) GetUserByEmail(ctx context.Context, email domain.Email) (*UserResponse, error) {
-	if email == "" {
-		return nil, domain.ErrInvalidEmail
+	email = domain.NormalizeEmail(email)
+	if err := domain.ValidateEmail(email); err != nil {
+		return nil, err
 	}
 	user, err := s.repo.GetByEmail(ctx, email)
 	if err != nil {
 		return nil, err
 	}
-	return &UserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		Name:      user.Name,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}, nil
+	return toUserResponse(user), nil
 }
 
 type UpdateUserRequest struct {
 	ID    uuid.UUID    `json:"id"`
 	Email domain.Email `json:"email"`
 	Name  domain.Name  `json:"name"`
+	// Version is the version the caller last read (If-Match); 0 skips the check
+	Version int64 `json:"version,omitempty"`
 }
 
 // UpdateUser updates an existing user
-func (s *userService) UpdateUser(ctx context.Context, req UpdateUserRequest) error {
+func (s *userService) UpdateUser(ctx context.Context, req UpdateUserRequest) (err error) {
+	var before, after *domain.User
+	defer func() {
+		s.auditUserChange(ctx, domain.AuditActionUserUpdate, req.ID, before, after, err)
+	}()
+
 	// IDのバリデーション
 	if req.ID == uuid.Nil {
 		return domain.ErrInvalidID
@@ -166,7 +193,12 @@ func (s *userService) UpdateUser(ctx context.Context, req UpdateUserRequest) err
 	if err != nil {
 		return err
 	}
+	if req.Version != 0 && req.Version != user.Version {
+		return domain.ErrConcurrentModification
+	}
+	before = snapshotUser(user)
 
+	previousEmail := user.Email
 	if req.Email != "" {
 		if err := user.UpdateEmail(req.Email); err != nil {
 			return err
@@ -182,16 +214,78 @@ func (s *userService) UpdateUser(ctx context.Context, req UpdateUserRequest) err
 		return err
 	}
 
-	// リポジトリで保存
-	return s.repo.Update(ctx, user)
+	// リポジトリで保存（読み込み後に他の更新が入っていれば ErrConcurrentModification）
+	if err := s.repo.Update(ctx, user); err != nil {
+		return err
+	}
+	after = user
+
+	// 新しいメールアドレスは改めて確認する
+	if !strings.EqualFold(string(previousEmail), string(user.Email)) {
+		s.sendEmailVerification(ctx, user)
+	}
+	return nil
+}
+
+type PatchUserRequest struct {
+	ID uuid.UUID `json:"id"`
+	// Patch holds only the fields the client sent; nil fields are left unchanged
+	Patch domain.UserPatch `json:"-"`
+	// Version is the version the caller last read (If-Match); 0 skips the check
+	Version int64 `json:"version,omitempty"`
+}
+
+// PatchUser applies a partial update and returns the updated user; only fields that actually change are written
+func (s *userService) PatchUser(ctx context.Context, req PatchUserRequest) (_ *UserResponse, err error) {
+	var before, after *domain.User
+	defer func() {
+		s.auditUserChange(ctx, domain.AuditActionUserUpdate, req.ID, before, after, err)
+	}()
+
+	if req.ID == uuid.Nil {
+		return nil, domain.ErrInvalidID
+	}
+
+	user, err := s.repo.GetByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Version != 0 && req.Version != user.Version {
+		return nil, domain.ErrConcurrentModification
+	}
+	before = snapshotUser(user)
+
+	previousEmail := user.Email
+	changes, err := user.ApplyPatch(req.Patch)
+	if err != nil {
+		return nil, err
+	}
+	// 値が変わらないパッチは書き込まず、バージョンも進めない
+	if changes.IsEmpty() {
+		return toUserResponse(user), nil
+	}
+
+	if err := s.repo.Patch(ctx, user, changes); err != nil {
+		return nil, err
+	}
+	after = user
+
+	if !strings.EqualFold(string(previousEmail), string(user.Email)) {
+		s.sendEmailVerification(ctx, user)
+	}
+	return toUserResponse(user), nil
 }
 
 type DeleteUserRequest struct {
 	ID uuid.UUID `json:"id"`
 }
 
-// DeleteUser deletes a user by ID
-func (s *userService) DeleteUser(ctx context.Context, req DeleteUserRequest) error {
+// DeleteUser soft-deletes a user by ID; the user can be restored until it is purged
+func (s *userService) DeleteUser(ctx context.Context, req DeleteUserRequest) (err error) {
+	defer func() {
+		s.auditUserChange(ctx, domain.AuditActionUserDelete, req.ID, nil, nil, err)
+	}()
+
 	if req.ID == uuid.Nil {
 		return domain.ErrInvalidID
 	}
@@ -200,16 +294,224 @@ func (s *userService) DeleteUser(ctx context.Context, req DeleteUserRequest) err
 		return err
 	}
 
+	// 削除されたユーザーのリフレッシュトークンを失効させる
+	if err := s.tokenRepo.RevokeAllForUser(ctx, req.ID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
 	return nil
 }
 
+type RestoreUserRequest struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// RestoreUser restores a soft-deleted user
+func (s *userService) RestoreUser(ctx context.Context, req RestoreUserRequest) (_ *UserResponse, err error) {
+	defer func() {
+		s.auditUserChange(ctx, domain.AuditActionUserRestore, req.ID, nil, nil, err)
+	}()
+
+	if req.ID == uuid.Nil {
+		return nil, domain.ErrInvalidID
+	}
+
+	user, err := s.repo.Restore(ctx, req.ID)
+	if err != nil {
+		// 削除中に同じメールアドレスで別のユーザーが登録されている
+		if errors.Is(err, domain.ErrDuplicateEmail) {
+			return nil, domain.ErrUserAlreadyExists
+		}
+		return nil, err
+	}
+
+	return toUserResponse(user), nil
+}
+
 type ListUsersRequest struct {
 	Limit  int32 `json:"limit"`
 	Offset int32 `json:"offset"`
+	// Cursor is a next_cursor / prev_cursor of a previous page; it cannot be combined with Offset or a non-default sort
+	Cursor string `json:"cursor"`
+	// SortBy is one of created_at (default), updated_at, name or email
+	SortBy string `json:"sort_by"`
+	// SortOrder is asc or desc (default)
+	SortOrder   string     `json:"sort_order"`
+	EmailPrefix string     `json:"email_prefix"`
+	NamePrefix  string     `json:"name_prefix"`
+	CreatedFrom *time.Time `json:"created_from"`
+	CreatedTo   *time.Time `json:"created_to"`
+}
+
+type ListUsersResponse struct {
+	Users      []*UserResponse `json:"users"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+	PrevCursor string          `json:"prev_cursor,omitempty"`
+	// TotalCount is the number of users matching the filter across all pages
+	TotalCount int64 `json:"total_count"`
+	// TotalCountEstimated is true when TotalCount is an estimate for a large unfiltered list
+	TotalCountEstimated bool `json:"total_count_estimated,omitempty"`
+}
+
+// userSortFields is the allow-list of the columns users can be sorted by
+var userSortFields = map[string]domain.UserSortField{
+	"created_at": domain.UserSortCreatedAt,
+	"updated_at": domain.UserSortUpdatedAt,
+	"name":       domain.UserSortName,
+	"email":      domain.UserSortEmail,
+}
+
+// sortOrders is the allow-list of the sort directions
+var sortOrders = map[string]domain.SortOrder{
+	"asc":  domain.SortAsc,
+	"desc": domain.SortDesc,
+}
+
+// toUserSort validates the requested sort against the allow-lists; empty values fall back to the default sort
+func toUserSort(sortBy, sortOrder string) (domain.UserSort, error) {
+	sort := domain.DefaultUserSort()
+	if sortBy != "" {
+		field, ok := userSortFields[sortBy]
+		if !ok {
+			return sort, fmt.Errorf("unknown sort field %q: %w", sortBy, domain.ErrInvalidSort)
+		}
+		sort.Field = field
+	}
+	if sortOrder != "" {
+		order, ok := sortOrders[sortOrder]
+		if !ok {
+			return sort, fmt.Errorf("unknown sort order %q: %w", sortOrder, domain.ErrInvalidSort)
+		}
+		sort.Order = order
+	}
+	return sort, nil
+}
+
+// ListUsers retrieves a filtered and sorted page of users by cursor or by offset, with the total count
+func (s *userService) ListUsers(ctx context.Context, req ListUsersRequest) (*ListUsersResponse, error) {
+	if req.Limit <= 0 {
+		return nil, domain.ErrInvalidLimit
+	}
+	if req.Offset < 0 {
+		return nil, domain.ErrInvalidOffset
+	}
+	if req.Cursor != "" && req.Offset > 0 {
+		return nil, fmt.Errorf("cursor cannot be combined with offset: %w", domain.ErrInvalidCursor)
+	}
+
+	sort, err := toUserSort(req.SortBy, req.SortOrder)
+	if err != nil {
+		return nil, err
+	}
+	// カーソルは created_at の降順を前提としているため、他の並び順ではオフセットでページングする
+	if req.Cursor != "" && !sort.IsDefault() {
+		return nil, fmt.Errorf("cursor requires the default sort: %w", domain.ErrInvalidCursor)
+	}
+
+	filter := domain.UserFilter{
+		EmailPrefix: req.EmailPrefix,
+		NamePrefix:  req.NamePrefix,
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.listUsersPage(ctx, req, filter, sort)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.TotalCount, resp.TotalCountEstimated, err = s.repo.CountUsers(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// listUsersPage reads one page of users; cursors are attached only for the default sort
+func (s *userService) listUsersPage(ctx context.Context, req ListUsersRequest, filter domain.UserFilter, sort domain.UserSort) (*ListUsersResponse, error) {
+	// 次のページの有無を判定するため 1 件多く取得する
+	fetch := req.Limit + 1
+
+	if req.Cursor == "" {
+		users, err := s.repo.ListUsers(ctx, filter, sort, fetch, req.Offset)
+		if err != nil {
+			return nil, err
+		}
+		hasNext := len(users) > int(req.Limit)
+		if hasNext {
+			users = users[:req.Limit]
+		}
+		if !sort.IsDefault() {
+			return s.toListUsersResponse(users, false, false), nil
+		}
+		return s.toListUsersResponse(users, req.Offset > 0, hasNext), nil
+	}
+
+	cursor, err := s.cursors.Decode(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.repo.ListUsersByCursor(ctx, filter, cursor, fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor.Direction == domain.CursorPrev {
+		// 新しい順に並んでいるため、余分な 1 件は先頭にある
+		hasPrev := len(users) > int(req.Limit)
+		if hasPrev {
+			users = users[1:]
+		}
+		return s.toListUsersResponse(users, hasPrev, true), nil
+	}
+
+	hasNext := len(users) > int(req.Limit)
+	if hasNext {
+		users = users[:req.Limit]
+	}
+	return s.toListUsersResponse(users, true, hasNext), nil
 }
 
-// ListUsers retrieves a paginated list of users
-func (s *userService) ListUsers(ctx context.Context, req ListUsersRequest) ([]*UserResponse, error) {
+// toListUsersResponse attaches cursors pointing before the first and after the last user of the page
+func (s *userService) toListUsersResponse(users []*domain.User, hasPrev, hasNext bool) *ListUsersResponse {
+	resp := &ListUsersResponse{Users: toUserResponses(users)}
+	if len(users) == 0 {
+		return resp
+	}
+	if hasPrev {
+		resp.PrevCursor = s.cursors.Encode(domain.PrevUserCursor(users[0]))
+	}
+	if hasNext {
+		resp.NextCursor = s.cursors.Encode(domain.NextUserCursor(users[len(users)-1]))
+	}
+	return resp
+}
+
+type SearchUsersRequest struct {
+	Query  string `json:"query"`
+	Limit  int32  `json:"limit"`
+	Offset int32  `json:"offset"`
+}
+
+type UserSearchHitResponse struct {
+	User  *UserResponse `json:"user"`
+	Score float64       `json:"score"`
+}
+
+type SearchUsersResponse struct {
+	Hits []*UserSearchHitResponse `json:"hits"`
+	// TotalCount is the number of users matching the query across all pages
+	TotalCount int64 `json:"total_count"`
+}
+
+// SearchUsers finds users whose name or email contains or resembles the query, best match first
+func (s *userService) SearchUsers(ctx context.Context, req SearchUsersRequest) (*SearchUsersResponse, error) {
+	if err := domain.ValidateSearchQuery(req.Query); err != nil {
+		return nil, err
+	}
 	if req.Limit <= 0 {
 		return nil, domain.ErrInvalidLimit
 	}
@@ -217,23 +519,43 @@ func (s *userService) ListUsers(ctx context.Context, req ListUsersRequest) ([]*U
 		return nil, domain.ErrInvalidOffset
 	}
 
-	users, err := s.repo.ListUsers(ctx, req.Limit, req.Offset)
+	query := strings.TrimSpace(req.Query)
+	hits, err := s.repo.SearchUsers(ctx, query, req.Limit, req.Offset)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.repo.CountSearchUsers(ctx, query)
 	if err != nil {
 		return nil, err
 	}
-	return toUserResponses(users), nil
+
+	resp := &SearchUsersResponse{
+		Hits:       make([]*UserSearchHitResponse, 0, len(hits)),
+		TotalCount: total,
+	}
+	for _, hit := range hits {
+		resp.Hits = append(resp.Hits, &UserSearchHitResponse{User: toUserResponse(hit.User), Score: hit.Score})
+	}
+	return resp, nil
+}
+
+func toUserResponse(user *domain.User) *UserResponse {
+	return &UserResponse{
+		ID:              user.ID,
+		Email:           user.Email,
+		Name:            user.Name,
+		Roles:           user.Roles,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		Version:         user.Version,
+	}
 }
 
 func toUserResponses(users []*domain.User) []*UserResponse {
 	responses := make([]*UserResponse, 0, len(users))
 	for _, user := range users {
-		responses = append(responses, &UserResponse{
-			ID:        user.ID,
-			Email:     user.Email,
-			Name:      user.Name,
-			CreatedAt: user.CreatedAt,
-			UpdatedAt: user.UpdatedAt,
-		})
+		responses = append(responses, toUserResponse(user))
 	}
 	return responses
 }
@@ -241,24 +563,494 @@ func toUserResponses(users []*domain.User) []*UserResponse {
 type AuthenticateUserRequest struct {
 	Email    domain.Email    `json:"email"`
 	Password domain.Password `json:"password"`
+	// ClientIP is the caller's address used for per-IP throttling; empty disables it
+	ClientIP string `json:"-"`
 }
 
-func (s *userService) AuthenticateUser(ctx context.Context, req AuthenticateUserRequest) error {
+// AuthTokens is the token pair returned on login and refresh
+type AuthTokens struct {
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+// AuthenticateUser verifies the credentials and issues a new token pair.
+// Repeated failures temporarily lock the account (domain.ErrAccountLocked) or the client IP (domain.ErrTooManyAttempts).
+func (s *userService) AuthenticateUser(ctx context.Context, req AuthenticateUserRequest) (_ *AuthTokens, err error) {
+	var userID uuid.UUID
+	defer func() {
+		s.metrics.AuthenticationAttempted(err == nil)
+		s.auditAuthentication(ctx, userID, err)
+	}()
+
 	if req.Email == "" {
-		return domain.ErrInvalidEmail
+		return nil, domain.ErrInvalidEmail
 	}
 	if req.Password == "" {
-		return domain.ErrInvalidPassword
+		return nil, domain.ErrInvalidPassword
+	}
+
+	if req.ClientIP != "" {
+		if err := s.limiter.Check(ctx, domain.LoginThrottleScopeIP, req.ClientIP); err != nil {
+			return nil, err
+		}
 	}
 
 	user, err := s.repo.GetByEmail(ctx, req.Email)
 	if err != nil {
-		return err
+		// ユーザーの存在有無を推測されないよう認証失敗として扱う
+		// 応答時間からも推測されないよう、存在するユーザーと同じくパスワードを照合する
+		s.hasher.Compare(dummyPasswordHash, string(req.Password))
+		s.logger.Info(ctx, "Authentication failed: user lookup", zap.Error(err))
+		if err := s.recordIPFailure(ctx, req.ClientIP); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	userID = user.ID
+	userKey := user.ID.String()
+	if err := s.limiter.Check(ctx, domain.LoginThrottleScopeUser, userKey); err != nil {
+		return nil, err
 	}
 
 	if !s.hasher.Compare(user.Password, string(req.Password)) {
-		return fmt.Errorf("invalid credentials")
+		if err := s.recordIPFailure(ctx, req.ClientIP); err != nil {
+			return nil, err
+		}
+		locked, err := s.limiter.RecordFailure(ctx, domain.LoginThrottleScopeUser, userKey)
+		if err != nil {
+			return nil, err
+		}
+		if locked {
+			return nil, domain.ErrAccountLocked
+		}
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	// 成功時はユーザー単位の失敗回数のみリセットする（IP 単位は他アカウントへの試行も含むため維持）
+	if err := s.limiter.Reset(ctx, domain.LoginThrottleScopeUser, userKey); err != nil {
+		return nil, err
+	}
+
+	// パスワードが正しい場合のみ伝え、未検証かどうかから登録の有無を推測されないようにする
+	if s.verifier.Required() && !user.IsEmailVerified() {
+		return nil, domain.ErrEmailNotVerified
+	}
+
+	return s.issueTokens(ctx, user.ID, user.Roles)
+}
+
+// recordIPFailure counts a failed login against the client IP, if known
+func (s *userService) recordIPFailure(ctx context.Context, clientIP string) error {
+	if clientIP == "" {
+		return nil
+	}
+	_, err := s.limiter.RecordFailure(ctx, domain.LoginThrottleScopeIP, clientIP)
+	return err
+}
+
+type UnlockUserRequest struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// UnlockUser lifts a lockout of the user before the cooldown expires
+func (s *userService) UnlockUser(ctx context.Context, req UnlockUserRequest) (err error) {
+	defer func() {
+		s.auditUserChange(ctx, domain.AuditActionUserUnlock, req.ID, nil, nil, err)
+	}()
+
+	if req.ID == uuid.Nil {
+		return domain.ErrInvalidID
+	}
+
+	if _, err := s.repo.GetByID(ctx, req.ID); err != nil {
+		return err
+	}
+
+	if err := s.limiter.Reset(ctx, domain.LoginThrottleScopeUser, req.ID.String()); err != nil {
+		return err
+	}
+
+	s.logger.Info(ctx, "User unlocked", zap.String("user_id", req.ID.String()))
+	return nil
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RefreshToken exchanges a refresh token for a new token pair (the old refresh token is rotated out)
+func (s *userService) RefreshToken(ctx context.Context, req RefreshTokenRequest) (*AuthTokens, error) {
+	if req.RefreshToken == "" {
+		return nil, domain.ErrInvalidToken
+	}
+
+	token, err := s.tokenRepo.GetByHash(ctx, s.issuer.HashRefreshToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	if token.IsRevoked() {
+		// 失効済みトークンの再利用は漏洩の可能性があるため、ユーザーの全トークンを失効させる
+		s.logger.Warn(ctx, "Revoked refresh token reused", zap.String("user_id", token.UserID.String()))
+		if err := s.tokenRepo.RevokeAllForUser(ctx, token.UserID); err != nil {
+			return nil, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+		}
+		return nil, domain.ErrInvalidToken
+	}
+	if !token.IsActive(time.Now()) {
+		return nil, domain.ErrInvalidToken
+	}
+
+	if err := s.tokenRepo.Revoke(ctx, token.ID); err != nil {
+		// 同時リフレッシュで先に失効された場合
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+
+	// ロールの付与・剥奪を反映するため、リフレッシュ時に最新のロールを取得する
+	user, err := s.repo.GetByID(ctx, token.UserID)
+	if err != nil {
+		s.logger.Info(ctx, "Refresh failed: user lookup", zap.Error(err))
+		return nil, domain.ErrInvalidToken
+	}
+
+	return s.issueTokens(ctx, user.ID, user.Roles)
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Logout revokes the given refresh token; unknown or already revoked tokens are ignored
+func (s *userService) Logout(ctx context.Context, req LogoutRequest) error {
+	if req.RefreshToken == "" {
+		return domain.ErrInvalidToken
+	}
+
+	token, err := s.tokenRepo.GetByHash(ctx, s.issuer.HashRefreshToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	if err := s.tokenRepo.Revoke(ctx, token.ID); err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	return nil
+}
+
+type ChangePasswordRequest struct {
+	ID              uuid.UUID       `json:"id"`
+	CurrentPassword domain.Password `json:"current_password"`
+	NewPassword     domain.Password `json:"new_password"`
+}
+
+// ChangePassword changes the password and revokes all refresh tokens of the user
+func (s *userService) ChangePassword(ctx context.Context, req ChangePasswordRequest) (err error) {
+	var before, after *domain.User
+	defer func() {
+		s.auditUserChange(ctx, domain.AuditActionPasswordChange, req.ID, before, after, err)
+	}()
+
+	if req.ID == uuid.Nil {
+		return domain.ErrInvalidID
+	}
+	if req.CurrentPassword == "" {
+		return domain.ErrInvalidPassword
+	}
+	if err := domain.ValidatePassword(req.NewPassword); err != nil {
+		return err
+	}
+
+	user, err := s.repo.GetByID(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+
+	if !s.hasher.Compare(user.Password, string(req.CurrentPassword)) {
+		return domain.ErrInvalidCredentials
+	}
+	before = snapshotUser(user)
+
+	if err := s.setPassword(ctx, user, req.NewPassword); err != nil {
+		return err
+	}
+	after = user
+	return nil
+}
+
+type RequestPasswordResetRequest struct {
+	Email domain.Email `json:"email"`
+}
+
+// RequestPasswordReset issues a single-use reset token and delivers it through the notifier.
+// Unknown emails succeed silently so that the endpoint cannot be used to enumerate users.
+func (s *userService) RequestPasswordReset(ctx context.Context, req RequestPasswordResetRequest) error {
+	email := domain.NormalizeEmail(req.Email)
+	if err := domain.ValidateEmail(email); err != nil {
+		return err
+	}
+
+	user, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		s.logger.Info(ctx, "Password reset requested for unknown email", zap.Error(err))
+		return nil
+	}
+
+	// 未使用の古いトークンは無効化し、有効なトークンを常に 1 つに保つ
+	if err := s.resetRepo.InvalidateAllForUser(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to invalidate password reset tokens: %w", err)
+	}
+
+	token, tokenHash, expiresAt, err := s.issuer.IssuePasswordResetToken()
+	if err != nil {
+		return err
+	}
+	if err := s.resetRepo.Create(ctx, domain.NewPasswordResetToken(user.ID, tokenHash, expiresAt)); err != nil {
+		return fmt.Errorf("failed to save password reset token: %w", err)
+	}
+
+	if err := s.notifier.SendPasswordReset(ctx, user, token, expiresAt); err != nil {
+		return fmt.Errorf("failed to send password reset: %w", err)
+	}
+	return nil
+}
+
+type ConfirmPasswordResetRequest struct {
+	Token       string          `json:"token"`
+	NewPassword domain.Password `json:"new_password"`
+}
+
+// ConfirmPasswordReset consumes the reset token, sets the new password and revokes all refresh tokens of the user
+func (s *userService) ConfirmPasswordReset(ctx context.Context, req ConfirmPasswordResetRequest) (err error) {
+	var userID uuid.UUID
+	var before, after *domain.User
+	defer func() {
+		s.auditUserChange(ctx, domain.AuditActionPasswordReset, userID, before, after, err)
+	}()
+
+	if req.Token == "" {
+		return domain.ErrInvalidToken
+	}
+	if err := domain.ValidatePassword(req.NewPassword); err != nil {
+		return err
+	}
+
+	token, err := s.resetRepo.GetByHash(ctx, s.issuer.HashPasswordResetToken(req.Token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidToken
+		}
+		return fmt.Errorf("failed to get password reset token: %w", err)
+	}
+	userID = token.UserID
+	if !token.IsActive(time.Now()) {
+		return domain.ErrInvalidToken
+	}
+
+	if err := s.resetRepo.MarkUsed(ctx, token.ID); err != nil {
+		// 同時に使用された場合
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidToken
+		}
+		return fmt.Errorf("failed to mark password reset token as used: %w", err)
+	}
+
+	user, err := s.repo.GetByID(ctx, token.UserID)
+	if err != nil {
+		return err
+	}
+	before = snapshotUser(user)
+
+	if err := s.setPassword(ctx, user, req.NewPassword); err != nil {
+		return err
+	}
+	after = user
+	return nil
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+// VerifyEmail consumes the verification token and marks the email it was sent to as verified
+func (s *userService) VerifyEmail(ctx context.Context, req VerifyEmailRequest) (err error) {
+	var userID uuid.UUID
+	defer func() {
+		s.auditUserChange(ctx, domain.AuditActionEmailVerify, userID, nil, nil, err)
+	}()
+
+	if req.Token == "" {
+		return domain.ErrInvalidToken
+	}
+
+	token, err := s.verifier.Consume(ctx, req.Token)
+	if err != nil {
+		return err
+	}
+	userID = token.UserID
+
+	if err := s.repo.MarkEmailVerified(ctx, token.UserID, token.Email); err != nil {
+		// トークンの発行後にメールアドレスが変更された（またはユーザーが削除された）
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.ErrInvalidToken
+		}
+		return err
+	}
+
+	s.logger.Info(ctx, "Email verified", zap.String("user_id", token.UserID.String()))
+	return nil
+}
+
+type ResendEmailVerificationRequest struct {
+	Email domain.Email `json:"email"`
+}
+
+// ResendEmailVerification sends a new verification token to an unverified user.
+// Unknown, already verified and rate-limited emails succeed silently so that the endpoint cannot be used to enumerate users.
+func (s *userService) ResendEmailVerification(ctx context.Context, req ResendEmailVerificationRequest) error {
+	email := domain.NormalizeEmail(req.Email)
+	if err := domain.ValidateEmail(email); err != nil {
+		return err
+	}
+
+	user, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		s.logger.Info(ctx, "Email verification requested for unknown email", zap.Error(err))
+		return nil
+	}
+	if user.IsEmailVerified() {
+		return nil
+	}
+
+	if err := s.verifier.Send(ctx, user); err != nil && !errors.Is(err, domain.ErrTooManyEmails) {
+		return err
+	}
+	return nil
+}
+
+// sendEmailVerification sends a verification token after the email has been set; failures are only logged
+// because the user can request another one with ResendEmailVerification
+func (s *userService) sendEmailVerification(ctx context.Context, user *domain.User) {
+	if err := s.verifier.Send(ctx, user); err != nil {
+		s.logger.Error(ctx, "Failed to send email verification",
+			zap.String("user_id", user.ID.String()),
+			zap.Error(err))
+	}
+}
+
+// setPassword hashes and stores the new password, then revokes all refresh tokens of the user
+func (s *userService) setPassword(ctx context.Context, user *domain.User, newPassword domain.Password) error {
+	hashedPassword, err := s.hasher.Hash(newPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	if err := user.UpdatePassword(domain.Password(hashedPassword)); err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdatePassword(ctx, user.ID, user.Password); err != nil {
+		return err
+	}
+
+	// パスワード変更時は既存のセッションをすべて無効にする
+	if err := s.tokenRepo.RevokeAllForUser(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return nil
+}
+
+type GrantRoleRequest struct {
+	UserID uuid.UUID   `json:"user_id"`
+	Role   domain.Role `json:"role"`
+}
+
+// GrantRole grants a role to the user; granting an already granted role is a no-op
+func (s *userService) GrantRole(ctx context.Context, req GrantRoleRequest) (err error) {
+	defer func() {
+		s.auditRoleChange(ctx, req.UserID, req.Role, true, err)
+	}()
+
+	if req.UserID == uuid.Nil {
+		return domain.ErrInvalidID
+	}
+	if err := domain.ValidateRole(req.Role); err != nil {
+		return err
+	}
+
+	// 存在しないユーザーへの付与は外部キー違反ではなく not found として返す
+	if _, err := s.repo.GetByID(ctx, req.UserID); err != nil {
+		return err
+	}
+
+	if err := s.repo.GrantRole(ctx, req.UserID, req.Role); err != nil {
+		return err
 	}
 
+	s.logger.Info(ctx, "Role granted", zap.String("user_id", req.UserID.String()), zap.String("role", string(req.Role)))
 	return nil
 }
+
+type RevokeRoleRequest struct {
+	UserID uuid.UUID   `json:"user_id"`
+	Role   domain.Role `json:"role"`
+}
+
+// RevokeRole revokes a role from the user; revoking a role that is not granted is a no-op
+func (s *userService) RevokeRole(ctx context.Context, req RevokeRoleRequest) (err error) {
+	defer func() {
+		s.auditRoleChange(ctx, req.UserID, req.Role, false, err)
+	}()
+
+	if req.UserID == uuid.Nil {
+		return domain.ErrInvalidID
+	}
+	if err := domain.ValidateRole(req.Role); err != nil {
+		return err
+	}
+
+	if _, err := s.repo.GetByID(ctx, req.UserID); err != nil {
+		return err
+	}
+
+	if err := s.repo.RevokeRole(ctx, req.UserID, req.Role); err != nil {
+		return err
+	}
+
+	s.logger.Info(ctx, "Role revoked", zap.String("user_id", req.UserID.String()), zap.String("role", string(req.Role)))
+	return nil
+}
+
+// issueTokens issues an access token and persists a new refresh token for the user
+func (s *userService) issueTokens(ctx context.Context, userID uuid.UUID, roles []domain.Role) (*AuthTokens, error) {
+	accessToken, accessExpiresAt, err := s.issuer.IssueAccessToken(userID, roles)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, refreshHash, refreshExpiresAt, err := s.issuer.IssueRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tokenRepo.Create(ctx, domain.NewRefreshToken(userID, refreshHash, refreshExpiresAt)); err != nil {
+		return nil, fmt.Errorf("failed to save refresh token: %w", err)
+	}
+
+	return &AuthTokens{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshExpiresAt,
+	}, nil
+}
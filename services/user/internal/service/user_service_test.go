@@ -3,15 +3,18 @@ package service_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lot-koichi/sre-skill-up-project/pkg/logger"
 	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/domain"
 	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/repository"
 	"github.com/lot-koichi/sre-skill-up-project/services/user/internal/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -22,9 +25,102 @@ type MockPasswordHasher struct {
 }
 
 // createTestLogger creates a test logger
-func createTestLogger() *zap.Logger {
-	logger, _ := zap.NewDevelopment()
-	return logger
+func createTestLogger() logger.Logger {
+	zapLogger, _ := zap.NewDevelopment()
+	return logger.New(zapLogger, logger.DefaultRedactionConfig())
+}
+
+// newTestTokenIssuer creates a token issuer with a fixed test secret
+func newTestTokenIssuer() service.TokenIssuer {
+	return service.NewJWTTokenIssuer(service.DefaultTokenConfig([]byte("test-secret")))
+}
+
+// newTestCursorCodec creates a cursor codec with a fixed test secret
+func newTestCursorCodec() service.CursorCodec {
+	return service.NewHMACCursorCodec([]byte("test-cursor-secret"))
+}
+
+// newTestLoginLimiter creates a login limiter with the default lockout configuration
+func newTestLoginLimiter(repo *repository.MockLoginThrottleRepository) *service.LoginLimiter {
+	return service.NewLoginLimiter(repo, service.DefaultLockoutConfig(), createTestLogger())
+}
+
+// newTestEmailVerifier creates an email verifier whose token repository and notifier accept any call
+func newTestEmailVerifier() *service.EmailVerifier {
+	repo := new(repository.MockEmailVerificationTokenRepository)
+	repo.On("CountIssuedSince", mock.Anything, mock.Anything, mock.Anything).Return(0, nil).Maybe()
+	repo.On("InvalidateAllForUser", mock.Anything, mock.Anything).Return(nil).Maybe()
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+	notifier := new(MockNotifier)
+	notifier.On("SendEmailVerification", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	return service.NewEmailVerifier(repo, newTestTokenIssuer(), notifier, service.DefaultEmailVerificationConfig(), createTestLogger())
+}
+
+// newTestAuditLog creates an audit log whose repository accepts any write
+func newTestAuditLog() *service.AuditLog {
+	repo := new(repository.MockAuditEventRepository)
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+	repo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return service.NewAuditLog(repo, createTestLogger())
+}
+
+// testServiceDeps are the dependencies of a user service under test; nil fields get the defaults of newTestService
+type testServiceDeps struct {
+	repo         repository.UserRepository
+	tokenRepo    repository.RefreshTokenRepository
+	resetRepo    repository.PasswordResetTokenRepository
+	hasher       service.PasswordHasher
+	issuer       service.TokenIssuer
+	notifier     service.Notifier
+	throttleRepo *repository.MockLoginThrottleRepository
+	verifier     *service.EmailVerifier
+	cursors      service.CursorCodec
+	// batch is DefaultBatchConfig if it is the zero value
+	batch   service.BatchConfig
+	audit   *service.AuditLog
+	metrics service.Metrics
+}
+
+// newTestService creates a user service from deps, using mocks without expectations for the dependencies not set
+func newTestService(deps testServiceDeps) service.UserService {
+	if deps.repo == nil {
+		deps.repo = new(repository.MockUserRepository)
+	}
+	if deps.tokenRepo == nil {
+		deps.tokenRepo = new(repository.MockRefreshTokenRepository)
+	}
+	if deps.resetRepo == nil {
+		deps.resetRepo = new(repository.MockPasswordResetTokenRepository)
+	}
+	if deps.hasher == nil {
+		deps.hasher = new(MockPasswordHasher)
+	}
+	if deps.issuer == nil {
+		deps.issuer = newTestTokenIssuer()
+	}
+	if deps.notifier == nil {
+		deps.notifier = new(MockNotifier)
+	}
+	if deps.throttleRepo == nil {
+		deps.throttleRepo = new(repository.MockLoginThrottleRepository)
+	}
+	if deps.verifier == nil {
+		deps.verifier = newTestEmailVerifier()
+	}
+	if deps.cursors == nil {
+		deps.cursors = newTestCursorCodec()
+	}
+	if deps.batch == (service.BatchConfig{}) {
+		deps.batch = service.DefaultBatchConfig()
+	}
+	if deps.audit == nil {
+		deps.audit = newTestAuditLog()
+	}
+	if deps.metrics == nil {
+		deps.metrics = service.NewNopMetrics()
+	}
+	return service.NewUserService(deps.repo, deps.tokenRepo, deps.resetRepo, deps.hasher, deps.issuer, deps.notifier,
+		newTestLoginLimiter(deps.throttleRepo), deps.verifier, deps.cursors, deps.batch, deps.audit, deps.metrics, createTestLogger())
 }
 
 func (m *MockPasswordHasher) Hash(password domain.Password) (string, error) {
@@ -37,13 +133,28 @@ func (m *MockPasswordHasher) Compare(hashedPassword domain.Password, plainPasswo
 	return args.Bool(0)
 }
 
+// MockNotifier is a mock implementation of Notifier
+type MockNotifier struct {
+	mock.Mock
+}
+
+func (m *MockNotifier) SendPasswordReset(ctx context.Context, user *domain.User, token string, expiresAt time.Time) error {
+	args := m.Called(ctx, user, token, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockNotifier) SendEmailVerification(ctx context.Context, user *domain.User, token string, expiresAt time.Time) error {
+	args := m.Called(ctx, user, token, expiresAt)
+	return args.Error(0)
+}
+
 func TestUserService_CreateUser_Success(t *testing.T) {
 	// 1. モックリポジトリを作成
 	mockRepo := new(repository.MockUserRepository)
 	mockHasher := new(MockPasswordHasher)
 
 	// 2. サービスを作成（モックを注入）
-	svc := service.NewUserService(mockRepo, mockHasher, createTestLogger())
+	svc := newTestService(testServiceDeps{repo: mockRepo, hasher: mockHasher})
 
 	// 3. モックの期待値を設定
 	// パスワードハッシュ化
@@ -91,7 +202,7 @@ func TestUserService_CreateUser_RepositoryError(t *testing.T) {
 	mockHasher := new(MockPasswordHasher)
 
 	// 2. サービスを作成
-	svc := service.NewUserService(mockRepo, mockHasher, createTestLogger())
+	svc := newTestService(testServiceDeps{repo: mockRepo, hasher: mockHasher})
 
 	// 3. パスワードハッシュ化
 	mockHasher.On("Hash", domain.Password("testPass123")).
@@ -135,7 +246,7 @@ func TestUserService_GetUserByID_Success(t *testing.T) {
 	mockHasher := new(MockPasswordHasher)
 
 	// 2. サービスを作成
-	svc := service.NewUserService(mockRepo, mockHasher, createTestLogger())
+	svc := newTestService(testServiceDeps{repo: mockRepo, hasher: mockHasher})
 
 	// 3. 期待する返り値を準備
 	expectedUser := &domain.User{
@@ -176,7 +287,7 @@ func TestUserService_GetUserByID_NotFound(t *testing.T) {
 	mockHasher := new(MockPasswordHasher)
 
 	// 2. サービスを作成
-	svc := service.NewUserService(mockRepo, mockHasher, createTestLogger())
+	svc := newTestService(testServiceDeps{repo: mockRepo, hasher: mockHasher})
 
 	// 3. 存在しないユーザーID
 	notFoundID := uuid.New()
@@ -204,7 +315,7 @@ func TestUserService_GetUserByID_NotFound(t *testing.T) {
 func TestUserService_GetUserByID_InvalidID(t *testing.T) {
 	mockRepo := new(repository.MockUserRepository)
 	mockHasher := new(MockPasswordHasher)
-	svc := service.NewUserService(mockRepo, mockHasher, createTestLogger())
+	svc := newTestService(testServiceDeps{repo: mockRepo, hasher: mockHasher})
 
 	ctx := context.Background()
 	user, err := svc.GetUserByID(ctx, uuid.Nil)
@@ -217,6 +328,62 @@ func TestUserService_GetUserByID_InvalidID(t *testing.T) {
 	mockRepo.AssertNotCalled(t, "GetByID")
 }
 
+func TestUserService_GetUserByEmail(t *testing.T) {
+	existing := &domain.User{
+		ID:    uuid.New(),
+		Email: domain.Email("Alice@Example.com"),
+		Name:  domain.Name("Alice"),
+	}
+
+	tests := []struct {
+		name      string
+		email     domain.Email
+		mockSetup func(*repository.MockUserRepository)
+		wantErr   error
+	}{
+		{
+			name:  "正常系：正規化したメールアドレスで検索する",
+			email: domain.Email("  alice@EXAMPLE.com "),
+			mockSetup: func(m *repository.MockUserRepository) {
+				m.On("GetByEmail", mock.Anything, domain.Email("alice@example.com")).Return(existing, nil).Once()
+			},
+		},
+		{
+			name:      "異常系：不正なメールアドレス",
+			email:     domain.Email("not-an-email"),
+			mockSetup: func(m *repository.MockUserRepository) {},
+			wantErr:   domain.ErrInvalidEmail,
+		},
+		{
+			name:  "異常系：ユーザーが存在しない",
+			email: domain.Email("nobody@example.com"),
+			mockSetup: func(m *repository.MockUserRepository) {
+				m.On("GetByEmail", mock.Anything, domain.Email("nobody@example.com")).Return(nil, domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockUserRepository)
+			tt.mockSetup(mockRepo)
+			svc := newTestService(testServiceDeps{repo: mockRepo})
+
+			user, err := svc.GetUserByEmail(context.Background(), tt.email)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, user)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, existing.ID, user.ID)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
 // ========== テーブルドリブンテストの例 ==========
 
 func TestUserService_CreateUser_TableDriven(t *testing.T) {
@@ -309,7 +476,7 @@ func TestUserService_CreateUser_TableDriven(t *testing.T) {
 			}
 
 			// サービスを作成
-			svc := service.NewUserService(mockRepo, mockHasher, createTestLogger())
+			svc := newTestService(testServiceDeps{repo: mockRepo, hasher: mockHasher})
 
 			// テスト実行
 			ctx := context.Background()
@@ -339,7 +506,7 @@ func TestUserService_CreateUser_TableDriven(t *testing.T) {
 func TestUserService_UpdateUser_Success(t *testing.T) {
 	mockRepo := new(repository.MockUserRepository)
 	mockHasher := new(MockPasswordHasher)
-	svc := service.NewUserService(mockRepo, mockHasher, createTestLogger())
+	svc := newTestService(testServiceDeps{repo: mockRepo, hasher: mockHasher})
 
 	existingUser := &domain.User{
 		ID:        uuid.New(),
@@ -375,7 +542,7 @@ func TestUserService_UpdateUser_Success(t *testing.T) {
 func TestUserService_UpdateUser_UserNotFound(t *testing.T) {
 	mockRepo := new(repository.MockUserRepository)
 	mockHasher := new(MockPasswordHasher)
-	svc := service.NewUserService(mockRepo, mockHasher, createTestLogger())
+	svc := newTestService(testServiceDeps{repo: mockRepo, hasher: mockHasher})
 
 	userID := uuid.New()
 	mockRepo.On("GetByID",
@@ -399,7 +566,7 @@ func TestUserService_UpdateUser_UserNotFound(t *testing.T) {
 func TestUserService_UpdateUser_InvalidInput(t *testing.T) {
 	mockRepo := new(repository.MockUserRepository)
 	mockHasher := new(MockPasswordHasher)
-	svc := service.NewUserService(mockRepo, mockHasher, createTestLogger())
+	svc := newTestService(testServiceDeps{repo: mockRepo, hasher: mockHasher})
 
 	ctx := context.Background()
 	req := service.UpdateUserRequest{
@@ -414,12 +581,161 @@ func TestUserService_UpdateUser_InvalidInput(t *testing.T) {
 	mockRepo.AssertNotCalled(t, "GetByEmail")
 }
 
+func TestUserService_UpdateUser_Version(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   int64
+		mockSetup func(*repository.MockUserRepository, *domain.User)
+		wantErr   error
+	}{
+		{
+			name:    "正常系：If-Match のバージョンが一致",
+			version: 3,
+			mockSetup: func(r *repository.MockUserRepository, u *domain.User) {
+				r.On("GetByID", mock.Anything, u.ID).Return(u, nil).Once()
+				r.On("Update", mock.Anything, mock.MatchedBy(func(user *domain.User) bool {
+					// 読み込んだバージョンを条件に保存する
+					return user.Version == 3
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name:    "異常系：If-Match のバージョンが古い",
+			version: 2,
+			mockSetup: func(r *repository.MockUserRepository, u *domain.User) {
+				r.On("GetByID", mock.Anything, u.ID).Return(u, nil).Once()
+			},
+			wantErr: domain.ErrConcurrentModification,
+		},
+		{
+			name:    "異常系：読み込み後に他の更新が入った",
+			version: 0,
+			mockSetup: func(r *repository.MockUserRepository, u *domain.User) {
+				r.On("GetByID", mock.Anything, u.ID).Return(u, nil).Once()
+				r.On("Update", mock.Anything, u).Return(domain.ErrConcurrentModification).Once()
+			},
+			wantErr: domain.ErrConcurrentModification,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockUserRepository)
+			svc := newTestService(testServiceDeps{repo: mockRepo})
+
+			existingUser := &domain.User{
+				ID:       uuid.New(),
+				Email:    domain.Email("user@example.com"),
+				Name:     domain.Name("Old Name"),
+				Password: domain.Password("hashedPassword"),
+				Version:  3,
+			}
+			tt.mockSetup(mockRepo, existingUser)
+
+			err := svc.UpdateUser(context.Background(), service.UpdateUserRequest{
+				ID:      existingUser.ID,
+				Name:    domain.Name("New Name"),
+				Version: tt.version,
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_PatchUser(t *testing.T) {
+	newName := domain.Name("New Name")
+	sameName := domain.Name("Old Name")
+	invalidEmail := domain.Email("invalid")
+
+	tests := []struct {
+		name      string
+		patch     domain.UserPatch
+		version   int64
+		mockSetup func(*repository.MockUserRepository, *domain.User)
+		wantName  domain.Name
+		wantErr   error
+	}{
+		{
+			name:  "正常系：変更したフィールドのみ書き込む",
+			patch: domain.UserPatch{Name: &newName},
+			mockSetup: func(r *repository.MockUserRepository, u *domain.User) {
+				r.On("GetByID", mock.Anything, u.ID).Return(u, nil).Once()
+				r.On("Patch", mock.Anything, u, domain.UserPatch{Name: &newName}).Return(nil).Once()
+			},
+			wantName: newName,
+		},
+		{
+			name:  "正常系：値が変わらなければ書き込まない",
+			patch: domain.UserPatch{Name: &sameName},
+			mockSetup: func(r *repository.MockUserRepository, u *domain.User) {
+				r.On("GetByID", mock.Anything, u.ID).Return(u, nil).Once()
+			},
+			wantName: sameName,
+		},
+		{
+			name:    "異常系：If-Match のバージョンが古い",
+			patch:   domain.UserPatch{Name: &newName},
+			version: 1,
+			mockSetup: func(r *repository.MockUserRepository, u *domain.User) {
+				r.On("GetByID", mock.Anything, u.ID).Return(u, nil).Once()
+			},
+			wantErr: domain.ErrConcurrentModification,
+		},
+		{
+			name:  "異常系：不正なメールアドレス",
+			patch: domain.UserPatch{Email: &invalidEmail},
+			mockSetup: func(r *repository.MockUserRepository, u *domain.User) {
+				r.On("GetByID", mock.Anything, u.ID).Return(u, nil).Once()
+			},
+			wantErr: domain.ErrInvalidEmail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockUserRepository)
+			svc := newTestService(testServiceDeps{repo: mockRepo})
+
+			existingUser := &domain.User{
+				ID:       uuid.New(),
+				Email:    domain.Email("user@example.com"),
+				Name:     domain.Name("Old Name"),
+				Password: domain.Password("hashedPassword"),
+				Version:  2,
+			}
+			tt.mockSetup(mockRepo, existingUser)
+
+			resp, err := svc.PatchUser(context.Background(), service.PatchUserRequest{
+				ID:      existingUser.ID,
+				Patch:   tt.patch,
+				Version: tt.version,
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantName, resp.Name)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
 // ========== DeleteUser テスト ==========
 
 func TestUserService_DeleteUser_Success(t *testing.T) {
 	mockRepo := new(repository.MockUserRepository)
+	mockTokenRepo := new(repository.MockRefreshTokenRepository)
 	mockHasher := new(MockPasswordHasher)
-	svc := service.NewUserService(mockRepo, mockHasher, createTestLogger())
+	svc := newTestService(testServiceDeps{repo: mockRepo, tokenRepo: mockTokenRepo, hasher: mockHasher})
 
 	userID := uuid.New()
 	mockRepo.On("Delete",
@@ -427,18 +743,25 @@ func TestUserService_DeleteUser_Success(t *testing.T) {
 		userID,
 	).Return(nil).Once()
 
+	// 削除時にリフレッシュトークンが失効されること
+	mockTokenRepo.On("RevokeAllForUser",
+		mock.Anything,
+		userID,
+	).Return(nil).Once()
+
 	ctx := context.Background()
 	req := service.DeleteUserRequest{ID: userID}
 	err := svc.DeleteUser(ctx, req)
 
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
+	mockTokenRepo.AssertExpectations(t)
 }
 
 func TestUserService_DeleteUser_InvalidID(t *testing.T) {
 	mockRepo := new(repository.MockUserRepository)
 	mockHasher := new(MockPasswordHasher)
-	svc := service.NewUserService(mockRepo, mockHasher, createTestLogger())
+	svc := newTestService(testServiceDeps{repo: mockRepo, hasher: mockHasher})
 
 	ctx := context.Background()
 	req := service.DeleteUserRequest{ID: uuid.Nil}
@@ -452,7 +775,7 @@ func TestUserService_DeleteUser_InvalidID(t *testing.T) {
 func TestUserService_DeleteUser_RepositoryError(t *testing.T) {
 	mockRepo := new(repository.MockUserRepository)
 	mockHasher := new(MockPasswordHasher)
-	svc := service.NewUserService(mockRepo, mockHasher, createTestLogger())
+	svc := newTestService(testServiceDeps{repo: mockRepo, hasher: mockHasher})
 
 	userID := uuid.New()
 	expectedErr := errors.New("database error")
@@ -470,12 +793,78 @@ func TestUserService_DeleteUser_RepositoryError(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUserService_RestoreUser(t *testing.T) {
+	userID := uuid.New()
+	restoredUser := &domain.User{
+		ID:    userID,
+		Email: domain.Email("test@example.com"),
+		Name:  domain.Name("Test User"),
+		Roles: []domain.Role{},
+	}
+
+	tests := []struct {
+		name      string
+		id        uuid.UUID
+		mockSetup func(*repository.MockUserRepository)
+		wantErr   error
+	}{
+		{
+			name: "正常系：論理削除されたユーザーを復元",
+			id:   userID,
+			mockSetup: func(m *repository.MockUserRepository) {
+				m.On("Restore", mock.Anything, userID).Return(restoredUser, nil).Once()
+			},
+		},
+		{
+			name: "異常系：削除されていないユーザー",
+			id:   userID,
+			mockSetup: func(m *repository.MockUserRepository) {
+				m.On("Restore", mock.Anything, userID).Return(nil, domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name: "異常系：メールアドレスが別のユーザーで使用されている",
+			id:   userID,
+			mockSetup: func(m *repository.MockUserRepository) {
+				m.On("Restore", mock.Anything, userID).Return(nil, domain.ErrDuplicateEmail).Once()
+			},
+			wantErr: domain.ErrUserAlreadyExists,
+		},
+		{
+			name:      "異常系：IDが空",
+			id:        uuid.Nil,
+			mockSetup: func(m *repository.MockUserRepository) {},
+			wantErr:   domain.ErrInvalidID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockUserRepository)
+			tt.mockSetup(mockRepo)
+			svc := newTestService(testServiceDeps{repo: mockRepo})
+
+			resp, err := svc.RestoreUser(context.Background(), service.RestoreUserRequest{ID: tt.id})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, userID, resp.ID)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
 // ========== ListUsers テスト ==========
 
 func TestUserService_ListUsers_Success(t *testing.T) {
 	mockRepo := new(repository.MockUserRepository)
 	mockHasher := new(MockPasswordHasher)
-	svc := service.NewUserService(mockRepo, mockHasher, createTestLogger())
+	svc := newTestService(testServiceDeps{repo: mockRepo, hasher: mockHasher})
 
 	mockUsers := []*domain.User{
 		{
@@ -496,31 +885,38 @@ func TestUserService_ListUsers_Success(t *testing.T) {
 		},
 	}
 
+	// 次のページの有無を判定するため limit+1 件を取得する
 	mockRepo.On("ListUsers",
 		mock.Anything,
-		int32(10),
+		domain.UserFilter{},
+		domain.DefaultUserSort(),
+		int32(11),
 		int32(0),
 	).Return(mockUsers, nil).Once()
+	mockRepo.On("CountUsers", mock.Anything, domain.UserFilter{}).Return(int64(2), false, nil).Once()
 
 	ctx := context.Background()
 	req := service.ListUsersRequest{
 		Limit:  10,
 		Offset: 0,
 	}
-	users, err := svc.ListUsers(ctx, req)
+	page, err := svc.ListUsers(ctx, req)
 
 	assert.NoError(t, err)
-	assert.NotNil(t, users)
-	assert.Len(t, users, 2)
-	assert.Equal(t, mockUsers[0].Email, users[0].Email)
-	assert.Equal(t, mockUsers[1].Email, users[1].Email)
+	assert.NotNil(t, page)
+	assert.Len(t, page.Users, 2)
+	assert.Equal(t, mockUsers[0].Email, page.Users[0].Email)
+	assert.Equal(t, mockUsers[1].Email, page.Users[1].Email)
+	assert.Equal(t, int64(2), page.TotalCount)
+	assert.Empty(t, page.NextCursor)
+	assert.Empty(t, page.PrevCursor)
 	mockRepo.AssertExpectations(t)
 }
 
 func TestUserService_ListUsers_InvalidLimit(t *testing.T) {
 	mockRepo := new(repository.MockUserRepository)
 	mockHasher := new(MockPasswordHasher)
-	svc := service.NewUserService(mockRepo, mockHasher, createTestLogger())
+	svc := newTestService(testServiceDeps{repo: mockRepo, hasher: mockHasher})
 
 	tests := []struct {
 		name    string
@@ -568,108 +964,424 @@ func TestUserService_ListUsers_InvalidLimit(t *testing.T) {
 func TestUserService_ListUsers_EmptyResult(t *testing.T) {
 	mockRepo := new(repository.MockUserRepository)
 	mockHasher := new(MockPasswordHasher)
-	svc := service.NewUserService(mockRepo, mockHasher, createTestLogger())
+	svc := newTestService(testServiceDeps{repo: mockRepo, hasher: mockHasher})
 
 	mockRepo.On("ListUsers",
 		mock.Anything,
-		int32(10),
+		domain.UserFilter{},
+		domain.DefaultUserSort(),
+		int32(11),
 		int32(100),
 	).Return([]*domain.User{}, nil).Once()
+	mockRepo.On("CountUsers", mock.Anything, domain.UserFilter{}).Return(int64(5), false, nil).Once()
 
 	ctx := context.Background()
 	req := service.ListUsersRequest{
 		Limit:  10,
 		Offset: 100,
 	}
-	users, err := svc.ListUsers(ctx, req)
+	page, err := svc.ListUsers(ctx, req)
 
 	assert.NoError(t, err)
-	assert.NotNil(t, users)
-	assert.Len(t, users, 0)
+	assert.NotNil(t, page)
+	assert.Len(t, page.Users, 0)
+	assert.Equal(t, int64(5), page.TotalCount)
+	assert.Empty(t, page.NextCursor)
+	assert.Empty(t, page.PrevCursor)
 	mockRepo.AssertExpectations(t)
 }
 
-// ========== Password Hashing Tests ==========
-
-func TestPasswordHasher_Hash(t *testing.T) {
-	hasher := service.NewPasswordHasher(bcrypt.MinCost) // 高速なテスト用
+func TestUserService_ListUsers_Cursor(t *testing.T) {
+	codec := newTestCursorCodec()
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	// 新しい順に u0, u1, u2, u3
+	users := make([]*domain.User, 4)
+	for i := range users {
+		users[i] = &domain.User{
+			ID:        uuid.New(),
+			Email:     domain.Email(fmt.Sprintf("user%d@example.com", i)),
+			CreatedAt: base.Add(-time.Duration(i) * time.Minute),
+		}
+	}
 
 	tests := []struct {
-		name     string
-		password domain.Password
-		wantErr  bool
+		name      string
+		req       service.ListUsersRequest
+		mockSetup func(*repository.MockUserRepository)
+		wantIDs   []uuid.UUID
+		wantNext  *domain.UserCursor
+		wantPrev  *domain.UserCursor
+		wantErr   error
 	}{
 		{
-			name:     "正常系：通常のパスワード",
-			password: domain.Password("testPassword123"),
-			wantErr:  false,
+			name: "正常系：オフセット指定でも次のカーソルを返す",
+			req:  service.ListUsersRequest{Limit: 2},
+			mockSetup: func(m *repository.MockUserRepository) {
+				m.On("ListUsers", mock.Anything, domain.UserFilter{}, domain.DefaultUserSort(), int32(3), int32(0)).Return(users[:3], nil).Once()
+			},
+			wantIDs:  []uuid.UUID{users[0].ID, users[1].ID},
+			wantNext: &domain.UserCursor{CreatedAt: users[1].CreatedAt, ID: users[1].ID, Direction: domain.CursorNext},
 		},
 		{
-			name:     "正常系：長いパスワード",
-			password: domain.Password("ThisIsAVeryLongPasswordWith123Numbers!@#"),
-			wantErr:  false,
+			name: "正常系：次のページ",
+			req:  service.ListUsersRequest{Limit: 2, Cursor: codec.Encode(domain.NextUserCursor(users[0]))},
+			mockSetup: func(m *repository.MockUserRepository) {
+				m.On("ListUsersByCursor", mock.Anything, domain.UserFilter{}, domain.NextUserCursor(users[0]), int32(3)).Return(users[1:4], nil).Once()
+			},
+			wantIDs:  []uuid.UUID{users[1].ID, users[2].ID},
+			wantNext: &domain.UserCursor{CreatedAt: users[2].CreatedAt, ID: users[2].ID, Direction: domain.CursorNext},
+			wantPrev: &domain.UserCursor{CreatedAt: users[1].CreatedAt, ID: users[1].ID, Direction: domain.CursorPrev},
 		},
 		{
-			name:     "正常系：特殊文字を含むパスワード",
-			password: domain.Password("P@ssw0rd!#$%^&*()"),
-			wantErr:  false,
+			name: "正常系：最後のページには次のカーソルがない",
+			req:  service.ListUsersRequest{Limit: 2, Cursor: codec.Encode(domain.NextUserCursor(users[1]))},
+			mockSetup: func(m *repository.MockUserRepository) {
+				m.On("ListUsersByCursor", mock.Anything, domain.UserFilter{}, domain.NextUserCursor(users[1]), int32(3)).Return(users[2:4], nil).Once()
+			},
+			wantIDs:  []uuid.UUID{users[2].ID, users[3].ID},
+			wantPrev: &domain.UserCursor{CreatedAt: users[2].CreatedAt, ID: users[2].ID, Direction: domain.CursorPrev},
 		},
 		{
-			name:     "境界値：72バイト（bcryptの最大）",
-			password: domain.Password(string(make([]byte, 72, 72))),
-			wantErr:  false,
+			name: "正常系：前のページ",
+			req:  service.ListUsersRequest{Limit: 2, Cursor: codec.Encode(domain.PrevUserCursor(users[3]))},
+			mockSetup: func(m *repository.MockUserRepository) {
+				m.On("ListUsersByCursor", mock.Anything, domain.UserFilter{}, domain.PrevUserCursor(users[3]), int32(3)).Return(users[0:3], nil).Once()
+			},
+			wantIDs:  []uuid.UUID{users[1].ID, users[2].ID},
+			wantNext: &domain.UserCursor{CreatedAt: users[2].CreatedAt, ID: users[2].ID, Direction: domain.CursorNext},
+			wantPrev: &domain.UserCursor{CreatedAt: users[1].CreatedAt, ID: users[1].ID, Direction: domain.CursorPrev},
+		},
+		{
+			name: "正常系：最初のページまで戻ると前のカーソルがない",
+			req:  service.ListUsersRequest{Limit: 2, Cursor: codec.Encode(domain.PrevUserCursor(users[2]))},
+			mockSetup: func(m *repository.MockUserRepository) {
+				m.On("ListUsersByCursor", mock.Anything, domain.UserFilter{}, domain.PrevUserCursor(users[2]), int32(3)).Return(users[0:2], nil).Once()
+			},
+			wantIDs:  []uuid.UUID{users[0].ID, users[1].ID},
+			wantNext: &domain.UserCursor{CreatedAt: users[1].CreatedAt, ID: users[1].ID, Direction: domain.CursorNext},
+		},
+		{
+			name:      "異常系：改ざんされたカーソル",
+			req:       service.ListUsersRequest{Limit: 2, Cursor: codec.Encode(domain.NextUserCursor(users[0])) + "x"},
+			mockSetup: func(m *repository.MockUserRepository) {},
+			wantErr:   domain.ErrInvalidCursor,
+		},
+		{
+			name:      "異常系：カーソルとオフセットの併用",
+			req:       service.ListUsersRequest{Limit: 2, Offset: 2, Cursor: codec.Encode(domain.NextUserCursor(users[0]))},
+			mockSetup: func(m *repository.MockUserRepository) {},
+			wantErr:   domain.ErrInvalidCursor,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			hashed, err := hasher.Hash(tt.password)
+			mockRepo := new(repository.MockUserRepository)
+			tt.mockSetup(mockRepo)
+			mockRepo.On("CountUsers", mock.Anything, domain.UserFilter{}).Return(int64(len(users)), false, nil).Maybe()
+			svc := newTestService(testServiceDeps{repo: mockRepo, cursors: codec})
 
-			if tt.wantErr {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-				assert.NotEmpty(t, hashed)
-				assert.NotEqual(t, string(tt.password), hashed)
-				// bcryptハッシュの形式を確認
-				assert.True(t, len(hashed) > 50)
+			page, err := svc.ListUsers(context.Background(), tt.req)
+
+			mockRepo.AssertExpectations(t)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, page)
+				return
+			}
+			require.NoError(t, err)
+
+			gotIDs := make([]uuid.UUID, 0, len(page.Users))
+			for _, user := range page.Users {
+				gotIDs = append(gotIDs, user.ID)
 			}
+			assert.Equal(t, tt.wantIDs, gotIDs)
+			assertCursor(t, codec, tt.wantNext, page.NextCursor)
+			assertCursor(t, codec, tt.wantPrev, page.PrevCursor)
 		})
 	}
 }
 
-func TestPasswordHasher_Compare(t *testing.T) {
-	hasher := service.NewPasswordHasher(bcrypt.MinCost)
-
-	// 事前にハッシュを生成
-	password := "testPassword123"
-	hashedPassword, err := hasher.Hash(domain.Password(password))
-	assert.NoError(t, err)
+func TestUserService_ListUsers_FilterAndSort(t *testing.T) {
+	codec := newTestCursorCodec()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	errDatabase := errors.New("database error")
+	users := []*domain.User{
+		{ID: uuid.New(), Email: "alice@example.com", Name: "Alice", CreatedAt: from},
+		{ID: uuid.New(), Email: "bob@example.com", Name: "Bob", CreatedAt: from},
+	}
 
 	tests := []struct {
-		name           string
-		hashedPassword domain.Password
-		plainPassword  string
-		want           bool
+		name      string
+		req       service.ListUsersRequest
+		mockSetup func(*repository.MockUserRepository)
+		wantCount int64
+		wantEst   bool
+		wantNext  bool
+		wantErr   error
 	}{
 		{
-			name:           "正常系：正しいパスワード",
-			hashedPassword: domain.Password(hashedPassword),
-			plainPassword:  password,
-			want:           true,
+			name: "正常系：フィルタと並び順をリポジトリに渡す",
+			req:  service.ListUsersRequest{Limit: 1, SortBy: "name", SortOrder: "asc", EmailPrefix: "a", NamePrefix: "Al", CreatedFrom: &from, CreatedTo: &to},
+			mockSetup: func(m *repository.MockUserRepository) {
+				filter := domain.UserFilter{EmailPrefix: "a", NamePrefix: "Al", CreatedFrom: &from, CreatedTo: &to}
+				m.On("ListUsers", mock.Anything, filter, domain.UserSort{Field: domain.UserSortName, Order: domain.SortAsc}, int32(2), int32(0)).Return(users, nil).Once()
+				m.On("CountUsers", mock.Anything, filter).Return(int64(7), false, nil).Once()
+			},
+			wantCount: 7,
 		},
 		{
-			name:           "異常系：間違ったパスワード",
-			hashedPassword: domain.Password(hashedPassword),
-			plainPassword:  "wrongPassword",
-			want:           false,
+			name: "正常系：並び順の方向のみ指定すると作成日時で並べる",
+			req:  service.ListUsersRequest{Limit: 1, SortOrder: "asc"},
+			mockSetup: func(m *repository.MockUserRepository) {
+				m.On("ListUsers", mock.Anything, domain.UserFilter{}, domain.UserSort{Field: domain.UserSortCreatedAt, Order: domain.SortAsc}, int32(2), int32(0)).Return(users, nil).Once()
+				m.On("CountUsers", mock.Anything, domain.UserFilter{}).Return(int64(2), false, nil).Once()
+			},
+			wantCount: 2,
 		},
 		{
-			name:           "異常系：空のパスワード",
-			hashedPassword: domain.Password(hashedPassword),
-			plainPassword:  "",
-			want:           false,
-		},
+			name: "正常系：既定の並び順ではカーソルと概算件数を返す",
+			req:  service.ListUsersRequest{Limit: 1, SortBy: "created_at", SortOrder: "desc"},
+			mockSetup: func(m *repository.MockUserRepository) {
+				m.On("ListUsers", mock.Anything, domain.UserFilter{}, domain.DefaultUserSort(), int32(2), int32(0)).Return(users, nil).Once()
+				m.On("CountUsers", mock.Anything, domain.UserFilter{}).Return(int64(250000), true, nil).Once()
+			},
+			wantCount: 250000,
+			wantEst:   true,
+			wantNext:  true,
+		},
+		{
+			name:      "異常系：許可されていない並び替え項目",
+			req:       service.ListUsersRequest{Limit: 1, SortBy: "password"},
+			mockSetup: func(m *repository.MockUserRepository) {},
+			wantErr:   domain.ErrInvalidSort,
+		},
+		{
+			name:      "異常系：許可されていない並び順",
+			req:       service.ListUsersRequest{Limit: 1, SortOrder: "random"},
+			mockSetup: func(m *repository.MockUserRepository) {},
+			wantErr:   domain.ErrInvalidSort,
+		},
+		{
+			name:      "異常系：作成日時の範囲が逆転している",
+			req:       service.ListUsersRequest{Limit: 1, CreatedFrom: &to, CreatedTo: &from},
+			mockSetup: func(m *repository.MockUserRepository) {},
+			wantErr:   domain.ErrInvalidFilter,
+		},
+		{
+			name:      "異常系：既定以外の並び順ではカーソルを使えない",
+			req:       service.ListUsersRequest{Limit: 1, SortBy: "email", Cursor: codec.Encode(domain.NextUserCursor(users[0]))},
+			mockSetup: func(m *repository.MockUserRepository) {},
+			wantErr:   domain.ErrInvalidCursor,
+		},
+		{
+			name: "異常系：件数の取得に失敗",
+			req:  service.ListUsersRequest{Limit: 1},
+			mockSetup: func(m *repository.MockUserRepository) {
+				m.On("ListUsers", mock.Anything, domain.UserFilter{}, domain.DefaultUserSort(), int32(2), int32(0)).Return(users, nil).Once()
+				m.On("CountUsers", mock.Anything, domain.UserFilter{}).Return(int64(0), false, errDatabase).Once()
+			},
+			wantErr: errDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockUserRepository)
+			tt.mockSetup(mockRepo)
+			svc := newTestService(testServiceDeps{repo: mockRepo, cursors: codec})
+
+			page, err := svc.ListUsers(context.Background(), tt.req)
+
+			mockRepo.AssertExpectations(t)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, page)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, page.Users, 1)
+			assert.Equal(t, tt.wantCount, page.TotalCount)
+			assert.Equal(t, tt.wantEst, page.TotalCountEstimated)
+			assert.Equal(t, tt.wantNext, page.NextCursor != "")
+			assert.Empty(t, page.PrevCursor)
+		})
+	}
+}
+
+func TestUserService_SearchUsers(t *testing.T) {
+	alice := &domain.User{ID: uuid.New(), Email: "alice@example.com", Name: "Alice", Roles: []domain.Role{}}
+	errDatabase := errors.New("database error")
+
+	tests := []struct {
+		name      string
+		req       service.SearchUsersRequest
+		mockSetup func(*repository.MockUserRepository)
+		wantHits  int
+		wantCount int64
+		wantErr   error
+	}{
+		{
+			name: "正常系：前後の空白を除いて検索する",
+			req:  service.SearchUsersRequest{Query: "  alic ", Limit: 10},
+			mockSetup: func(m *repository.MockUserRepository) {
+				m.On("SearchUsers", mock.Anything, "alic", int32(10), int32(0)).Return([]*domain.UserSearchHit{{User: alice, Score: 0.5}}, nil).Once()
+				m.On("CountSearchUsers", mock.Anything, "alic").Return(int64(1), nil).Once()
+			},
+			wantHits:  1,
+			wantCount: 1,
+		},
+		{
+			name: "正常系：該当なし",
+			req:  service.SearchUsersRequest{Query: "zzz", Limit: 10, Offset: 20},
+			mockSetup: func(m *repository.MockUserRepository) {
+				m.On("SearchUsers", mock.Anything, "zzz", int32(10), int32(20)).Return([]*domain.UserSearchHit{}, nil).Once()
+				m.On("CountSearchUsers", mock.Anything, "zzz").Return(int64(0), nil).Once()
+			},
+		},
+		{
+			name:      "異常系：空の検索語",
+			req:       service.SearchUsersRequest{Query: " ", Limit: 10},
+			mockSetup: func(m *repository.MockUserRepository) {},
+			wantErr:   domain.ErrInvalidSearchQuery,
+		},
+		{
+			name:      "異常系：リミットが0",
+			req:       service.SearchUsersRequest{Query: "alice"},
+			mockSetup: func(m *repository.MockUserRepository) {},
+			wantErr:   domain.ErrInvalidLimit,
+		},
+		{
+			name: "異常系：リポジトリエラー",
+			req:  service.SearchUsersRequest{Query: "alice", Limit: 10},
+			mockSetup: func(m *repository.MockUserRepository) {
+				m.On("SearchUsers", mock.Anything, "alice", int32(10), int32(0)).Return(nil, errDatabase).Once()
+			},
+			wantErr: errDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockUserRepository)
+			tt.mockSetup(mockRepo)
+			svc := newTestService(testServiceDeps{repo: mockRepo})
+
+			result, err := svc.SearchUsers(context.Background(), tt.req)
+
+			mockRepo.AssertExpectations(t)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, result.Hits, tt.wantHits)
+			assert.Equal(t, tt.wantCount, result.TotalCount)
+			if tt.wantHits > 0 {
+				assert.Equal(t, alice.ID, result.Hits[0].User.ID)
+				assert.Equal(t, 0.5, result.Hits[0].Score)
+			}
+		})
+	}
+}
+
+// assertCursor decodes the cursor and compares it with want; a nil want expects no cursor
+func assertCursor(t *testing.T, codec service.CursorCodec, want *domain.UserCursor, got string) {
+	t.Helper()
+	if want == nil {
+		assert.Empty(t, got)
+		return
+	}
+	cursor, err := codec.Decode(got)
+	require.NoError(t, err)
+	assert.Equal(t, want.ID, cursor.ID)
+	assert.Equal(t, want.Direction, cursor.Direction)
+	assert.True(t, want.CreatedAt.Equal(cursor.CreatedAt))
+}
+
+// ========== Password Hashing Tests ==========
+
+func TestPasswordHasher_Hash(t *testing.T) {
+	hasher := service.NewPasswordHasher(bcrypt.MinCost) // 高速なテスト用
+
+	tests := []struct {
+		name     string
+		password domain.Password
+		wantErr  bool
+	}{
+		{
+			name:     "正常系：通常のパスワード",
+			password: domain.Password("testPassword123"),
+			wantErr:  false,
+		},
+		{
+			name:     "正常系：長いパスワード",
+			password: domain.Password("ThisIsAVeryLongPasswordWith123Numbers!@#"),
+			wantErr:  false,
+		},
+		{
+			name:     "正常系：特殊文字を含むパスワード",
+			password: domain.Password("P@ssw0rd!#$%^&*()"),
+			wantErr:  false,
+		},
+		{
+			name:     "境界値：72バイト（bcryptの最大）",
+			password: domain.Password(string(make([]byte, 72, 72))),
+			wantErr:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hashed, err := hasher.Hash(tt.password)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, hashed)
+				assert.NotEqual(t, string(tt.password), hashed)
+				// bcryptハッシュの形式を確認
+				assert.True(t, len(hashed) > 50)
+			}
+		})
+	}
+}
+
+func TestPasswordHasher_Compare(t *testing.T) {
+	hasher := service.NewPasswordHasher(bcrypt.MinCost)
+
+	// 事前にハッシュを生成
+	password := "testPassword123"
+	hashedPassword, err := hasher.Hash(domain.Password(password))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		hashedPassword domain.Password
+		plainPassword  string
+		want           bool
+	}{
+		{
+			name:           "正常系：正しいパスワード",
+			hashedPassword: domain.Password(hashedPassword),
+			plainPassword:  password,
+			want:           true,
+		},
+		{
+			name:           "異常系：間違ったパスワード",
+			hashedPassword: domain.Password(hashedPassword),
+			plainPassword:  "wrongPassword",
+			want:           false,
+		},
+		{
+			name:           "異常系：空のパスワード",
+			hashedPassword: domain.Password(hashedPassword),
+			plainPassword:  "",
+			want:           false,
+		},
 		{
 			name:           "異常系：無効なハッシュ",
 			hashedPassword: domain.Password("invalid_hash"),
@@ -689,7 +1401,7 @@ func TestPasswordHasher_Compare(t *testing.T) {
 func TestUserService_CreateUser_WithPasswordHashing(t *testing.T) {
 	mockRepo := new(repository.MockUserRepository)
 	mockHasher := new(MockPasswordHasher)
-	svc := service.NewUserService(mockRepo, mockHasher, createTestLogger())
+	svc := newTestService(testServiceDeps{repo: mockRepo, hasher: mockHasher})
 
 	// パスワードハッシュ化の期待値設定
 	plainPassword := "securePassword123"
@@ -729,7 +1441,7 @@ func TestUserService_CreateUser_WithPasswordHashing(t *testing.T) {
 func TestUserService_CreateUser_HashingError(t *testing.T) {
 	mockRepo := new(repository.MockUserRepository)
 	mockHasher := new(MockPasswordHasher)
-	svc := service.NewUserService(mockRepo, mockHasher, createTestLogger())
+	svc := newTestService(testServiceDeps{repo: mockRepo, hasher: mockHasher})
 
 	// ハッシュ化でエラーを返す
 	mockHasher.On("Hash", domain.Password("testPass123")).
@@ -756,8 +1468,11 @@ func TestUserService_CreateUser_HashingError(t *testing.T) {
 
 func TestUserService_AuthenticateUser_Success(t *testing.T) {
 	mockRepo := new(repository.MockUserRepository)
+	mockTokenRepo := new(repository.MockRefreshTokenRepository)
 	mockHasher := new(MockPasswordHasher)
-	svc := service.NewUserService(mockRepo, mockHasher, createTestLogger())
+	mockThrottleRepo := new(repository.MockLoginThrottleRepository)
+	issuer := newTestTokenIssuer()
+	svc := newTestService(testServiceDeps{repo: mockRepo, tokenRepo: mockTokenRepo, hasher: mockHasher, issuer: issuer, throttleRepo: mockThrottleRepo})
 
 	hashedPassword := "$2a$10$hashedPasswordExample"
 	existingUser := &domain.User{
@@ -777,23 +1492,50 @@ func TestUserService_AuthenticateUser_Success(t *testing.T) {
 		"correctPassword",
 	).Return(true).Once()
 
+	// ロックされておらず、成功時に失敗回数がリセットされること
+	mockThrottleRepo.On("Get", mock.Anything, domain.LoginThrottleScopeUser, existingUser.ID.String()).Return(nil, domain.ErrNotFound).Once()
+	mockThrottleRepo.On("Reset", mock.Anything, domain.LoginThrottleScopeUser, existingUser.ID.String()).Return(nil).Once()
+
+	// リフレッシュトークンはハッシュのみ保存されること
+	var savedToken *domain.RefreshToken
+	mockTokenRepo.On("Create",
+		mock.Anything,
+		mock.AnythingOfType("*domain.RefreshToken"),
+	).Run(func(args mock.Arguments) {
+		savedToken = args.Get(1).(*domain.RefreshToken)
+	}).Return(nil).Once()
+
 	ctx := context.Background()
 	req := service.AuthenticateUserRequest{
 		Email:    domain.Email("test@example.com"),
 		Password: domain.Password("correctPassword"),
 	}
 
-	err := svc.AuthenticateUser(ctx, req)
+	tokens, err := svc.AuthenticateUser(ctx, req)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tokens.AccessToken)
+	assert.NotEmpty(t, tokens.RefreshToken)
 
+	claims, err := issuer.ParseAccessToken(tokens.AccessToken)
 	assert.NoError(t, err)
+	assert.Equal(t, existingUser.ID, claims.UserID)
+
+	assert.Equal(t, existingUser.ID, savedToken.UserID)
+	assert.Equal(t, issuer.HashRefreshToken(tokens.RefreshToken), savedToken.TokenHash)
+	assert.NotEqual(t, tokens.RefreshToken, savedToken.TokenHash)
+
 	mockRepo.AssertExpectations(t)
+	mockTokenRepo.AssertExpectations(t)
 	mockHasher.AssertExpectations(t)
+	mockThrottleRepo.AssertExpectations(t)
 }
 
 func TestUserService_AuthenticateUser_InvalidPassword(t *testing.T) {
 	mockRepo := new(repository.MockUserRepository)
 	mockHasher := new(MockPasswordHasher)
-	svc := service.NewUserService(mockRepo, mockHasher, createTestLogger())
+	mockThrottleRepo := new(repository.MockLoginThrottleRepository)
+	svc := newTestService(testServiceDeps{repo: mockRepo, hasher: mockHasher, throttleRepo: mockThrottleRepo})
 
 	hashedPassword := "$2a$10$hashedPasswordExample"
 	existingUser := &domain.User{
@@ -813,29 +1555,37 @@ func TestUserService_AuthenticateUser_InvalidPassword(t *testing.T) {
 		"wrongPassword",
 	).Return(false).Once()
 
+	// 失敗回数が記録されること
+	mockThrottleRepo.On("Get", mock.Anything, domain.LoginThrottleScopeUser, existingUser.ID.String()).Return(nil, domain.ErrNotFound).Once()
+	mockThrottleRepo.On("RecordFailure", mock.Anything, domain.LoginThrottleScopeUser, existingUser.ID.String(), 15*time.Minute).
+		Return(&domain.LoginThrottle{Scope: domain.LoginThrottleScopeUser, Subject: existingUser.ID.String(), Failures: 1}, nil).Once()
+
 	ctx := context.Background()
 	req := service.AuthenticateUserRequest{
 		Email:    domain.Email("test@example.com"),
 		Password: domain.Password("wrongPassword"),
 	}
 
-	err := svc.AuthenticateUser(ctx, req)
+	_, err := svc.AuthenticateUser(ctx, req)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid credentials")
 	mockRepo.AssertExpectations(t)
 	mockHasher.AssertExpectations(t)
+	mockThrottleRepo.AssertExpectations(t)
 }
 
 func TestUserService_AuthenticateUser_UserNotFound(t *testing.T) {
 	mockRepo := new(repository.MockUserRepository)
 	mockHasher := new(MockPasswordHasher)
-	svc := service.NewUserService(mockRepo, mockHasher, createTestLogger())
+	svc := newTestService(testServiceDeps{repo: mockRepo, hasher: mockHasher})
 
 	mockRepo.On("GetByEmail",
 		mock.Anything,
 		domain.Email("nonexistent@example.com"),
 	).Return(nil, errors.New("user not found")).Once()
+	// 応答時間で存在有無を推測されないよう、ダミーのハッシュと照合する
+	mockHasher.On("Compare", mock.Anything, "password").Return(false).Once()
 
 	ctx := context.Background()
 	req := service.AuthenticateUserRequest{
@@ -843,12 +1593,182 @@ func TestUserService_AuthenticateUser_UserNotFound(t *testing.T) {
 		Password: domain.Password("password"),
 	}
 
-	err := svc.AuthenticateUser(ctx, req)
+	_, err := svc.AuthenticateUser(ctx, req)
 
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
 	mockRepo.AssertExpectations(t)
-	// Compareが呼ばれていないことを確認
-	mockHasher.AssertNotCalled(t, "Compare")
+	mockHasher.AssertExpectations(t)
+}
+
+func TestUserService_AuthenticateUser_Lockout(t *testing.T) {
+	userID := uuid.New()
+	userKey := userID.String()
+	clientIP := "192.0.2.1"
+	lockedUntil := time.Now().Add(10 * time.Minute)
+	expiredLock := time.Now().Add(-time.Minute)
+	existingUser := &domain.User{
+		ID:       userID,
+		Email:    domain.Email("test@example.com"),
+		Password: domain.Password("hashed"),
+		Name:     domain.Name("Test User"),
+	}
+
+	tests := []struct {
+		name      string
+		email     domain.Email
+		password  domain.Password
+		mockSetup func(*repository.MockUserRepository, *repository.MockLoginThrottleRepository, *MockPasswordHasher)
+		wantErr   error
+	}{
+		{
+			name:     "異常系：ロック中のアカウントはパスワードを検証しない",
+			email:    "test@example.com",
+			password: "correctPassword",
+			mockSetup: func(r *repository.MockUserRepository, tr *repository.MockLoginThrottleRepository, h *MockPasswordHasher) {
+				tr.On("Get", mock.Anything, domain.LoginThrottleScopeIP, clientIP).Return(nil, domain.ErrNotFound).Once()
+				r.On("GetByEmail", mock.Anything, domain.Email("test@example.com")).Return(existingUser, nil).Once()
+				tr.On("Get", mock.Anything, domain.LoginThrottleScopeUser, userKey).
+					Return(&domain.LoginThrottle{Scope: domain.LoginThrottleScopeUser, Subject: userKey, LockedUntil: &lockedUntil}, nil).Once()
+			},
+			wantErr: domain.ErrAccountLocked,
+		},
+		{
+			name:     "異常系：ブロック中のIP",
+			email:    "test@example.com",
+			password: "correctPassword",
+			mockSetup: func(r *repository.MockUserRepository, tr *repository.MockLoginThrottleRepository, h *MockPasswordHasher) {
+				tr.On("Get", mock.Anything, domain.LoginThrottleScopeIP, clientIP).
+					Return(&domain.LoginThrottle{Scope: domain.LoginThrottleScopeIP, Subject: clientIP, LockedUntil: &lockedUntil}, nil).Once()
+			},
+			wantErr: domain.ErrTooManyAttempts,
+		},
+		{
+			name:     "異常系：しきい値に達するとアカウントをロック",
+			email:    "test@example.com",
+			password: "wrongPassword",
+			mockSetup: func(r *repository.MockUserRepository, tr *repository.MockLoginThrottleRepository, h *MockPasswordHasher) {
+				tr.On("Get", mock.Anything, domain.LoginThrottleScopeIP, clientIP).Return(nil, domain.ErrNotFound).Once()
+				r.On("GetByEmail", mock.Anything, domain.Email("test@example.com")).Return(existingUser, nil).Once()
+				tr.On("Get", mock.Anything, domain.LoginThrottleScopeUser, userKey).Return(nil, domain.ErrNotFound).Once()
+				h.On("Compare", domain.Password("hashed"), "wrongPassword").Return(false).Once()
+				tr.On("RecordFailure", mock.Anything, domain.LoginThrottleScopeIP, clientIP, 15*time.Minute).
+					Return(&domain.LoginThrottle{Scope: domain.LoginThrottleScopeIP, Subject: clientIP, Failures: 5}, nil).Once()
+				tr.On("RecordFailure", mock.Anything, domain.LoginThrottleScopeUser, userKey, 15*time.Minute).
+					Return(&domain.LoginThrottle{Scope: domain.LoginThrottleScopeUser, Subject: userKey, Failures: 5}, nil).Once()
+				tr.On("Lock", mock.Anything, domain.LoginThrottleScopeUser, userKey, 15*time.Minute).Return(nil).Once()
+			},
+			wantErr: domain.ErrAccountLocked,
+		},
+		{
+			name:     "異常系：存在しないメールアドレスでもIPの失敗を記録",
+			email:    "unknown@example.com",
+			password: "password",
+			mockSetup: func(r *repository.MockUserRepository, tr *repository.MockLoginThrottleRepository, h *MockPasswordHasher) {
+				tr.On("Get", mock.Anything, domain.LoginThrottleScopeIP, clientIP).Return(nil, domain.ErrNotFound).Once()
+				r.On("GetByEmail", mock.Anything, domain.Email("unknown@example.com")).Return(nil, domain.ErrUserNotFound).Once()
+				h.On("Compare", mock.Anything, "password").Return(false).Once()
+				tr.On("RecordFailure", mock.Anything, domain.LoginThrottleScopeIP, clientIP, 15*time.Minute).
+					Return(&domain.LoginThrottle{Scope: domain.LoginThrottleScopeIP, Subject: clientIP, Failures: 20}, nil).Once()
+				tr.On("Lock", mock.Anything, domain.LoginThrottleScopeIP, clientIP, 15*time.Minute).Return(nil).Once()
+			},
+			wantErr: domain.ErrInvalidCredentials,
+		},
+		{
+			name:     "正常系：クールダウン経過後はログインできる",
+			email:    "test@example.com",
+			password: "correctPassword",
+			mockSetup: func(r *repository.MockUserRepository, tr *repository.MockLoginThrottleRepository, h *MockPasswordHasher) {
+				tr.On("Get", mock.Anything, domain.LoginThrottleScopeIP, clientIP).Return(nil, domain.ErrNotFound).Once()
+				r.On("GetByEmail", mock.Anything, domain.Email("test@example.com")).Return(existingUser, nil).Once()
+				tr.On("Get", mock.Anything, domain.LoginThrottleScopeUser, userKey).
+					Return(&domain.LoginThrottle{Scope: domain.LoginThrottleScopeUser, Subject: userKey, LockedUntil: &expiredLock}, nil).Once()
+				h.On("Compare", domain.Password("hashed"), "correctPassword").Return(true).Once()
+				tr.On("Reset", mock.Anything, domain.LoginThrottleScopeUser, userKey).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockUserRepository)
+			mockTokenRepo := new(repository.MockRefreshTokenRepository)
+			mockThrottleRepo := new(repository.MockLoginThrottleRepository)
+			mockHasher := new(MockPasswordHasher)
+			tt.mockSetup(mockRepo, mockThrottleRepo, mockHasher)
+			mockTokenRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+			svc := newTestService(testServiceDeps{repo: mockRepo, tokenRepo: mockTokenRepo, hasher: mockHasher, throttleRepo: mockThrottleRepo})
+
+			tokens, err := svc.AuthenticateUser(context.Background(), service.AuthenticateUserRequest{
+				Email:    tt.email,
+				Password: tt.password,
+				ClientIP: clientIP,
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, tokens)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, tokens.AccessToken)
+			}
+			mockRepo.AssertExpectations(t)
+			mockThrottleRepo.AssertExpectations(t)
+			mockHasher.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_UnlockUser(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		id        uuid.UUID
+		mockSetup func(*repository.MockUserRepository, *repository.MockLoginThrottleRepository)
+		wantErr   error
+	}{
+		{
+			name: "正常系：ロックを解除",
+			id:   userID,
+			mockSetup: func(r *repository.MockUserRepository, tr *repository.MockLoginThrottleRepository) {
+				r.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID}, nil).Once()
+				tr.On("Reset", mock.Anything, domain.LoginThrottleScopeUser, userID.String()).Return(nil).Once()
+			},
+		},
+		{
+			name: "異常系：ユーザーが存在しない",
+			id:   userID,
+			mockSetup: func(r *repository.MockUserRepository, tr *repository.MockLoginThrottleRepository) {
+				r.On("GetByID", mock.Anything, userID).Return(nil, domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name:      "異常系：IDが空",
+			id:        uuid.Nil,
+			mockSetup: func(r *repository.MockUserRepository, tr *repository.MockLoginThrottleRepository) {},
+			wantErr:   domain.ErrInvalidID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockUserRepository)
+			mockThrottleRepo := new(repository.MockLoginThrottleRepository)
+			tt.mockSetup(mockRepo, mockThrottleRepo)
+			svc := newTestService(testServiceDeps{repo: mockRepo, throttleRepo: mockThrottleRepo})
+
+			err := svc.UnlockUser(context.Background(), service.UnlockUserRequest{ID: tt.id})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+			mockThrottleRepo.AssertExpectations(t)
+		})
+	}
 }
 
 func TestPasswordHasher_UniquenessOfHashes(t *testing.T) {
@@ -867,3 +1787,802 @@ func TestPasswordHasher_UniquenessOfHashes(t *testing.T) {
 	assert.True(t, hasher.Compare(domain.Password(hash1), string(password)))
 	assert.True(t, hasher.Compare(domain.Password(hash2), string(password)))
 }
+
+// ========== Token Tests ==========
+
+func TestUserService_RefreshToken(t *testing.T) {
+	issuer := newTestTokenIssuer()
+	userID := uuid.New()
+	plainToken := "plain-refresh-token"
+	tokenHash := issuer.HashRefreshToken(plainToken)
+	revokedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name      string
+		mockSetup func(*repository.MockUserRepository, *repository.MockRefreshTokenRepository)
+		wantErr   error
+	}{
+		{
+			name: "正常系：トークンをローテーションして再発行",
+			mockSetup: func(r *repository.MockUserRepository, m *repository.MockRefreshTokenRepository) {
+				token := domain.NewRefreshToken(userID, tokenHash, time.Now().Add(time.Hour))
+				m.On("GetByHash", mock.Anything, tokenHash).Return(token, nil).Once()
+				m.On("Revoke", mock.Anything, token.ID).Return(nil).Once()
+				r.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID, Roles: []domain.Role{domain.RoleSupport}}, nil).Once()
+				m.On("Create", mock.Anything, mock.MatchedBy(func(t *domain.RefreshToken) bool {
+					return t.UserID == userID && t.TokenHash != tokenHash
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name: "異常系：存在しないトークン",
+			mockSetup: func(r *repository.MockUserRepository, m *repository.MockRefreshTokenRepository) {
+				m.On("GetByHash", mock.Anything, tokenHash).Return(nil, domain.ErrNotFound).Once()
+			},
+			wantErr: domain.ErrInvalidToken,
+		},
+		{
+			name: "異常系：有効期限切れ",
+			mockSetup: func(r *repository.MockUserRepository, m *repository.MockRefreshTokenRepository) {
+				token := domain.NewRefreshToken(userID, tokenHash, time.Now().Add(-time.Second))
+				m.On("GetByHash", mock.Anything, tokenHash).Return(token, nil).Once()
+			},
+			wantErr: domain.ErrInvalidToken,
+		},
+		{
+			name: "異常系：失効済みトークンの再利用で全トークンを失効",
+			mockSetup: func(r *repository.MockUserRepository, m *repository.MockRefreshTokenRepository) {
+				token := domain.NewRefreshToken(userID, tokenHash, time.Now().Add(time.Hour))
+				token.RevokedAt = &revokedAt
+				m.On("GetByHash", mock.Anything, tokenHash).Return(token, nil).Once()
+				m.On("RevokeAllForUser", mock.Anything, userID).Return(nil).Once()
+			},
+			wantErr: domain.ErrInvalidToken,
+		},
+		{
+			name: "異常系：ユーザーが削除済み",
+			mockSetup: func(r *repository.MockUserRepository, m *repository.MockRefreshTokenRepository) {
+				token := domain.NewRefreshToken(userID, tokenHash, time.Now().Add(time.Hour))
+				m.On("GetByHash", mock.Anything, tokenHash).Return(token, nil).Once()
+				m.On("Revoke", mock.Anything, token.ID).Return(nil).Once()
+				r.On("GetByID", mock.Anything, userID).Return(nil, domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrInvalidToken,
+		},
+		{
+			name: "異常系：同時リフレッシュで先に失効済み",
+			mockSetup: func(r *repository.MockUserRepository, m *repository.MockRefreshTokenRepository) {
+				token := domain.NewRefreshToken(userID, tokenHash, time.Now().Add(time.Hour))
+				m.On("GetByHash", mock.Anything, tokenHash).Return(token, nil).Once()
+				m.On("Revoke", mock.Anything, token.ID).Return(domain.ErrNotFound).Once()
+			},
+			wantErr: domain.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockUserRepository)
+			mockTokenRepo := new(repository.MockRefreshTokenRepository)
+			tt.mockSetup(mockRepo, mockTokenRepo)
+			svc := newTestService(testServiceDeps{repo: mockRepo, tokenRepo: mockTokenRepo, issuer: issuer})
+
+			tokens, err := svc.RefreshToken(context.Background(), service.RefreshTokenRequest{RefreshToken: plainToken})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, tokens)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, tokens.AccessToken)
+				assert.NotEqual(t, plainToken, tokens.RefreshToken)
+
+				// 最新のロールがアクセストークンに含まれる
+				claims, err := issuer.ParseAccessToken(tokens.AccessToken)
+				require.NoError(t, err)
+				assert.Equal(t, []domain.Role{domain.RoleSupport}, claims.Roles)
+			}
+			mockRepo.AssertExpectations(t)
+			mockTokenRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_Logout(t *testing.T) {
+	issuer := newTestTokenIssuer()
+	plainToken := "plain-refresh-token"
+	tokenHash := issuer.HashRefreshToken(plainToken)
+
+	tests := []struct {
+		name      string
+		mockSetup func(*repository.MockRefreshTokenRepository)
+	}{
+		{
+			name: "正常系：トークンを失効",
+			mockSetup: func(m *repository.MockRefreshTokenRepository) {
+				token := domain.NewRefreshToken(uuid.New(), tokenHash, time.Now().Add(time.Hour))
+				m.On("GetByHash", mock.Anything, tokenHash).Return(token, nil).Once()
+				m.On("Revoke", mock.Anything, token.ID).Return(nil).Once()
+			},
+		},
+		{
+			name: "正常系：存在しないトークンは無視",
+			mockSetup: func(m *repository.MockRefreshTokenRepository) {
+				m.On("GetByHash", mock.Anything, tokenHash).Return(nil, domain.ErrNotFound).Once()
+			},
+		},
+		{
+			name: "正常系：失効済みトークンは無視",
+			mockSetup: func(m *repository.MockRefreshTokenRepository) {
+				token := domain.NewRefreshToken(uuid.New(), tokenHash, time.Now().Add(time.Hour))
+				m.On("GetByHash", mock.Anything, tokenHash).Return(token, nil).Once()
+				m.On("Revoke", mock.Anything, token.ID).Return(domain.ErrNotFound).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTokenRepo := new(repository.MockRefreshTokenRepository)
+			tt.mockSetup(mockTokenRepo)
+			svc := newTestService(testServiceDeps{tokenRepo: mockTokenRepo, issuer: issuer})
+
+			err := svc.Logout(context.Background(), service.LogoutRequest{RefreshToken: plainToken})
+
+			assert.NoError(t, err)
+			mockTokenRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_ChangePassword(t *testing.T) {
+	userID := uuid.New()
+	// パスワード更新でユーザーが書き換わるため、ケースごとに新しいユーザーを返す
+	existingUser := func() *domain.User {
+		return &domain.User{
+			ID:       userID,
+			Email:    domain.Email("test@example.com"),
+			Password: domain.Password("old_hash"),
+			Name:     domain.Name("Test User"),
+		}
+	}
+
+	tests := []struct {
+		name      string
+		req       service.ChangePasswordRequest
+		mockSetup func(*repository.MockUserRepository, *repository.MockRefreshTokenRepository, *MockPasswordHasher)
+		wantErr   error
+	}{
+		{
+			name: "正常系：パスワード変更で全トークンを失効",
+			req:  service.ChangePasswordRequest{ID: userID, CurrentPassword: "oldPassword", NewPassword: "newPassword123"},
+			mockSetup: func(r *repository.MockUserRepository, tr *repository.MockRefreshTokenRepository, h *MockPasswordHasher) {
+				r.On("GetByID", mock.Anything, userID).Return(existingUser(), nil).Once()
+				h.On("Compare", domain.Password("old_hash"), "oldPassword").Return(true).Once()
+				h.On("Hash", domain.Password("newPassword123")).Return("new_hash", nil).Once()
+				r.On("UpdatePassword", mock.Anything, userID, domain.Password("new_hash")).Return(nil).Once()
+				tr.On("RevokeAllForUser", mock.Anything, userID).Return(nil).Once()
+			},
+		},
+		{
+			name: "異常系：現在のパスワードが誤り",
+			req:  service.ChangePasswordRequest{ID: userID, CurrentPassword: "wrong", NewPassword: "newPassword123"},
+			mockSetup: func(r *repository.MockUserRepository, tr *repository.MockRefreshTokenRepository, h *MockPasswordHasher) {
+				r.On("GetByID", mock.Anything, userID).Return(existingUser(), nil).Once()
+				h.On("Compare", domain.Password("old_hash"), "wrong").Return(false).Once()
+			},
+			wantErr: domain.ErrInvalidCredentials,
+		},
+		{
+			name: "異常系：新しいパスワードが短い",
+			req:  service.ChangePasswordRequest{ID: userID, CurrentPassword: "oldPassword", NewPassword: "short"},
+			mockSetup: func(r *repository.MockUserRepository, tr *repository.MockRefreshTokenRepository, h *MockPasswordHasher) {
+			},
+			wantErr: domain.ErrInvalidPassword,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockUserRepository)
+			mockTokenRepo := new(repository.MockRefreshTokenRepository)
+			mockHasher := new(MockPasswordHasher)
+			tt.mockSetup(mockRepo, mockTokenRepo, mockHasher)
+			svc := newTestService(testServiceDeps{repo: mockRepo, tokenRepo: mockTokenRepo, hasher: mockHasher})
+
+			err := svc.ChangePassword(context.Background(), tt.req)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+			mockTokenRepo.AssertExpectations(t)
+			mockHasher.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_RequestPasswordReset(t *testing.T) {
+	userID := uuid.New()
+	existingUser := &domain.User{ID: userID, Email: domain.Email("test@example.com")}
+
+	tests := []struct {
+		name      string
+		email     domain.Email
+		mockSetup func(*repository.MockUserRepository, *repository.MockPasswordResetTokenRepository, *MockNotifier)
+		wantErr   error
+	}{
+		{
+			name:  "正常系：トークンを発行して通知",
+			email: "test@example.com",
+			mockSetup: func(r *repository.MockUserRepository, rr *repository.MockPasswordResetTokenRepository, n *MockNotifier) {
+				r.On("GetByEmail", mock.Anything, domain.Email("test@example.com")).Return(existingUser, nil).Once()
+				rr.On("InvalidateAllForUser", mock.Anything, userID).Return(nil).Once()
+				rr.On("Create", mock.Anything, mock.MatchedBy(func(t *domain.PasswordResetToken) bool {
+					return t.UserID == userID && t.TokenHash != ""
+				})).Return(nil).Once()
+				n.On("SendPasswordReset", mock.Anything, existingUser, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(nil).Once()
+			},
+		},
+		{
+			name:  "正常系：存在しないメールアドレスでも成功を返す",
+			email: "unknown@example.com",
+			mockSetup: func(r *repository.MockUserRepository, rr *repository.MockPasswordResetTokenRepository, n *MockNotifier) {
+				r.On("GetByEmail", mock.Anything, domain.Email("unknown@example.com")).Return(nil, domain.ErrUserNotFound).Once()
+			},
+		},
+		{
+			name:  "異常系：通知に失敗",
+			email: "test@example.com",
+			mockSetup: func(r *repository.MockUserRepository, rr *repository.MockPasswordResetTokenRepository, n *MockNotifier) {
+				r.On("GetByEmail", mock.Anything, domain.Email("test@example.com")).Return(existingUser, nil).Once()
+				rr.On("InvalidateAllForUser", mock.Anything, userID).Return(nil).Once()
+				rr.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+				n.On("SendPasswordReset", mock.Anything, existingUser, mock.Anything, mock.Anything).Return(errors.New("smtp down")).Once()
+			},
+			wantErr: errors.New("failed to send password reset: smtp down"),
+		},
+		{
+			name:  "異常系：不正なメールアドレス",
+			email: "invalid",
+			mockSetup: func(r *repository.MockUserRepository, rr *repository.MockPasswordResetTokenRepository, n *MockNotifier) {
+			},
+			wantErr: domain.ErrInvalidEmail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockUserRepository)
+			mockResetRepo := new(repository.MockPasswordResetTokenRepository)
+			mockNotifier := new(MockNotifier)
+			tt.mockSetup(mockRepo, mockResetRepo, mockNotifier)
+			svc := newTestService(testServiceDeps{repo: mockRepo, resetRepo: mockResetRepo, notifier: mockNotifier})
+
+			err := svc.RequestPasswordReset(context.Background(), service.RequestPasswordResetRequest{Email: tt.email})
+
+			switch {
+			case tt.wantErr == nil:
+				assert.NoError(t, err)
+			case errors.Is(tt.wantErr, domain.ErrInvalidEmail):
+				assert.ErrorIs(t, err, tt.wantErr)
+			default:
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+			mockRepo.AssertExpectations(t)
+			mockResetRepo.AssertExpectations(t)
+			mockNotifier.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_ConfirmPasswordReset(t *testing.T) {
+	issuer := newTestTokenIssuer()
+	userID := uuid.New()
+	plainToken := "plain-reset-token"
+	tokenHash := issuer.HashPasswordResetToken(plainToken)
+	usedAt := time.Now().Add(-time.Minute)
+	existingUser := &domain.User{ID: userID, Email: domain.Email("test@example.com"), Password: domain.Password("old_hash")}
+
+	tests := []struct {
+		name      string
+		password  domain.Password
+		mockSetup func(*repository.MockUserRepository, *repository.MockRefreshTokenRepository, *repository.MockPasswordResetTokenRepository, *MockPasswordHasher)
+		wantErr   error
+	}{
+		{
+			name:     "正常系：パスワードを再設定して全トークンを失効",
+			password: "newPassword123",
+			mockSetup: func(r *repository.MockUserRepository, tr *repository.MockRefreshTokenRepository, rr *repository.MockPasswordResetTokenRepository, h *MockPasswordHasher) {
+				token := domain.NewPasswordResetToken(userID, tokenHash, time.Now().Add(time.Hour))
+				rr.On("GetByHash", mock.Anything, tokenHash).Return(token, nil).Once()
+				rr.On("MarkUsed", mock.Anything, token.ID).Return(nil).Once()
+				r.On("GetByID", mock.Anything, userID).Return(existingUser, nil).Once()
+				h.On("Hash", domain.Password("newPassword123")).Return("new_hash", nil).Once()
+				r.On("UpdatePassword", mock.Anything, userID, domain.Password("new_hash")).Return(nil).Once()
+				tr.On("RevokeAllForUser", mock.Anything, userID).Return(nil).Once()
+			},
+		},
+		{
+			name:     "異常系：存在しないトークン",
+			password: "newPassword123",
+			mockSetup: func(r *repository.MockUserRepository, tr *repository.MockRefreshTokenRepository, rr *repository.MockPasswordResetTokenRepository, h *MockPasswordHasher) {
+				rr.On("GetByHash", mock.Anything, tokenHash).Return(nil, domain.ErrNotFound).Once()
+			},
+			wantErr: domain.ErrInvalidToken,
+		},
+		{
+			name:     "異常系：有効期限切れ",
+			password: "newPassword123",
+			mockSetup: func(r *repository.MockUserRepository, tr *repository.MockRefreshTokenRepository, rr *repository.MockPasswordResetTokenRepository, h *MockPasswordHasher) {
+				token := domain.NewPasswordResetToken(userID, tokenHash, time.Now().Add(-time.Second))
+				rr.On("GetByHash", mock.Anything, tokenHash).Return(token, nil).Once()
+			},
+			wantErr: domain.ErrInvalidToken,
+		},
+		{
+			name:     "異常系：使用済みトークン",
+			password: "newPassword123",
+			mockSetup: func(r *repository.MockUserRepository, tr *repository.MockRefreshTokenRepository, rr *repository.MockPasswordResetTokenRepository, h *MockPasswordHasher) {
+				token := domain.NewPasswordResetToken(userID, tokenHash, time.Now().Add(time.Hour))
+				token.UsedAt = &usedAt
+				rr.On("GetByHash", mock.Anything, tokenHash).Return(token, nil).Once()
+			},
+			wantErr: domain.ErrInvalidToken,
+		},
+		{
+			name:     "異常系：同時に使用済み",
+			password: "newPassword123",
+			mockSetup: func(r *repository.MockUserRepository, tr *repository.MockRefreshTokenRepository, rr *repository.MockPasswordResetTokenRepository, h *MockPasswordHasher) {
+				token := domain.NewPasswordResetToken(userID, tokenHash, time.Now().Add(time.Hour))
+				rr.On("GetByHash", mock.Anything, tokenHash).Return(token, nil).Once()
+				rr.On("MarkUsed", mock.Anything, token.ID).Return(domain.ErrNotFound).Once()
+			},
+			wantErr: domain.ErrInvalidToken,
+		},
+		{
+			name:     "異常系：新しいパスワードが短い",
+			password: "short",
+			mockSetup: func(r *repository.MockUserRepository, tr *repository.MockRefreshTokenRepository, rr *repository.MockPasswordResetTokenRepository, h *MockPasswordHasher) {
+			},
+			wantErr: domain.ErrInvalidPassword,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockUserRepository)
+			mockTokenRepo := new(repository.MockRefreshTokenRepository)
+			mockResetRepo := new(repository.MockPasswordResetTokenRepository)
+			mockHasher := new(MockPasswordHasher)
+			tt.mockSetup(mockRepo, mockTokenRepo, mockResetRepo, mockHasher)
+			svc := newTestService(testServiceDeps{repo: mockRepo, tokenRepo: mockTokenRepo, resetRepo: mockResetRepo, hasher: mockHasher, issuer: issuer})
+
+			err := svc.ConfirmPasswordReset(context.Background(), service.ConfirmPasswordResetRequest{Token: plainToken, NewPassword: tt.password})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+			mockTokenRepo.AssertExpectations(t)
+			mockResetRepo.AssertExpectations(t)
+			mockHasher.AssertExpectations(t)
+		})
+	}
+}
+
+// ========== Email Verification Tests ==========
+
+func TestUserService_CreateUser_SendsEmailVerification(t *testing.T) {
+	mockRepo := new(repository.MockUserRepository)
+	mockHasher := new(MockPasswordHasher)
+	verifyRepo := new(repository.MockEmailVerificationTokenRepository)
+	notifier := new(MockNotifier)
+	issuer := newTestTokenIssuer()
+	verifier := service.NewEmailVerifier(verifyRepo, issuer, notifier, service.DefaultEmailVerificationConfig(), createTestLogger())
+	svc := newTestService(testServiceDeps{repo: mockRepo, hasher: mockHasher, issuer: issuer, verifier: verifier})
+
+	mockHasher.On("Hash", domain.Password("password123")).Return("hashed", nil).Once()
+	mockRepo.On("GetByEmail", mock.Anything, domain.Email("new@example.com")).Return(nil, domain.ErrUserNotFound).Once()
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil).Once()
+
+	// 登録したメールアドレス宛てにトークンが発行され、ハッシュのみ保存されること
+	var savedToken *domain.EmailVerificationToken
+	verifyRepo.On("CountIssuedSince", mock.Anything, mock.Anything, mock.Anything).Return(0, nil).Once()
+	verifyRepo.On("InvalidateAllForUser", mock.Anything, mock.Anything).Return(nil).Once()
+	verifyRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.EmailVerificationToken")).Run(func(args mock.Arguments) {
+		savedToken = args.Get(1).(*domain.EmailVerificationToken)
+	}).Return(nil).Once()
+	var sentToken string
+	notifier.On("SendEmailVerification", mock.Anything, mock.AnythingOfType("*domain.User"), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		sentToken = args.String(2)
+	}).Return(nil).Once()
+
+	user, err := svc.CreateUser(context.Background(), service.CreateUserRequest{
+		Email:    domain.Email("new@example.com"),
+		Name:     domain.Name("New User"),
+		Password: domain.Password("password123"),
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, user.EmailVerifiedAt)
+	require.NotNil(t, savedToken)
+	assert.Equal(t, user.ID, savedToken.UserID)
+	assert.Equal(t, domain.Email("new@example.com"), savedToken.Email)
+	assert.Equal(t, issuer.HashEmailVerificationToken(sentToken), savedToken.TokenHash)
+	mockRepo.AssertExpectations(t)
+	verifyRepo.AssertExpectations(t)
+	notifier.AssertExpectations(t)
+}
+
+func TestUserService_UpdateUser_EmailVerification(t *testing.T) {
+	verifiedAt := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name         string
+		email        domain.Email
+		wantVerified bool
+		wantSend     bool
+	}{
+		{name: "正常系：別のメールアドレスへの変更は再確認する", email: "changed@example.com", wantSend: true},
+		{name: "正常系：大文字小文字のみの変更は確認済みのまま", email: "Verified@example.com", wantVerified: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockUserRepository)
+			verifyRepo := new(repository.MockEmailVerificationTokenRepository)
+			notifier := new(MockNotifier)
+			verifier := service.NewEmailVerifier(verifyRepo, newTestTokenIssuer(), notifier, service.DefaultEmailVerificationConfig(), createTestLogger())
+			svc := newTestService(testServiceDeps{repo: mockRepo, verifier: verifier})
+
+			existing := &domain.User{ID: uuid.New(), Email: "verified@example.com", Name: "Verified User", Password: "password123", EmailVerifiedAt: &verifiedAt}
+			mockRepo.On("GetByID", mock.Anything, existing.ID).Return(existing, nil).Once()
+			mockRepo.On("Update", mock.Anything, existing).Return(nil).Once()
+			if tt.wantSend {
+				verifyRepo.On("CountIssuedSince", mock.Anything, existing.ID, mock.Anything).Return(0, nil).Once()
+				verifyRepo.On("InvalidateAllForUser", mock.Anything, existing.ID).Return(nil).Once()
+				verifyRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.EmailVerificationToken")).Return(nil).Once()
+				notifier.On("SendEmailVerification", mock.Anything, existing, mock.Anything, mock.Anything).Return(nil).Once()
+			}
+
+			err := svc.UpdateUser(context.Background(), service.UpdateUserRequest{ID: existing.ID, Email: tt.email})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantVerified, existing.IsEmailVerified())
+			mockRepo.AssertExpectations(t)
+			verifyRepo.AssertExpectations(t)
+			notifier.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_VerifyEmail(t *testing.T) {
+	issuer := newTestTokenIssuer()
+	user := &domain.User{ID: uuid.New(), Email: domain.Email("test@example.com")}
+	plainToken := "plain-verification-token"
+	tokenHash := issuer.HashEmailVerificationToken(plainToken)
+	usedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name      string
+		token     string
+		mockSetup func(*repository.MockUserRepository, *repository.MockEmailVerificationTokenRepository)
+		wantErr   error
+	}{
+		{
+			name:  "正常系：トークンを使用してメールアドレスを確認済みにする",
+			token: plainToken,
+			mockSetup: func(r *repository.MockUserRepository, vr *repository.MockEmailVerificationTokenRepository) {
+				token := domain.NewEmailVerificationToken(user, tokenHash, time.Now().Add(time.Hour))
+				vr.On("GetByHash", mock.Anything, tokenHash).Return(token, nil).Once()
+				vr.On("MarkUsed", mock.Anything, token.ID).Return(nil).Once()
+				r.On("MarkEmailVerified", mock.Anything, user.ID, user.Email).Return(nil).Once()
+			},
+		},
+		{
+			name:      "異常系：トークンが空",
+			token:     "",
+			mockSetup: func(r *repository.MockUserRepository, vr *repository.MockEmailVerificationTokenRepository) {},
+			wantErr:   domain.ErrInvalidToken,
+		},
+		{
+			name:  "異常系：存在しないトークン",
+			token: plainToken,
+			mockSetup: func(r *repository.MockUserRepository, vr *repository.MockEmailVerificationTokenRepository) {
+				vr.On("GetByHash", mock.Anything, tokenHash).Return(nil, domain.ErrNotFound).Once()
+			},
+			wantErr: domain.ErrInvalidToken,
+		},
+		{
+			name:  "異常系：有効期限切れ",
+			token: plainToken,
+			mockSetup: func(r *repository.MockUserRepository, vr *repository.MockEmailVerificationTokenRepository) {
+				token := domain.NewEmailVerificationToken(user, tokenHash, time.Now().Add(-time.Second))
+				vr.On("GetByHash", mock.Anything, tokenHash).Return(token, nil).Once()
+			},
+			wantErr: domain.ErrInvalidToken,
+		},
+		{
+			name:  "異常系：使用済みトークン",
+			token: plainToken,
+			mockSetup: func(r *repository.MockUserRepository, vr *repository.MockEmailVerificationTokenRepository) {
+				token := domain.NewEmailVerificationToken(user, tokenHash, time.Now().Add(time.Hour))
+				token.UsedAt = &usedAt
+				vr.On("GetByHash", mock.Anything, tokenHash).Return(token, nil).Once()
+			},
+			wantErr: domain.ErrInvalidToken,
+		},
+		{
+			name:  "異常系：発行後にメールアドレスが変更された",
+			token: plainToken,
+			mockSetup: func(r *repository.MockUserRepository, vr *repository.MockEmailVerificationTokenRepository) {
+				token := domain.NewEmailVerificationToken(user, tokenHash, time.Now().Add(time.Hour))
+				vr.On("GetByHash", mock.Anything, tokenHash).Return(token, nil).Once()
+				vr.On("MarkUsed", mock.Anything, token.ID).Return(nil).Once()
+				r.On("MarkEmailVerified", mock.Anything, user.ID, user.Email).Return(domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockUserRepository)
+			verifyRepo := new(repository.MockEmailVerificationTokenRepository)
+			tt.mockSetup(mockRepo, verifyRepo)
+			verifier := service.NewEmailVerifier(verifyRepo, issuer, new(MockNotifier), service.DefaultEmailVerificationConfig(), createTestLogger())
+			svc := newTestService(testServiceDeps{repo: mockRepo, issuer: issuer, verifier: verifier})
+
+			err := svc.VerifyEmail(context.Background(), service.VerifyEmailRequest{Token: tt.token})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+			verifyRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_ResendEmailVerification(t *testing.T) {
+	verifiedAt := time.Now()
+	unverified := &domain.User{ID: uuid.New(), Email: domain.Email("unverified@example.com")}
+	verified := &domain.User{ID: uuid.New(), Email: domain.Email("verified@example.com"), EmailVerifiedAt: &verifiedAt}
+
+	tests := []struct {
+		name      string
+		email     domain.Email
+		mockSetup func(*repository.MockUserRepository, *repository.MockEmailVerificationTokenRepository, *MockNotifier)
+		wantErr   error
+	}{
+		{
+			name:  "正常系：未確認のユーザーに再送する",
+			email: " unverified@EXAMPLE.com",
+			mockSetup: func(r *repository.MockUserRepository, vr *repository.MockEmailVerificationTokenRepository, n *MockNotifier) {
+				r.On("GetByEmail", mock.Anything, unverified.Email).Return(unverified, nil).Once()
+				vr.On("CountIssuedSince", mock.Anything, unverified.ID, mock.Anything).Return(1, nil).Once()
+				vr.On("InvalidateAllForUser", mock.Anything, unverified.ID).Return(nil).Once()
+				vr.On("Create", mock.Anything, mock.AnythingOfType("*domain.EmailVerificationToken")).Return(nil).Once()
+				n.On("SendEmailVerification", mock.Anything, unverified, mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name:  "正常系：回数制限に達した場合は送信せずに成功を返す",
+			email: unverified.Email,
+			mockSetup: func(r *repository.MockUserRepository, vr *repository.MockEmailVerificationTokenRepository, n *MockNotifier) {
+				r.On("GetByEmail", mock.Anything, unverified.Email).Return(unverified, nil).Once()
+				vr.On("CountIssuedSince", mock.Anything, unverified.ID, mock.Anything).Return(5, nil).Once()
+			},
+		},
+		{
+			name:  "正常系：確認済みのユーザーには送信しない",
+			email: verified.Email,
+			mockSetup: func(r *repository.MockUserRepository, vr *repository.MockEmailVerificationTokenRepository, n *MockNotifier) {
+				r.On("GetByEmail", mock.Anything, verified.Email).Return(verified, nil).Once()
+			},
+		},
+		{
+			name:  "正常系：存在しないメールアドレスでも成功を返す",
+			email: "unknown@example.com",
+			mockSetup: func(r *repository.MockUserRepository, vr *repository.MockEmailVerificationTokenRepository, n *MockNotifier) {
+				r.On("GetByEmail", mock.Anything, domain.Email("unknown@example.com")).Return(nil, domain.ErrUserNotFound).Once()
+			},
+		},
+		{
+			name:  "異常系：不正なメールアドレス",
+			email: "invalid",
+			mockSetup: func(r *repository.MockUserRepository, vr *repository.MockEmailVerificationTokenRepository, n *MockNotifier) {
+			},
+			wantErr: domain.ErrInvalidEmail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockUserRepository)
+			verifyRepo := new(repository.MockEmailVerificationTokenRepository)
+			notifier := new(MockNotifier)
+			tt.mockSetup(mockRepo, verifyRepo, notifier)
+			verifier := service.NewEmailVerifier(verifyRepo, newTestTokenIssuer(), notifier, service.DefaultEmailVerificationConfig(), createTestLogger())
+			svc := newTestService(testServiceDeps{repo: mockRepo, verifier: verifier})
+
+			err := svc.ResendEmailVerification(context.Background(), service.ResendEmailVerificationRequest{Email: tt.email})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+			verifyRepo.AssertExpectations(t)
+			notifier.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_AuthenticateUser_EmailVerificationRequired(t *testing.T) {
+	verifiedAt := time.Now()
+
+	tests := []struct {
+		name       string
+		required   bool
+		verifiedAt *time.Time
+		wantErr    error
+	}{
+		{name: "正常系：確認済みならログインできる", required: true, verifiedAt: &verifiedAt},
+		{name: "正常系：ポリシーが無効なら未確認でもログインできる", required: false},
+		{name: "異常系：ポリシーが有効なら未確認ではログインできない", required: true, wantErr: domain.ErrEmailNotVerified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockUserRepository)
+			mockTokenRepo := new(repository.MockRefreshTokenRepository)
+			mockHasher := new(MockPasswordHasher)
+			mockThrottleRepo := new(repository.MockLoginThrottleRepository)
+			cfg := service.DefaultEmailVerificationConfig()
+			cfg.Required = tt.required
+			verifier := service.NewEmailVerifier(new(repository.MockEmailVerificationTokenRepository), newTestTokenIssuer(), new(MockNotifier), cfg, createTestLogger())
+			svc := newTestService(testServiceDeps{repo: mockRepo, tokenRepo: mockTokenRepo, hasher: mockHasher, throttleRepo: mockThrottleRepo, verifier: verifier})
+
+			user := &domain.User{ID: uuid.New(), Email: "test@example.com", Password: "hashed", EmailVerifiedAt: tt.verifiedAt}
+			mockRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+			mockHasher.On("Compare", user.Password, "correctPassword").Return(true).Once()
+			mockThrottleRepo.On("Get", mock.Anything, domain.LoginThrottleScopeUser, user.ID.String()).Return(nil, domain.ErrNotFound).Once()
+			mockThrottleRepo.On("Reset", mock.Anything, domain.LoginThrottleScopeUser, user.ID.String()).Return(nil).Once()
+			if tt.wantErr == nil {
+				mockTokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.RefreshToken")).Return(nil).Once()
+			}
+
+			tokens, err := svc.AuthenticateUser(context.Background(), service.AuthenticateUserRequest{Email: user.Email, Password: "correctPassword"})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, tokens)
+			} else {
+				require.NoError(t, err)
+				assert.NotEmpty(t, tokens.AccessToken)
+			}
+			mockRepo.AssertExpectations(t)
+			mockTokenRepo.AssertExpectations(t)
+			mockThrottleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// ========== Role Tests ==========
+
+func TestUserService_GrantRole(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		req       service.GrantRoleRequest
+		mockSetup func(*repository.MockUserRepository)
+		wantErr   error
+	}{
+		{
+			name: "正常系：ロールを付与",
+			req:  service.GrantRoleRequest{UserID: userID, Role: domain.RoleSupport},
+			mockSetup: func(r *repository.MockUserRepository) {
+				r.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID}, nil).Once()
+				r.On("GrantRole", mock.Anything, userID, domain.RoleSupport).Return(nil).Once()
+			},
+		},
+		{
+			name:      "異常系：未知のロール",
+			req:       service.GrantRoleRequest{UserID: userID, Role: "owner"},
+			mockSetup: func(r *repository.MockUserRepository) {},
+			wantErr:   domain.ErrInvalidRole,
+		},
+		{
+			name:      "異常系：IDが空",
+			req:       service.GrantRoleRequest{Role: domain.RoleAdmin},
+			mockSetup: func(r *repository.MockUserRepository) {},
+			wantErr:   domain.ErrInvalidID,
+		},
+		{
+			name: "異常系：ユーザーが存在しない",
+			req:  service.GrantRoleRequest{UserID: userID, Role: domain.RoleAdmin},
+			mockSetup: func(r *repository.MockUserRepository) {
+				r.On("GetByID", mock.Anything, userID).Return(nil, domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockUserRepository)
+			tt.mockSetup(mockRepo)
+			svc := newTestService(testServiceDeps{repo: mockRepo})
+
+			err := svc.GrantRole(context.Background(), tt.req)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_RevokeRole(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		req       service.RevokeRoleRequest
+		mockSetup func(*repository.MockUserRepository)
+		wantErr   error
+	}{
+		{
+			name: "正常系：ロールを剥奪",
+			req:  service.RevokeRoleRequest{UserID: userID, Role: domain.RoleAdmin},
+			mockSetup: func(r *repository.MockUserRepository) {
+				r.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID}, nil).Once()
+				r.On("RevokeRole", mock.Anything, userID, domain.RoleAdmin).Return(nil).Once()
+			},
+		},
+		{
+			name:      "異常系：未知のロール",
+			req:       service.RevokeRoleRequest{UserID: userID, Role: "owner"},
+			mockSetup: func(r *repository.MockUserRepository) {},
+			wantErr:   domain.ErrInvalidRole,
+		},
+		{
+			name: "異常系：ユーザーが存在しない",
+			req:  service.RevokeRoleRequest{UserID: userID, Role: domain.RoleAdmin},
+			mockSetup: func(r *repository.MockUserRepository) {
+				r.On("GetByID", mock.Anything, userID).Return(nil, domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockUserRepository)
+			tt.mockSetup(mockRepo)
+			svc := newTestService(testServiceDeps{repo: mockRepo})
+
+			err := svc.RevokeRole(context.Background(), tt.req)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}